@@ -1,18 +1,23 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"api-web-scrapping/internal/application/usecases"
+	"api-web-scrapping/internal/domain/services"
 	"api-web-scrapping/internal/infrastructure/config"
 	"api-web-scrapping/internal/infrastructure/database"
 	"api-web-scrapping/internal/infrastructure/persistence"
 	"api-web-scrapping/internal/presentation/handlers"
 	"api-web-scrapping/internal/presentation/routes"
 	"api-web-scrapping/pkg/auth"
+	"api-web-scrapping/pkg/tradingcalendar"
 )
 
 func main() {
@@ -43,14 +48,91 @@ func main() {
 
 	// Initialize market data repository
 	marketDataRepo := persistence.NewMarketDataRepository(db)
+	candleRepo := persistence.NewCandleRepository(db)
+	quarantineRepo := persistence.NewQuarantineRepository(db)
+	screenRepo := persistence.NewScreenRepository(db)
+	emitenRepo := persistence.NewEmitenRepository(db)
+	indexRepo := persistence.NewIndexRepository(db)
+	indexMembershipRepo := persistence.NewIndexMembershipRepository(db)
+	corporateActionRepo := persistence.NewCorporateActionRepository(db)
+	tradingStatusRepo := persistence.NewTradingStatusRepository(db)
+	foreignFlowRepo := persistence.NewForeignFlowRepository(db)
+	brokerSummaryRepo := persistence.NewBrokerSummaryRepository(db)
+	orderBookRepo := persistence.NewOrderBookRepository(db)
+
+	// Load the IDX trading calendar; without a holiday list only weekends are closed
+	calendar, err := tradingcalendar.LoadFile(cfg.Market.HolidaysFile)
+	if err != nil {
+		log.Printf("Failed to load trading holidays, using weekends only: %v", err)
+		calendar = tradingcalendar.New(nil)
+	}
+	if now := time.Now(); !calendar.Covers(now) {
+		log.Printf("Warning: no trading holidays configured for %d, only weekends are closed", now.In(tradingcalendar.WIB).Year())
+	}
+
+	// Auto-rejection tiers default to the current IDX rules
+	autoRejection := services.DefaultAutoRejectionRules()
+	if cfg.Market.AutoRejectionFile != "" {
+		data, err := os.ReadFile(cfg.Market.AutoRejectionFile)
+		if err == nil {
+			autoRejection, err = services.ParseAutoRejectionRules(data)
+		}
+		if err != nil {
+			log.Fatalf("Failed to load auto-rejection rules: %v", err)
+		}
+	}
 
 	// Initialize use cases
 	authUseCase := usecases.NewAuthUseCase(userRepo, jwtManager)
-	marketDataUseCase := usecases.NewMarketDataUseCase(marketDataRepo)
+	marketDataUseCase := usecases.NewMarketDataUseCase(marketDataRepo, emitenRepo, corporateActionRepo, tradingStatusRepo, autoRejection)
+	candleUseCase := usecases.NewCandleUseCase(marketDataRepo, candleRepo, quarantineRepo, corporateActionRepo, cfg.Candle.RollupEnabled)
+	indicatorUseCase := usecases.NewIndicatorUseCase(marketDataRepo, corporateActionRepo)
+	screenerUseCase := usecases.NewScreenerUseCase(marketDataRepo)
+	screenUseCase := usecases.NewScreenUseCase(screenRepo, screenerUseCase)
+	emitenUseCase := usecases.NewEmitenUseCase(emitenRepo)
+	marketUseCase := usecases.NewMarketUseCase(marketDataRepo, emitenRepo, tradingStatusRepo, calendar, autoRejection)
+	indexUseCase := usecases.NewIndexUseCase(indexRepo, marketDataRepo, emitenRepo)
+	indexMembershipUseCase := usecases.NewIndexMembershipUseCase(indexMembershipRepo)
+	analyticsUseCase := usecases.NewAnalyticsUseCase(marketDataRepo, emitenRepo)
+	corporateActionUseCase := usecases.NewCorporateActionUseCase(corporateActionRepo)
+	dividendUseCase := usecases.NewDividendUseCase(corporateActionRepo, marketDataRepo)
+	tradingStatusUseCase := usecases.NewTradingStatusUseCase(tradingStatusRepo)
+	foreignFlowUseCase := usecases.NewForeignFlowUseCase(foreignFlowRepo)
+	brokerSummaryUseCase := usecases.NewBrokerSummaryUseCase(brokerSummaryRepo)
+	orderBookUseCase := usecases.NewOrderBookUseCase(orderBookRepo)
 
 	// Initialize handlers
 	authHandler := handlers.NewAuthHandler(authUseCase)
 	marketDataHandler := handlers.NewMarketDataHandler(marketDataUseCase)
+	candleHandler := handlers.NewCandleHandler(candleUseCase)
+	indicatorHandler := handlers.NewIndicatorHandler(indicatorUseCase)
+	screenerHandler := handlers.NewScreenerHandler(screenerUseCase)
+	screenHandler := handlers.NewScreenHandler(screenUseCase)
+	emitenHandler := handlers.NewEmitenHandler(emitenUseCase)
+	marketHandler := handlers.NewMarketHandler(marketUseCase)
+	indexHandler := handlers.NewIndexHandler(indexUseCase)
+	indexMembershipHandler := handlers.NewIndexMembershipHandler(indexMembershipUseCase)
+	analyticsHandler := handlers.NewAnalyticsHandler(analyticsUseCase)
+	corporateActionHandler := handlers.NewCorporateActionHandler(corporateActionUseCase)
+	dividendHandler := handlers.NewDividendHandler(dividendUseCase)
+	tradingStatusHandler := handlers.NewTradingStatusHandler(tradingStatusUseCase)
+	foreignFlowHandler := handlers.NewForeignFlowHandler(foreignFlowUseCase)
+	brokerSummaryHandler := handlers.NewBrokerSummaryHandler(brokerSummaryUseCase)
+	orderBookHandler := handlers.NewOrderBookHandler(orderBookUseCase)
+
+	// Start background workers
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go candleUseCase.StartRollupWorker(ctx, cfg.Candle.RollupInterval)
+
+	if cfg.Screen.SchedulerEnabled {
+		go screenUseCase.StartScheduler(ctx, cfg.Screen.SchedulerInterval)
+	}
+
+	if cfg.OrderBook.PruneEnabled {
+		go orderBookUseCase.StartRetentionWorker(ctx, cfg.OrderBook.Retention, cfg.OrderBook.PruneInterval)
+	}
 
 	// Setup Gin
 	if cfg.Auth.JWTSecret == "your-secret-key-change-in-production" {
@@ -61,7 +143,28 @@ func main() {
 	r := gin.Default()
 
 	// Setup routes
-	routes.SetupRoutes(r, authHandler, marketDataHandler)
+	routes.SetupRoutes(
+		r,
+		jwtManager,
+		cfg.Auth.AdminEmails,
+		authHandler,
+		marketDataHandler,
+		candleHandler,
+		indicatorHandler,
+		screenerHandler,
+		screenHandler,
+		emitenHandler,
+		marketHandler,
+		indexHandler,
+		indexMembershipHandler,
+		analyticsHandler,
+		corporateActionHandler,
+		dividendHandler,
+		tradingStatusHandler,
+		foreignFlowHandler,
+		brokerSummaryHandler,
+		orderBookHandler,
+	)
 
 	// Start server
 	log.Printf("Starting server on %s", cfg.Server.Port)
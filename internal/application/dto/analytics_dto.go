@@ -0,0 +1,138 @@
+package dto
+
+// CorrelationRequest represents the body of a correlation request. Lookback
+// is the number of trading sessions of returns to use, default 90.
+type CorrelationRequest struct {
+	Emitens  []string `json:"emitens" binding:"required,min=2"`
+	Lookback int      `json:"lookback"`
+}
+
+// CorrelationResponse represents pairwise statistics of daily log returns.
+// Row and column i of every matrix refer to Emitens[i]. Each pair uses the
+// sessions where both emitens have a return, counted in Observations;
+// statistics are null when a pair has fewer than two such sessions or a
+// series does not move.
+type CorrelationResponse struct {
+	Emitens      []string     `json:"emitens"`
+	Lookback     int          `json:"lookback"`
+	From         string       `json:"from,omitempty"`
+	To           string       `json:"to,omitempty"`
+	Observations [][]int      `json:"observations"`
+	Pearson      [][]*float64 `json:"pearson"`
+	Spearman     [][]*float64 `json:"spearman"`
+	Covariance   [][]*float64 `json:"covariance"`
+}
+
+// RiskRequest represents the query parameters of a risk request. Window is
+// the number of daily returns used, default 252; Benchmark is an optional
+// emiten or index symbol in the market data to compute beta against.
+type RiskRequest struct {
+	Window    int    `form:"window"`
+	Benchmark string `form:"benchmark"`
+}
+
+// DrawdownResponse represents the largest peak-to-trough fall in the window
+type DrawdownResponse struct {
+	DrawdownPct float64 `json:"drawdown_pct"`
+	PeakDate    string  `json:"peak_date"`
+	PeakClose   float64 `json:"peak_close"`
+	TroughDate  string  `json:"trough_date"`
+	TroughClose float64 `json:"trough_close"`
+}
+
+// BetaResponse represents the beta of an emiten against a benchmark over the
+// sessions where both have a return
+type BetaResponse struct {
+	Benchmark    string   `json:"benchmark"`
+	Beta         *float64 `json:"beta"`
+	Observations int      `json:"observations"`
+}
+
+// RiskResponse represents risk metrics of an emiten over its last Window
+// daily returns. Volatilities and downside deviation are annualized over
+// 252 sessions; metrics are null when the window has too little data.
+type RiskResponse struct {
+	Emiten                 string            `json:"emiten"`
+	Window                 int               `json:"window"`
+	Observations           int               `json:"observations"`
+	From                   string            `json:"from"`
+	To                     string            `json:"to"`
+	LastClose              float64           `json:"last_close"`
+	VolatilityPct          *float64          `json:"volatility_pct"`
+	ParkinsonVolatilityPct *float64          `json:"parkinson_volatility_pct"`
+	DownsideDeviationPct   *float64          `json:"downside_deviation_pct"`
+	ATRPeriod              int               `json:"atr_period"`
+	ATR                    *float64          `json:"atr"`
+	ATRPct                 *float64          `json:"atr_pct"`
+	MaxDrawdown            *DrawdownResponse `json:"max_drawdown"`
+	Beta                   *BetaResponse     `json:"beta,omitempty"`
+}
+
+// CompareRequest represents the query parameters of a performance
+// comparison. Emiten is a comma-separated list of tickers; Base is the value
+// every series starts from, default 100.
+type CompareRequest struct {
+	Emiten    string  `form:"emiten" binding:"required"`
+	Base      float64 `form:"base"`
+	Benchmark string  `form:"benchmark"`
+}
+
+// ComparePerformance represents the performance of one series over the
+// compared range. RelativeStrengthPct is its outperformance of the benchmark:
+// the ratio of their growth, minus one, in percent.
+type ComparePerformance struct {
+	Emiten              string   `json:"emiten"`
+	StartClose          float64  `json:"start_close"`
+	EndClose            float64  `json:"end_close"`
+	TotalReturnPct      float64  `json:"total_return_pct"`
+	CAGRPct             *float64 `json:"cagr_pct"`
+	RelativeStrengthPct *float64 `json:"relative_strength_pct,omitempty"`
+}
+
+// ComparePoint represents the rebased value of every series on one session
+type ComparePoint struct {
+	Date   string             `json:"date"`
+	Values map[string]float64 `json:"values"`
+}
+
+// CompareResponse represents date-aligned series rebased to a common start
+type CompareResponse struct {
+	Base        float64              `json:"base"`
+	Benchmark   string               `json:"benchmark,omitempty"`
+	From        string               `json:"from"`
+	To          string               `json:"to"`
+	Performance []ComparePerformance `json:"performance"`
+	Data        []ComparePoint       `json:"data"`
+}
+
+// PriceLevelResponse represents a reference price, the session it was
+// reached and the distance of the last close from it in percent
+type PriceLevelResponse struct {
+	Price       float64 `json:"price"`
+	Date        string  `json:"date"`
+	DistancePct float64 `json:"distance_pct"`
+}
+
+// AverageCloseResponse represents the average close over a number of
+// sessions and the distance of the last close from it in percent. Both are
+// null when the emiten has fewer sessions.
+type AverageCloseResponse struct {
+	Sessions    int      `json:"sessions"`
+	Value       *float64 `json:"value"`
+	DistancePct *float64 `json:"distance_pct"`
+}
+
+// PriceSummaryResponse represents the last session of an emiten against its
+// history. YTD fields are null when the history does not reach the previous
+// year.
+type PriceSummaryResponse struct {
+	Emiten        string                 `json:"emiten"`
+	Date          string                 `json:"date"`
+	Close         float64                `json:"close"`
+	High52w       PriceLevelResponse     `json:"high_52w"`
+	Low52w        PriceLevelResponse     `json:"low_52w"`
+	AverageCloses []AverageCloseResponse `json:"average_closes"`
+	AllTimeHigh   PriceLevelResponse     `json:"all_time_high"`
+	YTDBaseClose  *float64               `json:"ytd_base_close"`
+	YTDReturnPct  *float64               `json:"ytd_return_pct"`
+}
@@ -0,0 +1,58 @@
+package dto
+
+// BrokerSummaryRequest represents the trading of a broker in an emiten on
+// one session. Date is a YYYY-MM-DD date, lots are of 100 shares and values
+// in rupiah.
+type BrokerSummaryRequest struct {
+	Emiten    string  `json:"emiten" binding:"required"`
+	Date      string  `json:"date" binding:"required"`
+	Broker    string  `json:"broker" binding:"required"`
+	BuyLots   int64   `json:"buy_lots"`
+	SellLots  int64   `json:"sell_lots"`
+	BuyValue  float64 `json:"buy_value"`
+	SellValue float64 `json:"sell_value"`
+}
+
+// BrokerSummaryBatchRequest represents the body of a bulk broker summary
+// ingest request
+type BrokerSummaryBatchRequest struct {
+	Data []BrokerSummaryRequest `json:"data" binding:"required"`
+}
+
+// BrokerActivityResponse represents the trading of a broker in an emiten
+// summed over a date range. Only the side that varies is set: the broker in
+// an emiten's ranking and the emiten in a broker's. Average prices are per
+// share, null without lots on that side.
+type BrokerActivityResponse struct {
+	Broker       string   `json:"broker,omitempty"`
+	Emiten       string   `json:"emiten,omitempty"`
+	Days         int      `json:"days"`
+	BuyLots      int64    `json:"buy_lots"`
+	SellLots     int64    `json:"sell_lots"`
+	NetLots      int64    `json:"net_lots"`
+	BuyValue     float64  `json:"buy_value"`
+	SellValue    float64  `json:"sell_value"`
+	NetValue     float64  `json:"net_value"`
+	BuyAvgPrice  *float64 `json:"buy_avg_price"`
+	SellAvgPrice *float64 `json:"sell_avg_price"`
+}
+
+// BrokerRankingResponse represents the brokers with the largest net buy and
+// net sell values in an emiten over a date range
+type BrokerRankingResponse struct {
+	Emiten     string                   `json:"emiten"`
+	From       string                   `json:"from"`
+	To         string                   `json:"to"`
+	NetBuyers  []BrokerActivityResponse `json:"net_buyers"`
+	NetSellers []BrokerActivityResponse `json:"net_sellers"`
+}
+
+// BrokerAccumulationResponse represents the emitens a broker accumulated
+// (net bought) and distributed (net sold) most by value over a date range
+type BrokerAccumulationResponse struct {
+	Broker      string                   `json:"broker"`
+	From        string                   `json:"from"`
+	To          string                   `json:"to"`
+	Accumulated []BrokerActivityResponse `json:"accumulated"`
+	Distributed []BrokerActivityResponse `json:"distributed"`
+}
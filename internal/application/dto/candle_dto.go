@@ -0,0 +1,45 @@
+package dto
+
+import "time"
+
+// CandleResponse represents a single OHLC candle. Volume is in shares.
+type CandleResponse struct {
+	Time        time.Time `json:"time"`
+	Open        float64   `json:"open"`
+	High        float64   `json:"high"`
+	Low         float64   `json:"low"`
+	Close       float64   `json:"close"`
+	Volume      float64   `json:"volume"`
+	SampleCount int       `json:"sample_count"`
+}
+
+// CandleListResponse represents the candle series of an emiten
+type CandleListResponse struct {
+	Emiten   string           `json:"emiten"`
+	Interval string           `json:"interval"`
+	Adjusted bool             `json:"adjusted,omitempty"`
+	Data     []CandleResponse `json:"data"`
+}
+
+// QuarantinedMarketDataResponse represents a snapshot held back from the
+// rollups and the validation failures behind it
+type QuarantinedMarketDataResponse struct {
+	ID           int64     `json:"id"`
+	MarketDataID int64     `json:"market_data_id"`
+	Emiten       string    `json:"emiten"`
+	OpenPrice    float64   `json:"open_price"`
+	HighPrice    float64   `json:"high_price"`
+	LowPrice     float64   `json:"low_price"`
+	ClosePrice   float64   `json:"close_price"`
+	Date         time.Time `json:"date"`
+	Reasons      []string  `json:"reasons"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// QuarantineListResponse represents the quarantined snapshots of a time range
+type QuarantineListResponse struct {
+	From  time.Time                       `json:"from"`
+	To    time.Time                       `json:"to"`
+	Count int                             `json:"count"`
+	Data  []QuarantinedMarketDataResponse `json:"data"`
+}
@@ -4,4 +4,20 @@ package dto
 type ErrorResponse struct {
 	Error   string `json:"error"`
 	Message string `json:"message"`
+	// Position is the 1-based column of an invalid expression, when known
+	Position int `json:"position,omitempty"`
+}
+
+// ImportError describes a CSV row that could not be imported
+type ImportError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// ImportResponse represents the outcome of a bulk CSV import. Valid rows are
+// imported even when other rows fail.
+type ImportResponse struct {
+	Imported int           `json:"imported"`
+	Failed   int           `json:"failed"`
+	Errors   []ImportError `json:"errors"`
 }
@@ -0,0 +1,46 @@
+package dto
+
+import "time"
+
+// CorporateActionRequest represents the body of a create or update corporate
+// action request. ExDate is a YYYY-MM-DD date. Ratio, such as "1:5", applies
+// to every type but cash_dividend, which takes Amount per share instead;
+// Price is the exercise price of a rights issue. The other dates are
+// optional YYYY-MM-DD dates; PaymentDate only applies to cash dividends.
+type CorporateActionRequest struct {
+	Emiten           string  `json:"emiten" binding:"required"`
+	Type             string  `json:"type" binding:"required"`
+	ExDate           string  `json:"ex_date" binding:"required"`
+	AnnouncementDate string  `json:"announcement_date"`
+	CumDate          string  `json:"cum_date"`
+	RecordingDate    string  `json:"recording_date"`
+	PaymentDate      string  `json:"payment_date"`
+	Ratio            string  `json:"ratio"`
+	Price            float64 `json:"price"`
+	Amount           float64 `json:"amount"`
+	Description      string  `json:"description"`
+}
+
+// CorporateActionResponse represents a corporate action
+type CorporateActionResponse struct {
+	ID               int64     `json:"id"`
+	Emiten           string    `json:"emiten"`
+	Type             string    `json:"type"`
+	ExDate           string    `json:"ex_date"`
+	AnnouncementDate *string   `json:"announcement_date,omitempty"`
+	CumDate          *string   `json:"cum_date,omitempty"`
+	RecordingDate    *string   `json:"recording_date,omitempty"`
+	PaymentDate      *string   `json:"payment_date,omitempty"`
+	Ratio            string    `json:"ratio,omitempty"`
+	Price            float64   `json:"price,omitempty"`
+	Amount           float64   `json:"amount,omitempty"`
+	Description      string    `json:"description"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// CorporateActionListResponse represents corporate actions ordered by ex-date
+type CorporateActionListResponse struct {
+	Count int                       `json:"count"`
+	Data  []CorporateActionResponse `json:"data"`
+}
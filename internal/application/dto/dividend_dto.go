@@ -0,0 +1,65 @@
+package dto
+
+import "time"
+
+// DividendResponse represents a cash dividend and its schedule. YieldPct is
+// the amount against the latest close, null without a close.
+type DividendResponse struct {
+	ID               int64    `json:"id"`
+	Emiten           string   `json:"emiten"`
+	Amount           float64  `json:"amount"`
+	AnnouncementDate *string  `json:"announcement_date,omitempty"`
+	CumDate          *string  `json:"cum_date,omitempty"`
+	ExDate           string   `json:"ex_date"`
+	RecordingDate    *string  `json:"recording_date,omitempty"`
+	PaymentDate      *string  `json:"payment_date,omitempty"`
+	YieldPct         *float64 `json:"yield_pct"`
+	Description      string   `json:"description"`
+}
+
+// DividendCalendarResponse represents the dividends with a cum, ex,
+// recording or payment date in a date range
+type DividendCalendarResponse struct {
+	From  string             `json:"from"`
+	To    string             `json:"to"`
+	Count int                `json:"count"`
+	Data  []DividendResponse `json:"data"`
+}
+
+// AnnualDividendResponse represents the dividends of an emiten by ex-date
+// year, restated for later splits and bonus issues
+type AnnualDividendResponse struct {
+	Year   int     `json:"year"`
+	Amount float64 `json:"amount"`
+	Count  int     `json:"count"`
+}
+
+// DividendHistoryResponse represents the dividends of an emiten, newest
+// first, with its trailing-12-month dividend and yield
+type DividendHistoryResponse struct {
+	Emiten      string                   `json:"emiten"`
+	LatestClose *float64                 `json:"latest_close"`
+	LatestDate  *time.Time               `json:"latest_date"`
+	TTMDividend float64                  `json:"ttm_dividend"`
+	TTMYieldPct *float64                 `json:"ttm_yield_pct"`
+	Annual      []AnnualDividendResponse `json:"annual"`
+	Data        []DividendResponse       `json:"data"`
+}
+
+// DividendYieldResponse represents the trailing-12-month dividend yield of
+// an emiten
+type DividendYieldResponse struct {
+	Emiten      string  `json:"emiten"`
+	Close       float64 `json:"close"`
+	TTMDividend float64 `json:"ttm_dividend"`
+	TTMYieldPct float64 `json:"ttm_yield_pct"`
+	Dividends   int     `json:"dividends"`
+}
+
+// DividendYieldListResponse represents emitens ranked by trailing-12-month
+// dividend yield
+type DividendYieldListResponse struct {
+	AsOf  string                  `json:"as_of"`
+	Count int                     `json:"count"`
+	Data  []DividendYieldResponse `json:"data"`
+}
@@ -0,0 +1,52 @@
+package dto
+
+import "time"
+
+// EmitenRequest represents the body of a create or update emiten request.
+// ListingDate is formatted as YYYY-MM-DD.
+type EmitenRequest struct {
+	Ticker            string `json:"ticker"`
+	CompanyName       string `json:"company_name" binding:"required"`
+	Sector            string `json:"sector"`
+	SubIndustry       string `json:"sub_industry"`
+	Board             string `json:"board"`
+	ListingDate       string `json:"listing_date"`
+	SharesOutstanding int64  `json:"shares_outstanding"`
+	IsSharia          bool   `json:"is_sharia"`
+}
+
+// EmitenResponse represents emiten master data
+type EmitenResponse struct {
+	Ticker            string     `json:"ticker"`
+	CompanyName       string     `json:"company_name"`
+	Sector            string     `json:"sector"`
+	SubIndustry       string     `json:"sub_industry"`
+	Board             string     `json:"board"`
+	ListingDate       *time.Time `json:"listing_date,omitempty"`
+	SharesOutstanding int64      `json:"shares_outstanding"`
+	IsSharia          bool       `json:"is_sharia"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// EmitenListResponse represents the list response for emitens
+type EmitenListResponse struct {
+	Data []EmitenResponse `json:"data"`
+}
+
+// EmitenSearchResult represents an emiten matching a search query. MatchedOn
+// is "ticker" or "name"; a higher score is a better match.
+type EmitenSearchResult struct {
+	Ticker      string `json:"ticker"`
+	CompanyName string `json:"company_name"`
+	Sector      string `json:"sector"`
+	Board       string `json:"board"`
+	MatchedOn   string `json:"matched_on"`
+	Score       int    `json:"score"`
+}
+
+// EmitenSearchResponse represents the results of an emiten search
+type EmitenSearchResponse struct {
+	Query string               `json:"query"`
+	Data  []EmitenSearchResult `json:"data"`
+}
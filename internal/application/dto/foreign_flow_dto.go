@@ -0,0 +1,64 @@
+package dto
+
+// ForeignFlowRequest represents the foreign trading of an emiten on one
+// session. Date is a YYYY-MM-DD date, volumes are in shares and values in
+// rupiah.
+type ForeignFlowRequest struct {
+	Emiten     string  `json:"emiten" binding:"required"`
+	Date       string  `json:"date" binding:"required"`
+	BuyVolume  int64   `json:"buy_volume"`
+	SellVolume int64   `json:"sell_volume"`
+	BuyValue   float64 `json:"buy_value"`
+	SellValue  float64 `json:"sell_value"`
+}
+
+// ForeignFlowBatchRequest represents the body of a bulk foreign flow
+// ingest request
+type ForeignFlowBatchRequest struct {
+	Data []ForeignFlowRequest `json:"data" binding:"required"`
+}
+
+// ForeignFlowResponse represents the foreign flow of an emiten on one
+// session; a positive net is a net buy
+type ForeignFlowResponse struct {
+	Emiten     string  `json:"emiten"`
+	Date       string  `json:"date"`
+	BuyVolume  int64   `json:"buy_volume"`
+	SellVolume int64   `json:"sell_volume"`
+	NetVolume  int64   `json:"net_volume"`
+	BuyValue   float64 `json:"buy_value"`
+	SellValue  float64 `json:"sell_value"`
+	NetValue   float64 `json:"net_value"`
+}
+
+// ForeignFlowTotalResponse represents foreign flows summed over a date range
+type ForeignFlowTotalResponse struct {
+	Emiten     string  `json:"emiten,omitempty"`
+	Days       int     `json:"days"`
+	BuyVolume  int64   `json:"buy_volume"`
+	SellVolume int64   `json:"sell_volume"`
+	NetVolume  int64   `json:"net_volume"`
+	BuyValue   float64 `json:"buy_value"`
+	SellValue  float64 `json:"sell_value"`
+	NetValue   float64 `json:"net_value"`
+}
+
+// ForeignFlowHistoryResponse represents the daily foreign flows of an emiten
+// over a date range with their total
+type ForeignFlowHistoryResponse struct {
+	Emiten string                   `json:"emiten"`
+	From   string                   `json:"from"`
+	To     string                   `json:"to"`
+	Count  int                      `json:"count"`
+	Total  ForeignFlowTotalResponse `json:"total"`
+	Data   []ForeignFlowResponse    `json:"data"`
+}
+
+// ForeignFlowRankingResponse represents the emitens with the largest foreign
+// net buy and net sell values over a date range
+type ForeignFlowRankingResponse struct {
+	From     string                     `json:"from"`
+	To       string                     `json:"to"`
+	NetBuys  []ForeignFlowTotalResponse `json:"net_buys"`
+	NetSells []ForeignFlowTotalResponse `json:"net_sells"`
+}
@@ -0,0 +1,72 @@
+package dto
+
+import "time"
+
+// IndexConstituentRequest represents a constituent of an index request.
+// FreeFloat is the free-float ratio in (0, 1], used by free_float weighting.
+type IndexConstituentRequest struct {
+	Emiten    string  `json:"emiten" binding:"required"`
+	FreeFloat float64 `json:"free_float"`
+}
+
+// IndexRequest represents the body of a create index request. BaseDate is
+// formatted as YYYY-MM-DD; BaseValue defaults to 100.
+type IndexRequest struct {
+	Code         string                    `json:"code" binding:"required"`
+	Name         string                    `json:"name" binding:"required"`
+	Weighting    string                    `json:"weighting" binding:"required"`
+	BaseDate     string                    `json:"base_date" binding:"required"`
+	BaseValue    float64                   `json:"base_value"`
+	Constituents []IndexConstituentRequest `json:"constituents" binding:"required,min=1,dive"`
+}
+
+// IndexUpdateRequest represents the body of an update index request
+type IndexUpdateRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// IndexRebalanceRequest represents a change of index membership taking
+// effect from the session of EffectiveDate (YYYY-MM-DD, default today)
+type IndexRebalanceRequest struct {
+	EffectiveDate string                    `json:"effective_date"`
+	Constituents  []IndexConstituentRequest `json:"constituents" binding:"required,min=1,dive"`
+}
+
+// IndexConstituentResponse represents a constituent with its fixed weight
+type IndexConstituentResponse struct {
+	Emiten    string  `json:"emiten"`
+	FreeFloat float64 `json:"free_float,omitempty"`
+	Weight    float64 `json:"weight"`
+}
+
+// IndexResponse represents a composite index with its current constituents
+type IndexResponse struct {
+	Code         string                     `json:"code"`
+	Name         string                     `json:"name"`
+	Weighting    string                     `json:"weighting"`
+	BaseDate     string                     `json:"base_date"`
+	BaseValue    float64                    `json:"base_value"`
+	Divisor      float64                    `json:"divisor"`
+	Constituents []IndexConstituentResponse `json:"constituents"`
+	CreatedAt    time.Time                  `json:"created_at"`
+	UpdatedAt    time.Time                  `json:"updated_at"`
+}
+
+// IndexListResponse represents the list response for indices
+type IndexListResponse struct {
+	Data []IndexResponse `json:"data"`
+}
+
+// IndexDivisorResponse represents a period of an index with its divisor
+type IndexDivisorResponse struct {
+	EffectiveFrom string                     `json:"effective_from"`
+	Divisor       float64                    `json:"divisor"`
+	Reason        string                     `json:"reason"`
+	Constituents  []IndexConstituentResponse `json:"constituents"`
+}
+
+// IndexDivisorListResponse represents the divisor history of an index
+type IndexDivisorListResponse struct {
+	Code string                 `json:"code"`
+	Data []IndexDivisorResponse `json:"data"`
+}
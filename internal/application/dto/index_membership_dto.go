@@ -0,0 +1,38 @@
+package dto
+
+import "time"
+
+// IndexMembershipRequest represents the body of a create or update index
+// membership request. From and To are inclusive YYYY-MM-DD dates; an empty
+// To means the emiten is still a member.
+type IndexMembershipRequest struct {
+	Index  string `json:"index" binding:"required"`
+	Emiten string `json:"emiten" binding:"required"`
+	From   string `json:"from" binding:"required"`
+	To     string `json:"to"`
+}
+
+// IndexMembershipResponse represents an effective-dated index membership
+type IndexMembershipResponse struct {
+	ID        int64     `json:"id"`
+	Index     string    `json:"index"`
+	Emiten    string    `json:"emiten"`
+	From      string    `json:"from"`
+	To        *string   `json:"to"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// IndexMembershipListResponse represents the membership history of an index
+type IndexMembershipListResponse struct {
+	Index string                    `json:"index"`
+	Data  []IndexMembershipResponse `json:"data"`
+}
+
+// IndexMembersResponse represents the members of an index on a date
+type IndexMembersResponse struct {
+	Index string                    `json:"index"`
+	AsOf  string                    `json:"as_of"`
+	Count int                       `json:"count"`
+	Data  []IndexMembershipResponse `json:"data"`
+}
@@ -0,0 +1,36 @@
+package dto
+
+import "time"
+
+// IndicatorRequest represents the query parameters of an indicator request.
+// Parameters that do not apply to the chosen indicator are ignored and
+// missing ones fall back to the indicator's conventional defaults.
+type IndicatorRequest struct {
+	Name       string  `form:"name" binding:"required"`
+	Period     int     `form:"period"`
+	Fast       int     `form:"fast"`
+	Slow       int     `form:"slow"`
+	Signal     int     `form:"signal"`
+	Smooth     int     `form:"smooth"`
+	Multiplier float64 `form:"multiplier"`
+	// Adjusted computes the indicator over closes adjusted for corporate
+	// actions
+	Adjusted bool `form:"adjusted"`
+}
+
+// IndicatorPoint represents the indicator values of a single trading day.
+// Values are null while the indicator is still warming up.
+type IndicatorPoint struct {
+	Date   time.Time           `json:"date"`
+	Values map[string]*float64 `json:"values"`
+}
+
+// IndicatorResponse represents an indicator series computed over daily closes
+type IndicatorResponse struct {
+	Emiten        string             `json:"emiten"`
+	Indicator     string             `json:"indicator"`
+	Params        map[string]float64 `json:"params"`
+	WarmUpPeriods int                `json:"warmup_periods"`
+	Adjusted      bool               `json:"adjusted,omitempty"`
+	Data          []IndicatorPoint   `json:"data"`
+}
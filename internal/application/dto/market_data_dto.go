@@ -2,20 +2,70 @@ package dto
 
 import "time"
 
-// MarketDataResponse represents the response for market data
+// MarketDataResponse represents the response for market data. The volume,
+// value, frequency and VWAP fields are the session totals as of the scrape
+// and are omitted for snapshots without them.
 type MarketDataResponse struct {
-	ID         int64      `json:"id"`
-	Emiten     string     `json:"emiten"`
-	OpenPrice  float64    `json:"open_price"`
-	HighPrice  float64    `json:"high_price"`
-	LowPrice   float64    `json:"low_price"`
-	ClosePrice float64    `json:"close_price"`
-	Date       time.Time  `json:"date"`
-	CreatedAt  time.Time  `json:"created_at"`
-	UpdatedAt  time.Time  `json:"updated_at"`
+	ID         int64           `json:"id"`
+	Emiten     string          `json:"emiten"`
+	OpenPrice  float64         `json:"open_price"`
+	HighPrice  float64         `json:"high_price"`
+	LowPrice   float64         `json:"low_price"`
+	ClosePrice float64         `json:"close_price"`
+	Volume     *int64          `json:"volume,omitempty"`
+	VolumeLots *int64          `json:"volume_lots,omitempty"`
+	Value      *float64        `json:"value,omitempty"`
+	Frequency  *int64          `json:"frequency,omitempty"`
+	VWAP       *float64        `json:"vwap,omitempty"`
+	Date       time.Time       `json:"date"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+	Company    *EmitenResponse `json:"company,omitempty"`
+	// AutoRejection is set with ?include=limits when the previous close is known
+	AutoRejection *AutoRejectionResponse `json:"auto_rejection,omitempty"`
+	// TradingStatus is the status on the item's session for history and as of
+	// the query for the latest endpoints
+	TradingStatus *TradingStatusFlags `json:"trading_status,omitempty"`
+}
+
+// AutoRejectionResponse represents the auto-rejection limits of the session
+// of a market data item. ARA (ARB) is true when the high (low) reached the
+// upper (lower) limit.
+type AutoRejectionResponse struct {
+	PrevClose  float64 `json:"prev_close"`
+	UpperLimit float64 `json:"upper_limit"`
+	LowerLimit float64 `json:"lower_limit"`
+	ARA        bool    `json:"ara"`
+	ARB        bool    `json:"arb"`
 }
 
 // MarketDataListResponse represents the list response for market data
 type MarketDataListResponse struct {
-	Data []MarketDataResponse `json:"data"`
+	// AsOf is set when the list reproduces the latest data at a past instant
+	AsOf *time.Time `json:"as_of,omitempty"`
+	// Adjusted is set when prices are adjusted for corporate actions
+	Adjusted bool                 `json:"adjusted,omitempty"`
+	Data     []MarketDataResponse `json:"data"`
+}
+
+// MarketDataQuery represents optional query parameters shared by the market
+// data endpoints
+type MarketDataQuery struct {
+	// IncludeCompany embeds emiten master data in each item (?include=company)
+	IncludeCompany bool
+	// IncludeLimits adds auto-rejection limits and flags (?include=limits)
+	IncludeLimits bool
+	// Adjusted scales prices for corporate actions (?adjusted=true); only
+	// the emiten history endpoint honours it
+	Adjusted bool
+	// AsOf resolves the latest data as it was at an instant (?as_of=);
+	// zero means now. Only the latest endpoints honour it.
+	AsOf time.Time
+	// ExcludeSuspended, ExcludeDelisted, ExcludeNotations and
+	// ExcludeWatchlist drop list items by trading status
+	// (?exclude=suspended,delisted,notations,watchlist)
+	ExcludeSuspended bool
+	ExcludeDelisted  bool
+	ExcludeNotations bool
+	ExcludeWatchlist bool
 }
@@ -0,0 +1,104 @@
+package dto
+
+import "time"
+
+// SectorContributor represents the emiten that moved a sector the most
+type SectorContributor struct {
+	Emiten    string  `json:"emiten"`
+	ChangePct float64 `json:"change_pct"`
+	// Contribution is in percentage points of the sector's market-cap-weighted change
+	Contribution float64 `json:"contribution"`
+}
+
+// SectorResponse represents the aggregated latest moves of a sector. Change
+// percentages are null when no emiten has the data to compute them.
+type SectorResponse struct {
+	Sector                 string             `json:"sector"`
+	EmitenCount            int                `json:"emiten_count"`
+	Advancers              int                `json:"advancers"`
+	Decliners              int                `json:"decliners"`
+	Unchanged              int                `json:"unchanged"`
+	MarketCap              float64            `json:"market_cap"`
+	ChangePct              *float64           `json:"change_pct"`
+	EqualWeightedChangePct *float64           `json:"equal_weighted_change_pct"`
+	TopContributor         *SectorContributor `json:"top_contributor"`
+}
+
+// SectorListResponse represents the sector heatmap as of the latest data
+type SectorListResponse struct {
+	Date time.Time        `json:"date"`
+	Data []SectorResponse `json:"data"`
+}
+
+// BreadthPoint represents the market breadth of one trading day. ADLine is
+// the cumulative net advances since the start of the series.
+type BreadthPoint struct {
+	Date        string `json:"date"`
+	Advancers   int    `json:"advancers"`
+	Decliners   int    `json:"decliners"`
+	Unchanged   int    `json:"unchanged"`
+	NewHighs    int    `json:"new_highs"`
+	NewLows     int    `json:"new_lows"`
+	NetAdvances int    `json:"net_advances"`
+	ADLine      int    `json:"ad_line"`
+}
+
+// BreadthResponse represents a daily market breadth series
+type BreadthResponse struct {
+	From time.Time      `json:"from"`
+	To   time.Time      `json:"to"`
+	Data []BreadthPoint `json:"data"`
+}
+
+// MarketStatusResponse represents the IDX trading phase at an instant, in
+// WIB. IsOpen is true while orders are accepted, including the opening and
+// closing auctions.
+type MarketStatusResponse struct {
+	Time               time.Time `json:"time"`
+	Phase              string    `json:"phase"`
+	IsOpen             bool      `json:"is_open"`
+	IsTradingDay       bool      `json:"is_trading_day"`
+	Holiday            string    `json:"holiday,omitempty"`
+	NextOpen           time.Time `json:"next_open"`
+	NextClose          time.Time `json:"next_close"`
+	PreviousTradingDay string    `json:"previous_trading_day"`
+}
+
+// AutoRejectionStock represents an emiten that reached an auto-rejection
+// limit. ClosedAtLimit is true when its last price is still at the limit.
+type AutoRejectionStock struct {
+	Emiten        string  `json:"emiten"`
+	Board         string  `json:"board,omitempty"`
+	PrevClose     float64 `json:"prev_close"`
+	Close         float64 `json:"close"`
+	High          float64 `json:"high"`
+	Low           float64 `json:"low"`
+	ChangePct     float64 `json:"change_pct"`
+	Limit         float64 `json:"limit"`
+	ClosedAtLimit bool    `json:"closed_at_limit"`
+}
+
+// AutoRejectionListResponse represents the emitens at their upper (ARA) and
+// lower (ARB) limits in the latest session, by change
+type AutoRejectionListResponse struct {
+	Date string               `json:"date,omitempty"`
+	ARA  []AutoRejectionStock `json:"ara"`
+	ARB  []AutoRejectionStock `json:"arb"`
+}
+
+// TickRoundResponse represents a price rounded to the IDX tick grid
+type TickRoundResponse struct {
+	Price    float64 `json:"price"`
+	Mode     string  `json:"mode"`
+	Rounded  float64 `json:"rounded"`
+	TickSize float64 `json:"tick_size"`
+	Valid    bool    `json:"valid"`
+}
+
+// TickListResponse represents the valid prices between two prices
+type TickListResponse struct {
+	From  float64   `json:"from"`
+	To    float64   `json:"to"`
+	Count int       `json:"count"`
+	Ticks []float64 `json:"ticks"`
+}
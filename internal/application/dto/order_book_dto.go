@@ -0,0 +1,52 @@
+package dto
+
+import "time"
+
+// OrderBookLevel represents a price level of one side of an order book.
+// Orders is zero when the source does not report it.
+type OrderBookLevel struct {
+	Price  float64 `json:"price"`
+	Lots   int64   `json:"lots"`
+	Orders int     `json:"orders"`
+}
+
+// OrderBookRequest represents a depth snapshot captured by a scraper. Levels
+// may be sent in any order.
+type OrderBookRequest struct {
+	Emiten string           `json:"emiten" binding:"required"`
+	Time   time.Time        `json:"time" binding:"required"`
+	Bids   []OrderBookLevel `json:"bids"`
+	Offers []OrderBookLevel `json:"offers"`
+}
+
+// OrderBookBatchRequest represents the body of a bulk order book ingest
+// request
+type OrderBookBatchRequest struct {
+	Data []OrderBookRequest `json:"data" binding:"required"`
+}
+
+// OrderBookMetricsResponse represents the metrics derived from an order
+// book. Prices and the spread need a level on both sides and are null
+// otherwise; Imbalance is null without any lots within Depth.
+type OrderBookMetricsResponse struct {
+	BestBid     *float64 `json:"best_bid"`
+	BestOffer   *float64 `json:"best_offer"`
+	Spread      *float64 `json:"spread"`
+	SpreadPct   *float64 `json:"spread_pct"`
+	SpreadTicks *int     `json:"spread_ticks"`
+	MidPrice    *float64 `json:"mid_price"`
+	Depth       int      `json:"depth"`
+	BidLots     int64    `json:"bid_lots"`
+	OfferLots   int64    `json:"offer_lots"`
+	Imbalance   *float64 `json:"imbalance"`
+}
+
+// OrderBookResponse represents the latest depth snapshot of an emiten, bids
+// from the highest price and offers from the lowest
+type OrderBookResponse struct {
+	Emiten  string                   `json:"emiten"`
+	Time    time.Time                `json:"time"`
+	Bids    []OrderBookLevel         `json:"bids"`
+	Offers  []OrderBookLevel         `json:"offers"`
+	Metrics OrderBookMetricsResponse `json:"metrics"`
+}
@@ -0,0 +1,80 @@
+package dto
+
+import "time"
+
+// ScreenFilter represents the definition of a saved screen
+type ScreenFilter struct {
+	MinPrice     *float64 `json:"min_price,omitempty"`
+	MaxPrice     *float64 `json:"max_price,omitempty"`
+	MinChangePct *float64 `json:"min_change_pct,omitempty"`
+	MaxChangePct *float64 `json:"max_change_pct,omitempty"`
+	Emitens      []string `json:"emitens,omitempty"`
+	Expression   string   `json:"expression,omitempty"`
+}
+
+// ScreenRequest represents the body of a create or update screen request.
+// An empty schedule means the screen only runs on demand.
+type ScreenRequest struct {
+	Name     string       `json:"name" binding:"required"`
+	Filter   ScreenFilter `json:"filter"`
+	Schedule string       `json:"schedule"`
+	Enabled  *bool        `json:"enabled"`
+}
+
+// ScreenResponse represents a saved screen
+type ScreenResponse struct {
+	ID        int64        `json:"id"`
+	Name      string       `json:"name"`
+	Filter    ScreenFilter `json:"filter"`
+	Schedule  string       `json:"schedule"`
+	Timezone  string       `json:"timezone"`
+	Enabled   bool         `json:"enabled"`
+	NextRunAt *time.Time   `json:"next_run_at,omitempty"`
+	LastRunAt *time.Time   `json:"last_run_at,omitempty"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+// ScreenListResponse represents the list response for saved screens
+type ScreenListResponse struct {
+	Data []ScreenResponse `json:"data"`
+}
+
+// ScreenResultResponse represents an emiten matched by a screen run
+type ScreenResultResponse struct {
+	Emiten     string   `json:"emiten"`
+	ClosePrice float64  `json:"close_price"`
+	ChangePct  *float64 `json:"change_pct"`
+}
+
+// ScreenRunResponse represents a stored screen run
+type ScreenRunResponse struct {
+	ID       int64                  `json:"id"`
+	ScreenID int64                  `json:"screen_id"`
+	Trigger  string                 `json:"trigger"`
+	RunAt    time.Time              `json:"run_at"`
+	Count    int                    `json:"count"`
+	Results  []ScreenResultResponse `json:"results"`
+}
+
+// ScreenRunListResponse represents the list response for screen runs
+type ScreenRunListResponse struct {
+	Data []ScreenRunResponse `json:"data"`
+}
+
+// ScreenRunRef identifies a run in a diff
+type ScreenRunRef struct {
+	ID    int64     `json:"id"`
+	RunAt time.Time `json:"run_at"`
+}
+
+// ScreenRunDiffResponse represents the change in matching emitens between a
+// run and the run before it
+type ScreenRunDiffResponse struct {
+	ScreenID    int64         `json:"screen_id"`
+	Run         ScreenRunRef  `json:"run"`
+	PreviousRun *ScreenRunRef `json:"previous_run"`
+	Entered     []string      `json:"entered"`
+	Left        []string      `json:"left"`
+	Unchanged   []string      `json:"unchanged"`
+}
@@ -0,0 +1,25 @@
+package dto
+
+import "time"
+
+// ScreenerRequest represents a screener query
+type ScreenerRequest struct {
+	Expression string `json:"expression" binding:"required"`
+	Limit      int    `json:"limit"`
+}
+
+// ScreenerMatch represents an emiten that satisfies the screen, together with
+// the values of every field and function referenced by the expression
+type ScreenerMatch struct {
+	Emiten string              `json:"emiten"`
+	Date   time.Time           `json:"date"`
+	Fields map[string]*float64 `json:"fields"`
+}
+
+// ScreenerResponse represents the result of a screener query
+type ScreenerResponse struct {
+	Expression string          `json:"expression"`
+	Fields     []string        `json:"fields"`
+	Count      int             `json:"count"`
+	Data       []ScreenerMatch `json:"data"`
+}
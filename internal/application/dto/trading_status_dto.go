@@ -0,0 +1,57 @@
+package dto
+
+import "time"
+
+// TradingStatusRequest represents the body of a create or update trading
+// status request. From and To are inclusive YYYY-MM-DD dates; an empty To
+// means the status still applies.
+type TradingStatusRequest struct {
+	Emiten    string   `json:"emiten" binding:"required"`
+	Status    string   `json:"status"`
+	Notations []string `json:"notations"`
+	Watchlist bool     `json:"watchlist"`
+	Reason    string   `json:"reason"`
+	From      string   `json:"from" binding:"required"`
+	To        string   `json:"to"`
+}
+
+// TradingStatusResponse represents an effective-dated trading status
+type TradingStatusResponse struct {
+	ID        int64     `json:"id"`
+	Emiten    string    `json:"emiten"`
+	Status    string    `json:"status"`
+	Notations []string  `json:"notations"`
+	Watchlist bool      `json:"watchlist"`
+	Reason    string    `json:"reason"`
+	From      string    `json:"from"`
+	To        *string   `json:"to"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TradingStatusListResponse represents the trading statuses of an emiten, or
+// of every emiten on a date
+type TradingStatusListResponse struct {
+	Emiten string                  `json:"emiten,omitempty"`
+	AsOf   string                  `json:"as_of,omitempty"`
+	Count  int                     `json:"count"`
+	Data   []TradingStatusResponse `json:"data"`
+}
+
+// CurrentTradingStatusResponse represents the trading status of an emiten on
+// a date. Record is null when no status covers the date, which means active.
+type CurrentTradingStatusResponse struct {
+	Emiten string                 `json:"emiten"`
+	AsOf   string                 `json:"as_of"`
+	Flags  TradingStatusFlags     `json:"flags"`
+	Record *TradingStatusResponse `json:"record"`
+}
+
+// TradingStatusFlags summarizes the trading status of an emiten for market
+// data items
+type TradingStatusFlags struct {
+	Status    string   `json:"status"`
+	Suspended bool     `json:"suspended"`
+	Notations []string `json:"notations"`
+	Watchlist bool     `json:"watchlist"`
+}
@@ -0,0 +1,479 @@
+package usecases
+
+import (
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/repositories"
+	"api-web-scrapping/internal/domain/services"
+	"api-web-scrapping/pkg/indicators"
+	"api-web-scrapping/pkg/stats"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrInvalidAnalytics = errors.New("invalid analytics request")
+	ErrNoMarketData     = errors.New("no market data")
+)
+
+const (
+	// maxCorrelationEmitens bounds the pairs computed by one correlation request
+	maxCorrelationEmitens      = 30
+	defaultCorrelationLookback = 90
+	// maxCorrelationLookback is about three years of sessions
+	maxCorrelationLookback = 750
+
+	defaultRiskWindow = 252
+	minRiskWindow     = 20
+	maxRiskWindow     = 750
+	riskATRPeriod     = 14
+
+	maxCompareEmitens  = 10
+	defaultCompareBase = 100
+
+	// maxSummaryCacheEntries bounds the emitens whose summary history is cached
+	maxSummaryCacheEntries = 1000
+	// allTimeHighCandidates are the highest snapshots screened for the all-time
+	// high, so that an off-tick one does not leave it unknown
+	allTimeHighCandidates = 10
+)
+
+// AnalyticsUseCase computes statistics across the price histories of several
+// emitens
+type AnalyticsUseCase struct {
+	marketDataRepo repositories.MarketDataRepository
+	emitenRepo     repositories.EmitenRepository
+	summaryCache   map[string]*summaryHistory
+	summaryMu      sync.Mutex
+}
+
+// summaryHistory is the daily history of an emiten before the trading day
+// it was loaded on, trimmed to what a price summary needs. ready is closed
+// once the history is loaded, so concurrent requests share one load.
+type summaryHistory struct {
+	day         time.Time
+	candles     []entities.Candle
+	allTimeHigh services.PriceLevel
+	err         error
+	ready       chan struct{}
+}
+
+func NewAnalyticsUseCase(marketDataRepo repositories.MarketDataRepository, emitenRepo repositories.EmitenRepository) *AnalyticsUseCase {
+	return &AnalyticsUseCase{
+		marketDataRepo: marketDataRepo,
+		emitenRepo:     emitenRepo,
+		summaryCache:   make(map[string]*summaryHistory),
+	}
+}
+
+// Correlation computes Pearson and Spearman correlation and covariance
+// matrices of daily log returns over the last lookback sessions. Returns are
+// aligned on the sessions any of the emitens traded, and each pair uses only
+// the sessions where both have a return.
+func (uc *AnalyticsUseCase) Correlation(req dto.CorrelationRequest) (*dto.CorrelationResponse, error) {
+	emitens, err := normalizeEmitenList(req.Emitens, maxCorrelationEmitens)
+	if err != nil {
+		return nil, err
+	}
+	if len(emitens) < 2 {
+		return nil, fmt.Errorf("%w: at least two emitens are required", ErrInvalidAnalytics)
+	}
+
+	lookback := req.Lookback
+	if lookback == 0 {
+		lookback = defaultCorrelationLookback
+	}
+	if lookback < 2 || lookback > maxCorrelationLookback {
+		return nil, fmt.Errorf("%w: lookback must be between 2 and %d sessions", ErrInvalidAnalytics, maxCorrelationLookback)
+	}
+
+	// One extra session provides the close the first return starts from
+	to := time.Now()
+	from := to.AddDate(0, 0, -sessionsToCalendarDays(lookback+1))
+	series := make([][]entities.Candle, len(emitens))
+	for i, emiten := range emitens {
+		candles, err := loadDailyCandles(uc.marketDataRepo, emiten, from, to)
+		if err != nil {
+			return nil, err
+		}
+		series[i] = candles
+	}
+
+	aligned := services.AlignLogReturns(series).Tail(lookback)
+
+	n := len(emitens)
+	response := &dto.CorrelationResponse{
+		Emitens:      emitens,
+		Lookback:     lookback,
+		Observations: make([][]int, n),
+		Pearson:      make([][]*float64, n),
+		Spearman:     make([][]*float64, n),
+		Covariance:   make([][]*float64, n),
+	}
+	if len(aligned.Dates) > 0 {
+		response.From = aligned.Dates[0].Format(dateOnly)
+		response.To = aligned.Dates[len(aligned.Dates)-1].Format(dateOnly)
+	}
+	for i := 0; i < n; i++ {
+		response.Observations[i] = make([]int, n)
+		response.Pearson[i] = make([]*float64, n)
+		response.Spearman[i] = make([]*float64, n)
+		response.Covariance[i] = make([]*float64, n)
+	}
+
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			x, y := aligned.PairwiseComplete(i, j)
+			pearson := nullableFloat(stats.Pearson(x, y))
+			spearman := nullableFloat(stats.Spearman(x, y))
+			covariance := nullableFloat(stats.Covariance(x, y))
+
+			// The matrices are symmetric, so both cells share the values
+			for _, cell := range [][2]int{{i, j}, {j, i}} {
+				r, c := cell[0], cell[1]
+				response.Observations[r][c] = len(x)
+				response.Pearson[r][c] = pearson
+				response.Spearman[r][c] = spearman
+				response.Covariance[r][c] = covariance
+			}
+		}
+	}
+
+	return response, nil
+}
+
+// GetRisk computes volatility, ATR, drawdown, downside deviation and,
+// when a benchmark is given, beta over the last window daily returns of an
+// emiten
+func (uc *AnalyticsUseCase) GetRisk(emiten string, req dto.RiskRequest) (*dto.RiskResponse, error) {
+	emiten = entities.NormalizeTicker(emiten)
+	window := req.Window
+	if window == 0 {
+		window = defaultRiskWindow
+	}
+	if window < minRiskWindow || window > maxRiskWindow {
+		return nil, fmt.Errorf("%w: window must be between %d and %d sessions", ErrInvalidAnalytics, minRiskWindow, maxRiskWindow)
+	}
+
+	to := time.Now()
+	from := to.AddDate(0, 0, -sessionsToCalendarDays(window+1))
+	candles, err := loadDailyCandles(uc.marketDataRepo, emiten, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if len(candles) < 2 {
+		return nil, fmt.Errorf("%w for emiten %s", ErrNoMarketData, emiten)
+	}
+	// window returns span window+1 closes
+	if len(candles) > window+1 {
+		candles = candles[len(candles)-window-1:]
+	}
+
+	last := candles[len(candles)-1]
+	highs, lows, closes := candleSeries(candles)
+	atr := indicators.ATR(highs, lows, closes, riskATRPeriod)[len(candles)-1]
+
+	response := &dto.RiskResponse{
+		Emiten:                 emiten,
+		Window:                 window,
+		Observations:           len(candles) - 1,
+		From:                   candles[0].Time.Format(dateOnly),
+		To:                     last.Time.Format(dateOnly),
+		LastClose:              last.Close,
+		VolatilityPct:          nullableFloat(services.CloseToCloseVolatility(candles) * 100),
+		ParkinsonVolatilityPct: nullableFloat(services.ParkinsonVolatility(candles[1:]) * 100),
+		DownsideDeviationPct:   nullableFloat(services.AnnualizedDownsideDeviation(candles) * 100),
+		ATRPeriod:              riskATRPeriod,
+		ATR:                    nullableFloat(atr),
+		ATRPct:                 nullableFloat(atr / last.Close * 100),
+	}
+
+	if drawdown, ok := services.MaxDrawdown(candles); ok {
+		response.MaxDrawdown = &dto.DrawdownResponse{
+			DrawdownPct: drawdown.Depth * 100,
+			PeakDate:    drawdown.Peak.Format(dateOnly),
+			PeakClose:   drawdown.PeakClose,
+			TroughDate:  drawdown.Trough.Format(dateOnly),
+			TroughClose: drawdown.TroughClose,
+		}
+	}
+
+	if benchmark := entities.NormalizeTicker(req.Benchmark); benchmark != "" {
+		benchmarkCandles, err := loadDailyCandles(uc.marketDataRepo, benchmark, candles[0].Time, to)
+		if err != nil {
+			return nil, err
+		}
+		beta, observations := services.Beta(candles, benchmarkCandles, window)
+		response.Beta = &dto.BetaResponse{
+			Benchmark:    benchmark,
+			Beta:         nullableFloat(beta),
+			Observations: observations,
+		}
+	}
+
+	return response, nil
+}
+
+// Compare rebases the daily closes of several emitens, and optionally a
+// benchmark, to a common start value over [from, to]. The series start on
+// the first session in which all of them traded. A zero to means now and a
+// zero from means one year before to.
+func (uc *AnalyticsUseCase) Compare(req dto.CompareRequest, from, to time.Time) (*dto.CompareResponse, error) {
+	emitens, err := normalizeEmitenList(strings.Split(req.Emiten, ","), maxCompareEmitens)
+	if err != nil {
+		return nil, err
+	}
+
+	base := req.Base
+	if base == 0 {
+		base = defaultCompareBase
+	}
+	if base < 0 {
+		return nil, fmt.Errorf("%w: base must be positive", ErrInvalidAnalytics)
+	}
+
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if from.IsZero() {
+		from = to.AddDate(-1, 0, 0)
+	}
+	if err := validateRange(from, to, maxDailyRange); err != nil {
+		return nil, err
+	}
+
+	symbols := emitens
+	benchmark := entities.NormalizeTicker(req.Benchmark)
+	if benchmark != "" {
+		for _, e := range emitens {
+			if e == benchmark {
+				return nil, fmt.Errorf("%w: benchmark %s is also compared", ErrInvalidAnalytics, benchmark)
+			}
+		}
+		symbols = append(append([]string{}, emitens...), benchmark)
+	}
+
+	series := make([][]entities.Candle, len(symbols))
+	for i, symbol := range symbols {
+		candles, err := loadDailyCandles(uc.marketDataRepo, symbol, from, to)
+		if err != nil {
+			return nil, err
+		}
+		series[i] = candles
+	}
+
+	rebased, ok := services.Rebase(series, base)
+	if !ok {
+		return nil, fmt.Errorf("%w: no session in range where %s all traded", ErrNoMarketData, strings.Join(symbols, ", "))
+	}
+
+	first, last := 0, len(rebased.Dates)-1
+	growth := func(i int) float64 {
+		return rebased.Closes[i][last] / rebased.Closes[i][first]
+	}
+
+	response := &dto.CompareResponse{
+		Base:        base,
+		Benchmark:   benchmark,
+		From:        rebased.Dates[first].Format(dateOnly),
+		To:          rebased.Dates[last].Format(dateOnly),
+		Performance: make([]dto.ComparePerformance, len(symbols)),
+		Data:        make([]dto.ComparePoint, len(rebased.Dates)),
+	}
+	for i, symbol := range symbols {
+		performance := dto.ComparePerformance{
+			Emiten:         symbol,
+			StartClose:     rebased.Closes[i][first],
+			EndClose:       rebased.Closes[i][last],
+			TotalReturnPct: (growth(i) - 1) * 100,
+			CAGRPct:        nullableFloat(services.CAGR(rebased.Closes[i][first], rebased.Closes[i][last], rebased.Dates[first], rebased.Dates[last]) * 100),
+		}
+		if benchmark != "" && symbol != benchmark {
+			performance.RelativeStrengthPct = nullableFloat((growth(i)/growth(len(symbols)-1) - 1) * 100)
+		}
+		response.Performance[i] = performance
+	}
+	for t, day := range rebased.Dates {
+		values := make(map[string]float64, len(symbols))
+		for i, symbol := range symbols {
+			values[symbol] = rebased.Values[i][t]
+		}
+		response.Data[t] = dto.ComparePoint{Date: day.Format(dateOnly), Values: values}
+	}
+
+	return response, nil
+}
+
+// GetSummary computes 52-week high and low, average closes, the all-time
+// high and the year-to-date return of an emiten as of its last session. The
+// history before today is loaded once per trading day and combined with
+// today's snapshots on every call.
+func (uc *AnalyticsUseCase) GetSummary(emiten string) (*dto.PriceSummaryResponse, error) {
+	emiten = entities.NormalizeTicker(emiten)
+	company, err := uc.emitenRepo.FindByTicker(emiten)
+	if err != nil {
+		return nil, err
+	}
+	if company == nil {
+		return nil, fmt.Errorf("%w: %s", ErrEmitenNotFound, emiten)
+	}
+
+	now := time.Now()
+	today := services.BucketStart(now, entities.CandleInterval1d)
+
+	history, err := uc.summaryHistory(emiten, today)
+	if err != nil {
+		return nil, err
+	}
+	todayCandles, err := loadDailyCandles(uc.marketDataRepo, emiten, today, now)
+	if err != nil {
+		return nil, err
+	}
+
+	candles := append(append([]entities.Candle{}, history.candles...), todayCandles...)
+	summary, ok := services.ComputePriceSummary(candles, history.allTimeHigh)
+	if !ok {
+		return nil, fmt.Errorf("%w for emiten %s", ErrNoMarketData, emiten)
+	}
+
+	level := func(l services.PriceLevel) dto.PriceLevelResponse {
+		return dto.PriceLevelResponse{
+			Price:       l.Price,
+			Date:        l.Date.Format(dateOnly),
+			DistancePct: (summary.Close/l.Price - 1) * 100,
+		}
+	}
+	response := &dto.PriceSummaryResponse{
+		Emiten:        emiten,
+		Date:          summary.Date.Format(dateOnly),
+		Close:         summary.Close,
+		High52w:       level(summary.High52w),
+		Low52w:        level(summary.Low52w),
+		AverageCloses: make([]dto.AverageCloseResponse, len(services.SummaryAveragePeriods)),
+		AllTimeHigh:   level(summary.AllTimeHigh),
+		YTDBaseClose:  nullableFloat(summary.YTDBase),
+		YTDReturnPct:  nullableFloat((summary.Close/summary.YTDBase - 1) * 100),
+	}
+	for i, period := range services.SummaryAveragePeriods {
+		average := summary.Averages[period]
+		response.AverageCloses[i] = dto.AverageCloseResponse{
+			Sessions:    period,
+			Value:       nullableFloat(average),
+			DistancePct: nullableFloat((summary.Close/average - 1) * 100),
+		}
+	}
+
+	return response, nil
+}
+
+// summaryHistory returns the cached history of an emiten before today,
+// loading it on the first call of the trading day. The load runs outside the
+// lock; concurrent calls for the same emiten wait for it instead.
+func (uc *AnalyticsUseCase) summaryHistory(emiten string, today time.Time) (*summaryHistory, error) {
+	uc.summaryMu.Lock()
+	history, ok := uc.summaryCache[emiten]
+	if !ok || !history.day.Equal(today) {
+		history = &summaryHistory{day: today, ready: make(chan struct{})}
+		uc.evictSummaryHistories(today)
+		uc.summaryCache[emiten] = history
+		uc.summaryMu.Unlock()
+
+		history.err = uc.loadSummaryHistory(history, emiten)
+		close(history.ready)
+		if history.err != nil {
+			uc.summaryMu.Lock()
+			if uc.summaryCache[emiten] == history {
+				delete(uc.summaryCache, emiten)
+			}
+			uc.summaryMu.Unlock()
+		}
+	} else {
+		uc.summaryMu.Unlock()
+		<-history.ready
+	}
+
+	if history.err != nil {
+		return nil, history.err
+	}
+	return history, nil
+}
+
+// loadSummaryHistory loads the 52-week window, the sessions of the longest
+// average and the previous year's last close before history.day, and the
+// all-time high before the loaded candles
+func (uc *AnalyticsUseCase) loadSummaryHistory(history *summaryHistory, emiten string) error {
+	today := history.day
+	yearStart := time.Date(today.Year(), time.January, 1, 0, 0, 0, 0, services.WIB)
+	from := today.Add(-services.SummaryHighLowWindow)
+	for _, start := range []time.Time{
+		today.AddDate(0, 0, -sessionsToCalendarDays(services.SummaryHistorySessions)),
+		yearStart.AddDate(0, 0, -sessionsToCalendarDays(1)),
+	} {
+		if start.Before(from) {
+			from = start
+		}
+	}
+
+	candles, err := loadDailyCandles(uc.marketDataRepo, emiten, from, today.Add(-time.Nanosecond))
+	if err != nil {
+		return err
+	}
+	history.candles = candles
+
+	highest, err := uc.marketDataRepo.GetHighestByEmiten(emiten, services.BucketStart(from, entities.CandleInterval1d), allTimeHighCandidates)
+	if err != nil {
+		return err
+	}
+	valid, _ := screenSnapshots(highest)
+	if len(valid) > 0 {
+		history.allTimeHigh = services.PriceLevel{
+			Price: valid[0].HighPrice,
+			Date:  services.BucketStart(valid[0].Date, entities.CandleInterval1d),
+		}
+	}
+	return nil
+}
+
+// evictSummaryHistories makes room for one more cached history, dropping
+// those of earlier trading days first. The caller holds summaryMu.
+func (uc *AnalyticsUseCase) evictSummaryHistories(today time.Time) {
+	if len(uc.summaryCache) < maxSummaryCacheEntries {
+		return
+	}
+	for emiten, history := range uc.summaryCache {
+		if !history.day.Equal(today) {
+			delete(uc.summaryCache, emiten)
+		}
+	}
+	for emiten := range uc.summaryCache {
+		if len(uc.summaryCache) < maxSummaryCacheEntries {
+			break
+		}
+		delete(uc.summaryCache, emiten)
+	}
+}
+
+// normalizeEmitenList normalizes tickers, rejecting blanks, duplicates and
+// lists longer than limit
+func normalizeEmitenList(raw []string, limit int) ([]string, error) {
+	if len(raw) > limit {
+		return nil, fmt.Errorf("%w: at most %d emitens are allowed", ErrInvalidAnalytics, limit)
+	}
+	emitens := make([]string, 0, len(raw))
+	seen := make(map[string]bool, len(raw))
+	for _, e := range raw {
+		ticker := entities.NormalizeTicker(e)
+		if ticker == "" {
+			return nil, fmt.Errorf("%w: emiten must not be empty", ErrInvalidAnalytics)
+		}
+		if seen[ticker] {
+			return nil, fmt.Errorf("%w: emiten %s is listed twice", ErrInvalidAnalytics, ticker)
+		}
+		seen[ticker] = true
+		emitens = append(emitens, ticker)
+	}
+	return emitens, nil
+}
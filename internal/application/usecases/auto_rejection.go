@@ -0,0 +1,102 @@
+package usecases
+
+import (
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/repositories"
+	"api-web-scrapping/internal/domain/services"
+	"sort"
+	"time"
+)
+
+// autoRejections computes the auto-rejection limits of the session of each
+// market data item against the close of its previous session, on the board
+// given by sessionBoard for its trading status. Items whose previous close is
+// unknown get nil.
+func autoRejections(repo repositories.MarketDataRepository, rules services.AutoRejectionRules, boards map[string]string, statuses []*dto.TradingStatusFlags, marketDataList []entities.MarketData) ([]*dto.AutoRejectionResponse, error) {
+	responses := make([]*dto.AutoRejectionResponse, len(marketDataList))
+	if len(marketDataList) == 0 {
+		return responses, nil
+	}
+
+	lookback := sessionsToCalendarDays(1)
+	oldest := make(map[string]time.Time)
+	newest := make(map[string]time.Time)
+	to := marketDataList[0].Date
+	for _, md := range marketDataList {
+		if at, ok := oldest[md.Emiten]; !ok || md.Date.Before(at) {
+			oldest[md.Emiten] = md.Date
+		}
+		if md.Date.After(newest[md.Emiten]) {
+			newest[md.Emiten] = md.Date
+		}
+		if md.Date.After(to) {
+			to = md.Date
+		}
+	}
+
+	candlesByEmiten := make(map[string][]entities.Candle)
+	var stale []string
+	if len(oldest) == 1 {
+		stale = []string{marketDataList[0].Emiten}
+	} else {
+		// The market-wide load is bounded by the market's latest session, so
+		// that one long-suspended emiten does not widen it; emitens with an
+		// older snapshot are loaded on their own
+		recentFrom := services.BucketStart(to, entities.CandleInterval1d).AddDate(0, 0, -lookback)
+		from := to
+		for emiten, at := range oldest {
+			if at.Before(recentFrom) {
+				stale = append(stale, emiten)
+			} else if at.Before(from) {
+				from = at
+			}
+		}
+		var err error
+		if candlesByEmiten, err = loadDailyCandlesByEmiten(repo, from.AddDate(0, 0, -lookback), to); err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(stale)
+	for _, emiten := range stale {
+		candles, err := loadDailyCandles(repo, emiten, oldest[emiten].AddDate(0, 0, -lookback), newest[emiten])
+		if err != nil {
+			return nil, err
+		}
+		candlesByEmiten[emiten] = candles
+	}
+
+	for i, md := range marketDataList {
+		limits, ok := rules.Limits(sessionBoard(boards[md.Emiten], statuses[i]), previousClose(candlesByEmiten[md.Emiten], md.Date))
+		if !ok {
+			continue
+		}
+		responses[i] = &dto.AutoRejectionResponse{
+			PrevClose:  limits.PrevClose,
+			UpperLimit: limits.Upper,
+			LowerLimit: limits.Lower,
+			ARA:        limits.HitUpper(md.HighPrice),
+			ARB:        limits.HitLower(md.LowPrice),
+		}
+	}
+	return responses, nil
+}
+
+// emitenBoards maps tickers to their listing boards
+func emitenBoards(emitens []entities.Emiten) map[string]string {
+	boards := make(map[string]string, len(emitens))
+	for _, e := range emitens {
+		boards[e.Ticker] = e.Board
+	}
+	return boards
+}
+
+// sessionBoard returns the board whose limits apply to a session: the
+// watchlist board while the trading status places the emiten on it, else its
+// listing board
+func sessionBoard(listing string, status *dto.TradingStatusFlags) string {
+	if status != nil && status.Watchlist {
+		return entities.BoardWatchlist
+	}
+	return listing
+}
@@ -0,0 +1,266 @@
+package usecases
+
+import (
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/repositories"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+const (
+	defaultBrokerRankingLimit = 10
+	maxBrokerRankingLimit     = 100
+)
+
+var ErrInvalidBrokerSummary = errors.New("invalid broker summary")
+
+type BrokerSummaryUseCase struct {
+	summaryRepo repositories.BrokerSummaryRepository
+}
+
+func NewBrokerSummaryUseCase(summaryRepo repositories.BrokerSummaryRepository) *BrokerSummaryUseCase {
+	return &BrokerSummaryUseCase{
+		summaryRepo: summaryRepo,
+	}
+}
+
+// TopBrokers ranks the brokers of an emiten by net buy and net sell value
+// summed over [from, to], up to limit each. Without a range it ranks the
+// latest session with data of the emiten, so a suspended emiten still shows
+// its last session; a zero from alone means the date of to and a zero to
+// alone today.
+func (uc *BrokerSummaryUseCase) TopBrokers(emiten string, from, to time.Time, limit int) (*dto.BrokerRankingResponse, error) {
+	emiten = entities.NormalizeTicker(emiten)
+	response := &dto.BrokerRankingResponse{
+		Emiten:     emiten,
+		NetBuyers:  []dto.BrokerActivityResponse{},
+		NetSellers: []dto.BrokerActivityResponse{},
+	}
+
+	from, to, ok, err := sessionRange(from, to, func() (*time.Time, error) {
+		return uc.summaryRepo.LatestDateByEmiten(emiten)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return response, nil
+	}
+	response.From, response.To = from.Format(dateOnly), to.Format(dateOnly)
+
+	summaries, err := uc.summaryRepo.FindByEmiten(emiten, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	response.NetBuyers, response.NetSellers = rankBrokerActivity(summaries, true, brokerRankingLimit(limit))
+
+	return response, nil
+}
+
+// Accumulation ranks the emitens a broker net bought and net sold most by
+// value over [from, to], up to limit each. Without a range it ranks the
+// latest session with data of any emiten; a zero from alone means the date
+// of to and a zero to alone today.
+func (uc *BrokerSummaryUseCase) Accumulation(brokerCode string, from, to time.Time, limit int) (*dto.BrokerAccumulationResponse, error) {
+	brokerCode = entities.NormalizeBrokerCode(brokerCode)
+	response := &dto.BrokerAccumulationResponse{
+		Broker:      brokerCode,
+		Accumulated: []dto.BrokerActivityResponse{},
+		Distributed: []dto.BrokerActivityResponse{},
+	}
+
+	from, to, ok, err := sessionRange(from, to, uc.summaryRepo.LatestDate)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return response, nil
+	}
+	response.From, response.To = from.Format(dateOnly), to.Format(dateOnly)
+
+	summaries, err := uc.summaryRepo.FindByBroker(brokerCode, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	response.Accumulated, response.Distributed = rankBrokerActivity(summaries, false, brokerRankingLimit(limit))
+
+	return response, nil
+}
+
+// Ingest records a batch of daily broker summaries. A summary with the same
+// emiten, date and broker as a stored one replaces its totals. Invalid
+// summaries are reported by their 1-based position in the batch and skipped.
+func (uc *BrokerSummaryUseCase) Ingest(reqs []dto.BrokerSummaryRequest) (*dto.ImportResponse, error) {
+	return uc.save(newImportResult(), reqs, batchPositions(len(reqs)))
+}
+
+// Import records daily broker summaries from a CSV document with the columns
+// emiten, date and broker and the optional buy_lots, sell_lots, buy_value and
+// sell_value, which default to zero. A row with the same emiten, date and
+// broker as a stored summary replaces its totals.
+func (uc *BrokerSummaryUseCase) Import(reader io.Reader) (*dto.ImportResponse, error) {
+	rows, err := readCSV(reader, "emiten", "date", "broker")
+	if err != nil {
+		return nil, err
+	}
+
+	result := newImportResult()
+	var reqs []dto.BrokerSummaryRequest
+	var lines []int
+
+	for _, row := range rows {
+		req := dto.BrokerSummaryRequest{
+			Emiten: row.get("emiten"),
+			Date:   row.get("date"),
+			Broker: row.get("broker"),
+		}
+		var parseErr error
+		req.BuyLots, parseErr = row.optionalInt("buy_lots")
+		if parseErr == nil {
+			req.SellLots, parseErr = row.optionalInt("sell_lots")
+		}
+		if parseErr == nil {
+			req.BuyValue, parseErr = row.optionalFloat("buy_value")
+		}
+		if parseErr == nil {
+			req.SellValue, parseErr = row.optionalFloat("sell_value")
+		}
+		if parseErr != nil {
+			result.fail(row.line, fmt.Errorf("%w: %v", ErrInvalidBrokerSummary, parseErr))
+			continue
+		}
+
+		reqs = append(reqs, req)
+		lines = append(lines, row.line)
+	}
+
+	return uc.save(result, reqs, lines)
+}
+
+// save records the valid summaries of a batch, reporting the invalid ones in
+// result by their lines
+func (uc *BrokerSummaryUseCase) save(result *importResult, reqs []dto.BrokerSummaryRequest, lines []int) (*dto.ImportResponse, error) {
+	now := time.Now()
+	return importRecords(result, reqs, lines, func(req dto.BrokerSummaryRequest) (*entities.BrokerSummary, error) {
+		summary, err := requestToBrokerSummary(req)
+		if err != nil {
+			return nil, err
+		}
+		summary.CreatedAt, summary.UpdatedAt = now, now
+		return summary, nil
+	}, uc.summaryRepo.Upsert)
+}
+
+// Helper functions
+
+func brokerRankingLimit(limit int) int {
+	if limit <= 0 {
+		return defaultBrokerRankingLimit
+	}
+	if limit > maxBrokerRankingLimit {
+		return maxBrokerRankingLimit
+	}
+	return limit
+}
+
+// rankBrokerActivity sums summaries by broker, or else by emiten, and
+// returns up to limit net buyers, largest net value first, and net sellers,
+// most negative first
+func rankBrokerActivity(summaries []entities.BrokerSummary, byBroker bool, limit int) ([]dto.BrokerActivityResponse, []dto.BrokerActivityResponse) {
+	totals := make(map[string]*entities.BrokerSummary)
+	days := make(map[string]int)
+	for i := range summaries {
+		s := &summaries[i]
+		k := s.Emiten
+		if byBroker {
+			k = s.BrokerCode
+		}
+		total, ok := totals[k]
+		if !ok {
+			total = &entities.BrokerSummary{Emiten: s.Emiten}
+			if byBroker {
+				total = &entities.BrokerSummary{BrokerCode: s.BrokerCode}
+			}
+			totals[k] = total
+		}
+		total.BuyLots += s.BuyLots
+		total.SellLots += s.SellLots
+		total.BuyValue += s.BuyValue
+		total.SellValue += s.SellValue
+		days[k]++
+	}
+
+	buyers := []dto.BrokerActivityResponse{}
+	sellers := []dto.BrokerActivityResponse{}
+	for k, total := range totals {
+		switch {
+		case total.NetValue() > 0:
+			buyers = append(buyers, brokerActivityToResponse(total, days[k]))
+		case total.NetValue() < 0:
+			sellers = append(sellers, brokerActivityToResponse(total, days[k]))
+		}
+	}
+	sort.Slice(buyers, func(i, j int) bool {
+		if buyers[i].NetValue != buyers[j].NetValue {
+			return buyers[i].NetValue > buyers[j].NetValue
+		}
+		return buyers[i].Broker+buyers[i].Emiten < buyers[j].Broker+buyers[j].Emiten
+	})
+	sort.Slice(sellers, func(i, j int) bool {
+		if sellers[i].NetValue != sellers[j].NetValue {
+			return sellers[i].NetValue < sellers[j].NetValue
+		}
+		return sellers[i].Broker+sellers[i].Emiten < sellers[j].Broker+sellers[j].Emiten
+	})
+
+	if len(buyers) > limit {
+		buyers = buyers[:limit]
+	}
+	if len(sellers) > limit {
+		sellers = sellers[:limit]
+	}
+	return buyers, sellers
+}
+
+func requestToBrokerSummary(req dto.BrokerSummaryRequest) (*entities.BrokerSummary, error) {
+	date, err := time.Parse(dateOnly, req.Date)
+	if err != nil {
+		return nil, fmt.Errorf("%w: date must be YYYY-MM-DD", ErrInvalidBrokerSummary)
+	}
+
+	summary := &entities.BrokerSummary{
+		Emiten:     req.Emiten,
+		Date:       date,
+		BrokerCode: req.Broker,
+		BuyLots:    req.BuyLots,
+		SellLots:   req.SellLots,
+		BuyValue:   req.BuyValue,
+		SellValue:  req.SellValue,
+	}
+	if err := summary.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBrokerSummary, err)
+	}
+	return summary, nil
+}
+
+func brokerActivityToResponse(b *entities.BrokerSummary, days int) dto.BrokerActivityResponse {
+	return dto.BrokerActivityResponse{
+		Broker:       b.BrokerCode,
+		Emiten:       b.Emiten,
+		Days:         days,
+		BuyLots:      b.BuyLots,
+		SellLots:     b.SellLots,
+		NetLots:      b.NetLots(),
+		BuyValue:     b.BuyValue,
+		SellValue:    b.SellValue,
+		NetValue:     b.NetValue(),
+		BuyAvgPrice:  b.BuyAvgPrice(),
+		SellAvgPrice: b.SellAvgPrice(),
+	}
+}
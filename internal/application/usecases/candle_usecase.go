@@ -0,0 +1,332 @@
+package usecases
+
+import (
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/repositories"
+	"api-web-scrapping/internal/domain/services"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidCandleInterval = errors.New("invalid candle interval")
+	ErrInvalidTimeRange      = errors.New("invalid time range")
+)
+
+// rollupIntervals are the coarser intervals that may be served from the
+// persisted market_data_candles table instead of being computed per request
+var rollupIntervals = []entities.CandleInterval{
+	entities.CandleInterval1h,
+	entities.CandleInterval1d,
+	entities.CandleInterval1w,
+	entities.CandleInterval1M,
+}
+
+type CandleUseCase struct {
+	marketDataRepo repositories.MarketDataRepository
+	candleRepo     repositories.CandleRepository
+	quarantineRepo repositories.QuarantineRepository
+	actionRepo     repositories.CorporateActionRepository
+	rollupEnabled  bool
+}
+
+func NewCandleUseCase(marketDataRepo repositories.MarketDataRepository, candleRepo repositories.CandleRepository, quarantineRepo repositories.QuarantineRepository, actionRepo repositories.CorporateActionRepository, rollupEnabled bool) *CandleUseCase {
+	return &CandleUseCase{
+		marketDataRepo: marketDataRepo,
+		candleRepo:     candleRepo,
+		quarantineRepo: quarantineRepo,
+		actionRepo:     actionRepo,
+		rollupEnabled:  rollupEnabled,
+	}
+}
+
+// GetCandles returns OHLC candles of an emiten for the given interval. A zero
+// to means now and a zero from means the interval's default lookback.
+// Adjusted candles are always rolled up from snapshots adjusted for
+// corporate actions, so a candle spanning an ex-date is not mixed.
+func (uc *CandleUseCase) GetCandles(emiten, intervalValue string, from, to time.Time, adjusted bool) (*dto.CandleListResponse, error) {
+	interval, err := entities.ParseCandleInterval(intervalValue)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCandleInterval, err)
+	}
+
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if from.IsZero() {
+		from = to.Add(-interval.DefaultLookback())
+	}
+	if from.After(to) {
+		return nil, fmt.Errorf("%w: from must not be after to", ErrInvalidTimeRange)
+	}
+
+	start := services.BucketStart(from, interval)
+	var candles []entities.Candle
+	if uc.rollupEnabled && isRollupInterval(interval) && !adjusted {
+		candles, err = uc.candleRepo.GetCandles(emiten, interval, start, to)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The rollups only cover the recent refresh windows, so earlier buckets
+	// are rolled up from the snapshots
+	if len(candles) == 0 || candles[0].Time.After(start) {
+		rawTo := to
+		if len(candles) > 0 {
+			rawTo = candles[0].Time.Add(-time.Nanosecond)
+		}
+		raw, err := uc.rawCandles(emiten, interval, start, rawTo, adjusted)
+		if err != nil {
+			return nil, err
+		}
+		candles = append(raw, candles...)
+	}
+
+	if len(candles) == 0 {
+		return nil, fmt.Errorf("no market data found for emiten %s", emiten)
+	}
+
+	return &dto.CandleListResponse{
+		Emiten:   emiten,
+		Interval: string(interval),
+		Adjusted: adjusted,
+		Data:     candlesToResponses(candles),
+	}, nil
+}
+
+// RefreshRollups recomputes the persisted candles of every emiten for the
+// current and previous month. The window starts on the Monday on or before the
+// previous month so weekly candles are never rebuilt from a partial week; only
+// buckets starting within the window are saved.
+// Snapshots with prices off the tick grid are quarantined instead of rolled up.
+func (uc *CandleUseCase) RefreshRollups(now time.Time) error {
+	from := rollupWindowStart(now)
+
+	history, err := uc.marketDataRepo.GetHistory(from, now)
+	if err != nil {
+		return err
+	}
+
+	valid, quarantined := screenSnapshots(history)
+	if len(quarantined) > 0 {
+		if err := uc.quarantineRepo.Save(quarantined); err != nil {
+			return err
+		}
+	}
+
+	for _, snapshots := range groupByEmiten(valid) {
+		for _, interval := range rollupIntervals {
+			// A bucket starting before the window, such as the month the first
+			// week begins in, would be saved from partial data
+			candles := candlesFrom(services.AggregateCandles(snapshots, interval), from)
+			if err := uc.candleRepo.SaveCandles(candles); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// QuarantineSnapshots records the snapshots scraped within [from, to] with a
+// price off the tick grid
+func (uc *CandleUseCase) QuarantineSnapshots(from, to time.Time) error {
+	history, err := uc.marketDataRepo.GetHistory(from, to)
+	if err != nil {
+		return err
+	}
+
+	_, quarantined := screenSnapshots(history)
+	if len(quarantined) == 0 {
+		return nil
+	}
+	return uc.quarantineRepo.Save(quarantined)
+}
+
+// StartRollupWorker refreshes the persisted rollups every period until ctx is
+// done. With rollups disabled it only quarantines snapshots: those of the
+// rollup window on the first run and of the current session afterwards.
+func (uc *CandleUseCase) StartRollupWorker(ctx context.Context, period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	screenFrom := rollupWindowStart(time.Now())
+	for {
+		now := time.Now()
+		if uc.rollupEnabled {
+			if err := uc.RefreshRollups(now); err != nil {
+				log.Printf("Failed to refresh candle rollups: %v", err)
+			}
+		} else if err := uc.QuarantineSnapshots(screenFrom, now); err != nil {
+			log.Printf("Failed to quarantine market data: %v", err)
+		} else {
+			screenFrom = services.BucketStart(now, entities.CandleInterval1d)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ListQuarantine returns the snapshots rejected on ingest within [from, to],
+// optionally for one emiten. A zero to means now and a zero from a week
+// before to.
+func (uc *CandleUseCase) ListQuarantine(emiten string, from, to time.Time) (*dto.QuarantineListResponse, error) {
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -7)
+	}
+	if from.After(to) {
+		return nil, fmt.Errorf("%w: from must not be after to", ErrInvalidTimeRange)
+	}
+
+	emiten = strings.ToUpper(strings.TrimSpace(emiten))
+	records, err := uc.quarantineRepo.FindByRange(emiten, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.QuarantinedMarketDataResponse, len(records))
+	for i, q := range records {
+		responses[i] = dto.QuarantinedMarketDataResponse{
+			ID:           q.ID,
+			MarketDataID: q.MarketDataID,
+			Emiten:       q.Emiten,
+			OpenPrice:    q.OpenPrice,
+			HighPrice:    q.HighPrice,
+			LowPrice:     q.LowPrice,
+			ClosePrice:   q.ClosePrice,
+			Date:         q.Date,
+			Reasons:      strings.Split(q.Reason, quarantineReasonSeparator),
+			CreatedAt:    q.CreatedAt,
+		}
+	}
+
+	return &dto.QuarantineListResponse{
+		From:  from,
+		To:    to,
+		Count: len(responses),
+		Data:  responses,
+	}, nil
+}
+
+// Helper functions
+
+// rollupWindowStart returns the start of the window refreshed at now: the
+// Monday on or before the first day of the previous month
+func rollupWindowStart(now time.Time) time.Time {
+	monthStart := services.BucketStart(now, entities.CandleInterval1M).AddDate(0, -1, 0)
+	return services.BucketStart(monthStart, entities.CandleInterval1w)
+}
+
+// rawCandles rolls the snapshots of an emiten into the candles of the buckets
+// starting within [start, to]. Volumes are running session totals, so intraday
+// candles need the snapshots since the session open as a baseline.
+func (uc *CandleUseCase) rawCandles(emiten string, interval entities.CandleInterval, start, to time.Time, adjusted bool) ([]entities.Candle, error) {
+	loadFrom := start
+	if day := services.BucketStart(start, entities.CandleInterval1d); day.Before(loadFrom) {
+		loadFrom = day
+	}
+
+	history, err := uc.marketDataRepo.GetHistoryByEmiten(emiten, loadFrom, to)
+	if err != nil {
+		return nil, err
+	}
+	valid, _ := screenSnapshots(history)
+	if adjusted {
+		adjustments, err := loadPriceAdjustments(uc.actionRepo, uc.marketDataRepo, emiten, loadFrom)
+		if err != nil {
+			return nil, err
+		}
+		valid = services.AdjustMarketData(valid, adjustments)
+	}
+	return candlesFrom(services.AggregateCandles(valid, interval), start), nil
+}
+
+// candlesFrom drops the candles of buckets starting before start
+func candlesFrom(candles []entities.Candle, start time.Time) []entities.Candle {
+	for i, c := range candles {
+		if !c.Time.Before(start) {
+			return candles[i:]
+		}
+	}
+	return nil
+}
+
+// quarantineReasonSeparator joins the validation failures of a snapshot
+const quarantineReasonSeparator = "; "
+
+// screenSnapshots splits snapshots into those fit for the rollups and those
+// with an OHLC price off the tick grid, preserving their order
+func screenSnapshots(data []entities.MarketData) ([]entities.MarketData, []entities.QuarantinedMarketData) {
+	valid := make([]entities.MarketData, 0, len(data))
+	var quarantined []entities.QuarantinedMarketData
+	for _, md := range data {
+		violations := services.ValidateTicks(md)
+		if len(violations) == 0 {
+			valid = append(valid, md)
+			continue
+		}
+
+		reasons := make([]string, len(violations))
+		for i, v := range violations {
+			reasons[i] = v.String()
+		}
+		quarantined = append(quarantined, entities.QuarantinedMarketData{
+			MarketDataID: md.ID,
+			Emiten:       md.Emiten,
+			OpenPrice:    md.OpenPrice,
+			HighPrice:    md.HighPrice,
+			LowPrice:     md.LowPrice,
+			ClosePrice:   md.ClosePrice,
+			Date:         md.Date,
+			Reason:       strings.Join(reasons, quarantineReasonSeparator),
+		})
+	}
+	return valid, quarantined
+}
+
+func isRollupInterval(interval entities.CandleInterval) bool {
+	for _, i := range rollupIntervals {
+		if i == interval {
+			return true
+		}
+	}
+	return false
+}
+
+// groupByEmiten splits snapshots of several emitens, preserving their order
+func groupByEmiten(data []entities.MarketData) map[string][]entities.MarketData {
+	grouped := make(map[string][]entities.MarketData)
+	for _, md := range data {
+		grouped[md.Emiten] = append(grouped[md.Emiten], md)
+	}
+	return grouped
+}
+
+func candlesToResponses(candles []entities.Candle) []dto.CandleResponse {
+	responses := make([]dto.CandleResponse, len(candles))
+	for i, c := range candles {
+		responses[i] = dto.CandleResponse{
+			Time:        c.Time.In(services.WIB),
+			Open:        c.Open,
+			High:        c.High,
+			Low:         c.Low,
+			Close:       c.Close,
+			Volume:      c.Volume,
+			SampleCount: c.SampleCount,
+		}
+	}
+	return responses
+}
@@ -0,0 +1,289 @@
+package usecases
+
+import (
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/repositories"
+	"api-web-scrapping/internal/domain/services"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+var (
+	ErrCorporateActionNotFound = errors.New("corporate action not found")
+	ErrCorporateActionExists   = errors.New("corporate action already exists")
+	ErrInvalidCorporateAction  = errors.New("invalid corporate action")
+)
+
+type CorporateActionUseCase struct {
+	actionRepo repositories.CorporateActionRepository
+}
+
+func NewCorporateActionUseCase(actionRepo repositories.CorporateActionRepository) *CorporateActionUseCase {
+	return &CorporateActionUseCase{
+		actionRepo: actionRepo,
+	}
+}
+
+// List retrieves corporate actions by ex-date, optionally of one emiten. An
+// emiten without a date range lists all of its actions; otherwise a zero
+// from means one year before today and a zero to one year after it.
+func (uc *CorporateActionUseCase) List(emiten string, from, to time.Time) (*dto.CorporateActionListResponse, error) {
+	emiten = entities.NormalizeTicker(emiten)
+
+	var actions []entities.CorporateAction
+	var err error
+	if emiten != "" && from.IsZero() && to.IsZero() {
+		actions, err = uc.actionRepo.FindByEmiten(emiten)
+	} else {
+		today := time.Now()
+		if from.IsZero() {
+			from = today.AddDate(-1, 0, 0)
+		}
+		if to.IsZero() {
+			to = today.AddDate(1, 0, 0)
+		}
+		if from.After(to) {
+			return nil, fmt.Errorf("%w: from must not be after to", ErrInvalidTimeRange)
+		}
+		actions, err = uc.actionRepo.FindByRange(emiten, services.CalendarDate(from), services.CalendarDate(to))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.CorporateActionListResponse{
+		Count: len(actions),
+		Data:  corporateActionsToResponses(actions),
+	}, nil
+}
+
+// Get retrieves a corporate action
+func (uc *CorporateActionUseCase) Get(id int64) (*dto.CorporateActionResponse, error) {
+	action, err := uc.find(id)
+	if err != nil {
+		return nil, err
+	}
+	return corporateActionToResponse(action), nil
+}
+
+// Create records a corporate action; an emiten has at most one action of a
+// type per ex-date
+func (uc *CorporateActionUseCase) Create(req dto.CorporateActionRequest) (*dto.CorporateActionResponse, error) {
+	action, err := requestToCorporateAction(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.checkDuplicate(action); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	action.CreatedAt, action.UpdatedAt = now, now
+	if err := uc.actionRepo.Create(action); err != nil {
+		return nil, err
+	}
+	return corporateActionToResponse(action), nil
+}
+
+// Update replaces the type, ex-date and terms of a corporate action
+func (uc *CorporateActionUseCase) Update(id int64, req dto.CorporateActionRequest) (*dto.CorporateActionResponse, error) {
+	existing, err := uc.find(id)
+	if err != nil {
+		return nil, err
+	}
+
+	action, err := requestToCorporateAction(req)
+	if err != nil {
+		return nil, err
+	}
+	if action.Emiten != existing.Emiten {
+		return nil, fmt.Errorf("%w: emiten cannot be changed", ErrInvalidCorporateAction)
+	}
+	action.ID = existing.ID
+	if err := uc.checkDuplicate(action); err != nil {
+		return nil, err
+	}
+
+	action.CreatedAt, action.UpdatedAt = existing.CreatedAt, time.Now()
+	if err := uc.actionRepo.Update(action); err != nil {
+		return nil, err
+	}
+	return corporateActionToResponse(action), nil
+}
+
+// Delete removes a corporate action
+func (uc *CorporateActionUseCase) Delete(id int64) error {
+	if _, err := uc.find(id); err != nil {
+		return err
+	}
+	return uc.actionRepo.Delete(id)
+}
+
+// Import records corporate actions from a CSV document with the columns
+// emiten, type and ex_date and, depending on the type, ratio, price and
+// amount, plus the optional announcement_date, cum_date, recording_date,
+// payment_date and description. A row with the same emiten, type
+// and ex-date as an existing action updates its terms.
+func (uc *CorporateActionUseCase) Import(reader io.Reader) (*dto.ImportResponse, error) {
+	rows, err := readCSV(reader, "emiten", "type", "ex_date")
+	if err != nil {
+		return nil, err
+	}
+
+	result := newImportResult()
+	now := time.Now()
+	var imported []entities.CorporateAction
+
+	for _, row := range rows {
+		req := dto.CorporateActionRequest{
+			Emiten:           row.get("emiten"),
+			Type:             row.get("type"),
+			ExDate:           row.get("ex_date"),
+			AnnouncementDate: row.get("announcement_date"),
+			CumDate:          row.get("cum_date"),
+			RecordingDate:    row.get("recording_date"),
+			PaymentDate:      row.get("payment_date"),
+			Ratio:            row.get("ratio"),
+			Description:      row.get("description"),
+		}
+		var parseErr error
+		if req.Price, parseErr = parseOptionalFloat(row.get("price")); parseErr != nil {
+			result.fail(row.line, fmt.Errorf("%w: price must be a number", ErrInvalidCorporateAction))
+			continue
+		}
+		if req.Amount, parseErr = parseOptionalFloat(row.get("amount")); parseErr != nil {
+			result.fail(row.line, fmt.Errorf("%w: amount must be a number", ErrInvalidCorporateAction))
+			continue
+		}
+
+		action, err := requestToCorporateAction(req)
+		if err != nil {
+			result.fail(row.line, err)
+			continue
+		}
+
+		action.CreatedAt, action.UpdatedAt = now, now
+		imported = append(imported, *action)
+	}
+
+	if err := uc.actionRepo.Upsert(imported); err != nil {
+		return nil, err
+	}
+	result.response.Imported = len(imported)
+
+	return &result.response, nil
+}
+
+func (uc *CorporateActionUseCase) checkDuplicate(action *entities.CorporateAction) error {
+	existing, err := uc.actionRepo.FindByEmiten(action.Emiten)
+	if err != nil {
+		return err
+	}
+	for _, other := range existing {
+		if other.ID != action.ID && other.Type == action.Type && other.ExDate.Equal(action.ExDate) {
+			return fmt.Errorf("%w: %s %s on %s", ErrCorporateActionExists,
+				other.Emiten, other.Type, other.ExDate.Format(dateOnly))
+		}
+	}
+	return nil
+}
+
+func (uc *CorporateActionUseCase) find(id int64) (*entities.CorporateAction, error) {
+	action, err := uc.actionRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if action == nil {
+		return nil, fmt.Errorf("%w: %d", ErrCorporateActionNotFound, id)
+	}
+	return action, nil
+}
+
+// Helper functions
+
+func requestToCorporateAction(req dto.CorporateActionRequest) (*entities.CorporateAction, error) {
+	action := &entities.CorporateAction{
+		Emiten:      req.Emiten,
+		Type:        entities.CorporateActionType(req.Type),
+		Price:       req.Price,
+		Amount:      req.Amount,
+		Description: req.Description,
+	}
+
+	exDate, err := time.Parse(dateOnly, req.ExDate)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ex_date must be YYYY-MM-DD", ErrInvalidCorporateAction)
+	}
+	action.ExDate = exDate
+
+	schedule := []struct {
+		field string
+		value string
+		date  **time.Time
+	}{
+		{"announcement_date", req.AnnouncementDate, &action.AnnouncementDate},
+		{"cum_date", req.CumDate, &action.CumDate},
+		{"recording_date", req.RecordingDate, &action.RecordingDate},
+		{"payment_date", req.PaymentDate, &action.PaymentDate},
+	}
+	for _, s := range schedule {
+		if s.value == "" {
+			continue
+		}
+		date, err := time.Parse(dateOnly, s.value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s must be YYYY-MM-DD", ErrInvalidCorporateAction, s.field)
+		}
+		*s.date = &date
+	}
+
+	if req.Ratio != "" {
+		action.RatioFrom, action.RatioTo, err = entities.ParseRatio(req.Ratio)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCorporateAction, err)
+		}
+	}
+
+	if err := action.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCorporateAction, err)
+	}
+	return action, nil
+}
+
+func corporateActionToResponse(a *entities.CorporateAction) *dto.CorporateActionResponse {
+	return &dto.CorporateActionResponse{
+		ID:               a.ID,
+		Emiten:           a.Emiten,
+		Type:             string(a.Type),
+		ExDate:           a.ExDate.Format(dateOnly),
+		AnnouncementDate: formatOptionalDate(a.AnnouncementDate),
+		CumDate:          formatOptionalDate(a.CumDate),
+		RecordingDate:    formatOptionalDate(a.RecordingDate),
+		PaymentDate:      formatOptionalDate(a.PaymentDate),
+		Ratio:            a.Ratio(),
+		Price:            a.Price,
+		Amount:           a.Amount,
+		Description:      a.Description,
+		CreatedAt:        a.CreatedAt,
+		UpdatedAt:        a.UpdatedAt,
+	}
+}
+
+func formatOptionalDate(date *time.Time) *string {
+	if date == nil {
+		return nil
+	}
+	formatted := date.Format(dateOnly)
+	return &formatted
+}
+
+func corporateActionsToResponses(actions []entities.CorporateAction) []dto.CorporateActionResponse {
+	responses := make([]dto.CorporateActionResponse, len(actions))
+	for i := range actions {
+		responses[i] = *corporateActionToResponse(&actions[i])
+	}
+	return responses
+}
@@ -0,0 +1,191 @@
+package usecases
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/domain/services"
+)
+
+var ErrInvalidCSV = errors.New("invalid csv")
+
+// csvRow is a CSV record keyed by lower-cased header name
+type csvRow struct {
+	line   int
+	values map[string]string
+}
+
+func (r csvRow) get(column string) string {
+	return strings.TrimSpace(r.values[column])
+}
+
+// optionalInt reads a whole number column, zero when it is empty
+func (r csvRow) optionalInt(column string) (int64, error) {
+	value, err := parseOptionalInt(r.get(column))
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a whole number", column)
+	}
+	return value, nil
+}
+
+// optionalFloat reads a number column, zero when it is empty
+func (r csvRow) optionalFloat(column string) (float64, error) {
+	value, err := parseOptionalFloat(r.get(column))
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a number", column)
+	}
+	return value, nil
+}
+
+// readCSV reads a CSV document with a header row and checks that every
+// required column is present. Unknown columns are ignored.
+func readCSV(reader io.Reader, required ...string) ([]csvRow, error) {
+	cr := csv.NewReader(reader)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: file is empty", ErrInvalidCSV)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCSV, err)
+	}
+
+	columns := make([]string, len(header))
+	present := make(map[string]bool, len(header))
+	for i, name := range header {
+		// Spreadsheet exports often start with a UTF-8 byte order mark
+		columns[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		present[columns[i]] = true
+	}
+	for _, name := range required {
+		if !present[name] {
+			return nil, fmt.Errorf("%w: missing required column %q", ErrInvalidCSV, name)
+		}
+	}
+
+	var rows []csvRow
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCSV, err)
+		}
+
+		line, _ := cr.FieldPos(0)
+		row := csvRow{line: line, values: make(map[string]string, len(columns))}
+		empty := true
+		for i, value := range record {
+			if i < len(columns) {
+				row.values[columns[i]] = value
+			}
+			if strings.TrimSpace(value) != "" {
+				empty = false
+			}
+		}
+		if !empty {
+			rows = append(rows, row)
+		}
+	}
+
+	return rows, nil
+}
+
+// importResult collects per-row outcomes of a CSV import
+type importResult struct {
+	response dto.ImportResponse
+}
+
+func newImportResult() *importResult {
+	return &importResult{response: dto.ImportResponse{Errors: []dto.ImportError{}}}
+}
+
+func (r *importResult) fail(line int, err error) {
+	r.response.Failed++
+	r.response.Errors = append(r.response.Errors, dto.ImportError{Line: line, Message: err.Error()})
+}
+
+// batchPositions returns the 1-based positions by which the requests of a
+// JSON batch of n are reported
+func batchPositions(n int) []int {
+	positions := make([]int, n)
+	for i := range positions {
+		positions[i] = i + 1
+	}
+	return positions
+}
+
+// importRecords converts the requests of a batch and stores the valid
+// records with save. A request that fails to convert is reported in result by
+// lines[i], its position in the batch or its line in the CSV document, and
+// skipped.
+func importRecords[Req, Rec any](result *importResult, reqs []Req, lines []int, convert func(Req) (*Rec, error), save func([]Rec) error) (*dto.ImportResponse, error) {
+	var records []Rec
+	for i, req := range reqs {
+		record, err := convert(req)
+		if err != nil {
+			result.fail(lines[i], err)
+			continue
+		}
+		records = append(records, *record)
+	}
+
+	if err := save(records); err != nil {
+		return nil, err
+	}
+	result.response.Imported = len(records)
+	// Rows that failed to parse were reported before the batch was converted
+	sort.SliceStable(result.response.Errors, func(i, j int) bool {
+		return result.response.Errors[i].Line < result.response.Errors[j].Line
+	})
+
+	return &result.response, nil
+}
+
+// sessionRange resolves the calendar dates of a ranking range. Without from
+// and to it is the latest session with data, and ok is false when there is
+// none; a zero from alone means the date of to and a zero to alone today.
+func sessionRange(from, to time.Time, latestDate func() (*time.Time, error)) (time.Time, time.Time, bool, error) {
+	if from.IsZero() && to.IsZero() {
+		latest, err := latestDate()
+		if err != nil || latest == nil {
+			return from, to, false, err
+		}
+		from, to = *latest, *latest
+	}
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if from.IsZero() {
+		from = to
+	}
+	from, to = services.CalendarDate(from), services.CalendarDate(to)
+	if from.After(to) {
+		return from, to, false, fmt.Errorf("%w: from must not be after to", ErrInvalidTimeRange)
+	}
+	return from, to, true, nil
+}
+
+func parseOptionalInt(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
+
+func parseOptionalFloat(value string) (float64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(value, 64)
+}
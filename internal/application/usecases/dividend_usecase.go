@@ -0,0 +1,212 @@
+package usecases
+
+import (
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/repositories"
+	"api-web-scrapping/internal/domain/services"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// defaultDividendCalendarDays is the range of the dividend calendar when to
+// is omitted
+const defaultDividendCalendarDays = 90
+
+// DividendUseCase serves the cash dividends recorded as corporate actions
+type DividendUseCase struct {
+	actionRepo     repositories.CorporateActionRepository
+	marketDataRepo repositories.MarketDataRepository
+}
+
+func NewDividendUseCase(actionRepo repositories.CorporateActionRepository, marketDataRepo repositories.MarketDataRepository) *DividendUseCase {
+	return &DividendUseCase{
+		actionRepo:     actionRepo,
+		marketDataRepo: marketDataRepo,
+	}
+}
+
+// Upcoming lists the dividends with a cum, ex, recording or payment date in
+// [from, to]. A zero from means today and a zero to 90 days after from.
+func (uc *DividendUseCase) Upcoming(from, to time.Time) (*dto.DividendCalendarResponse, error) {
+	if from.IsZero() {
+		from = time.Now()
+	}
+	if to.IsZero() {
+		to = from.AddDate(0, 0, defaultDividendCalendarDays)
+	}
+	from, to = services.CalendarDate(from), services.CalendarDate(to)
+	if from.After(to) {
+		return nil, fmt.Errorf("%w: from must not be after to", ErrInvalidTimeRange)
+	}
+
+	dividends, err := uc.actionRepo.FindDividendsByDate(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := latestSnapshots(uc.marketDataRepo)
+	if err != nil {
+		return nil, err
+	}
+	closes := make(map[string]float64, len(latest))
+	for _, md := range latestPerEmiten(latest) {
+		closes[md.Emiten] = md.ClosePrice
+	}
+
+	responses := make([]dto.DividendResponse, len(dividends))
+	for i := range dividends {
+		responses[i] = dividendToResponse(&dividends[i], closes[dividends[i].Emiten])
+	}
+
+	return &dto.DividendCalendarResponse{
+		From:  from.Format(dateOnly),
+		To:    to.Format(dateOnly),
+		Count: len(responses),
+		Data:  responses,
+	}, nil
+}
+
+// History lists the dividends of an emiten, newest first, with its
+// trailing-12-month dividend and its yield on the latest close. Totals are
+// restated for later splits and bonus issues.
+func (uc *DividendUseCase) History(emiten string) (*dto.DividendHistoryResponse, error) {
+	emiten = entities.NormalizeTicker(emiten)
+	actions, err := uc.actionRepo.FindByEmiten(emiten)
+	if err != nil {
+		return nil, err
+	}
+	latest, err := latestSnapshot(uc.marketDataRepo, emiten)
+	if err != nil {
+		return nil, err
+	}
+
+	asOf := services.CalendarDate(time.Now())
+	ttm, _ := services.TrailingDividends(actions, asOf)
+	response := &dto.DividendHistoryResponse{
+		Emiten:      emiten,
+		TTMDividend: ttm,
+		Annual:      []dto.AnnualDividendResponse{},
+		Data:        []dto.DividendResponse{},
+	}
+
+	latestClose := 0.0
+	if latest != nil {
+		latestClose = latest.ClosePrice
+		response.LatestClose = &latest.ClosePrice
+		response.LatestDate = &latest.Date
+		response.TTMYieldPct = dividendYield(ttm, latestClose)
+	}
+
+	annual := make(map[int]*dto.AnnualDividendResponse)
+	for i := len(actions) - 1; i >= 0; i-- {
+		a := &actions[i]
+		if a.Type != entities.CorporateActionCashDividend {
+			continue
+		}
+		response.Data = append(response.Data, dividendToResponse(a, latestClose))
+
+		year := a.ExDate.Year()
+		if annual[year] == nil {
+			annual[year] = &dto.AnnualDividendResponse{Year: year}
+		}
+		annual[year].Amount += services.RestatedDividend(*a, actions, asOf)
+		annual[year].Count++
+	}
+	for _, a := range annual {
+		response.Annual = append(response.Annual, *a)
+	}
+	sort.Slice(response.Annual, func(i, j int) bool { return response.Annual[i].Year > response.Annual[j].Year })
+
+	return response, nil
+}
+
+// Yields ranks the emitens that went ex-dividend in the last twelve months by
+// their trailing-12-month dividend yield on the latest close
+func (uc *DividendUseCase) Yields() (*dto.DividendYieldListResponse, error) {
+	asOf := services.CalendarDate(time.Now())
+	actions, err := uc.actionRepo.FindByRange("", asOf.AddDate(-1, 0, 0), asOf)
+	if err != nil {
+		return nil, err
+	}
+	latest, err := latestSnapshots(uc.marketDataRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	actionsByEmiten := make(map[string][]entities.CorporateAction)
+	for _, a := range actions {
+		actionsByEmiten[a.Emiten] = append(actionsByEmiten[a.Emiten], a)
+	}
+
+	yields := []dto.DividendYieldResponse{}
+	for _, md := range latestPerEmiten(latest) {
+		ttm, dividends := services.TrailingDividends(actionsByEmiten[md.Emiten], asOf)
+		yield := dividendYield(ttm, md.ClosePrice)
+		if len(dividends) == 0 || yield == nil {
+			continue
+		}
+		yields = append(yields, dto.DividendYieldResponse{
+			Emiten:      md.Emiten,
+			Close:       md.ClosePrice,
+			TTMDividend: ttm,
+			TTMYieldPct: *yield,
+			Dividends:   len(dividends),
+		})
+	}
+	sort.SliceStable(yields, func(i, j int) bool { return yields[i].TTMYieldPct > yields[j].TTMYieldPct })
+
+	return &dto.DividendYieldListResponse{
+		AsOf:  asOf.Format(dateOnly),
+		Count: len(yields),
+		Data:  yields,
+	}, nil
+}
+
+// Helper functions
+
+// latestPerEmiten keeps one snapshot per emiten, the latest by date and then
+// by ID, in order of first appearance. The latest view can return several
+// snapshots of an emiten scraped at the same time.
+func latestPerEmiten(snapshots []entities.MarketData) []entities.MarketData {
+	index := make(map[string]int, len(snapshots))
+	kept := make([]entities.MarketData, 0, len(snapshots))
+	for _, md := range snapshots {
+		i, seen := index[md.Emiten]
+		if !seen {
+			index[md.Emiten] = len(kept)
+			kept = append(kept, md)
+			continue
+		}
+		if md.Date.After(kept[i].Date) || (md.Date.Equal(kept[i].Date) && md.ID > kept[i].ID) {
+			kept[i] = md
+		}
+	}
+	return kept
+}
+
+// dividendYield returns an amount as a percentage of a close, or nil without
+// a positive close
+func dividendYield(amount, close float64) *float64 {
+	if close <= 0 {
+		return nil
+	}
+	yield := amount / close * 100
+	return &yield
+}
+
+func dividendToResponse(a *entities.CorporateAction, latestClose float64) dto.DividendResponse {
+	return dto.DividendResponse{
+		ID:               a.ID,
+		Emiten:           a.Emiten,
+		Amount:           a.Amount,
+		AnnouncementDate: formatOptionalDate(a.AnnouncementDate),
+		CumDate:          formatOptionalDate(a.CumDate),
+		ExDate:           a.ExDate.Format(dateOnly),
+		RecordingDate:    formatOptionalDate(a.RecordingDate),
+		PaymentDate:      formatOptionalDate(a.PaymentDate),
+		YieldPct:         dividendYield(a.Amount, latestClose),
+		Description:      a.Description,
+	}
+}
@@ -0,0 +1,290 @@
+package usecases
+
+import (
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/repositories"
+	"api-web-scrapping/internal/domain/services"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	ErrEmitenNotFound = errors.New("emiten not found")
+	ErrEmitenExists   = errors.New("emiten already exists")
+	ErrInvalidEmiten  = errors.New("invalid emiten")
+)
+
+const (
+	defaultEmitenSearchLimit = 10
+	maxEmitenSearchLimit     = 50
+)
+
+type EmitenUseCase struct {
+	emitenRepo repositories.EmitenRepository
+	index      *services.EmitenIndex
+	indexMu    sync.Mutex
+}
+
+func NewEmitenUseCase(emitenRepo repositories.EmitenRepository) *EmitenUseCase {
+	return &EmitenUseCase{
+		emitenRepo: emitenRepo,
+		index:      services.NewEmitenIndex(),
+	}
+}
+
+// List retrieves every emiten
+func (uc *EmitenUseCase) List() (*dto.EmitenListResponse, error) {
+	emitens, err := uc.emitenRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.EmitenResponse, len(emitens))
+	for i := range emitens {
+		responses[i] = *emitenToResponse(&emitens[i])
+	}
+	return &dto.EmitenListResponse{Data: responses}, nil
+}
+
+// Search matches tickers by prefix and company names fuzzily. The index is
+// rebuilt on the first search after the emiten list changed.
+func (uc *EmitenUseCase) Search(query string, limit int) (*dto.EmitenSearchResponse, error) {
+	if limit <= 0 {
+		limit = defaultEmitenSearchLimit
+	}
+	if limit > maxEmitenSearchLimit {
+		limit = maxEmitenSearchLimit
+	}
+
+	if err := uc.ensureIndex(); err != nil {
+		return nil, err
+	}
+
+	results := uc.index.Search(query, limit)
+	data := make([]dto.EmitenSearchResult, len(results))
+	for i, r := range results {
+		data[i] = dto.EmitenSearchResult{
+			Ticker:      r.Emiten.Ticker,
+			CompanyName: r.Emiten.CompanyName,
+			Sector:      r.Emiten.Sector,
+			Board:       r.Emiten.Board,
+			MatchedOn:   r.MatchedOn,
+			Score:       r.Score,
+		}
+	}
+
+	return &dto.EmitenSearchResponse{Query: query, Data: data}, nil
+}
+
+func (uc *EmitenUseCase) ensureIndex() error {
+	if uc.index.Built() {
+		return nil
+	}
+
+	// Serialize rebuilds so a burst of searches loads the list only once
+	uc.indexMu.Lock()
+	defer uc.indexMu.Unlock()
+	if uc.index.Built() {
+		return nil
+	}
+
+	// A change saved while the list loads invalidates it again
+	generation := uc.index.Generation()
+	emitens, err := uc.emitenRepo.FindAll()
+	if err != nil {
+		return err
+	}
+	uc.index.Rebuild(emitens, generation)
+	return nil
+}
+
+// Get retrieves an emiten by ticker
+func (uc *EmitenUseCase) Get(ticker string) (*dto.EmitenResponse, error) {
+	emiten, err := uc.find(ticker)
+	if err != nil {
+		return nil, err
+	}
+	return emitenToResponse(emiten), nil
+}
+
+// Create adds a new emiten
+func (uc *EmitenUseCase) Create(req dto.EmitenRequest) (*dto.EmitenResponse, error) {
+	emiten, err := requestToEmiten(req)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.emitenRepo.FindByTicker(emiten.Ticker)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("%w: %s", ErrEmitenExists, emiten.Ticker)
+	}
+
+	now := time.Now()
+	emiten.CreatedAt, emiten.UpdatedAt = now, now
+	if err := uc.emitenRepo.Create(emiten); err != nil {
+		return nil, err
+	}
+	uc.index.Invalidate()
+	return emitenToResponse(emiten), nil
+}
+
+// Update replaces the master data of an existing emiten
+func (uc *EmitenUseCase) Update(ticker string, req dto.EmitenRequest) (*dto.EmitenResponse, error) {
+	existing, err := uc.find(ticker)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Ticker = existing.Ticker
+	emiten, err := requestToEmiten(req)
+	if err != nil {
+		return nil, err
+	}
+
+	emiten.CreatedAt, emiten.UpdatedAt = existing.CreatedAt, time.Now()
+	if err := uc.emitenRepo.Update(emiten); err != nil {
+		return nil, err
+	}
+	uc.index.Invalidate()
+	return emitenToResponse(emiten), nil
+}
+
+// Delete removes an emiten
+func (uc *EmitenUseCase) Delete(ticker string) error {
+	emiten, err := uc.find(ticker)
+	if err != nil {
+		return err
+	}
+	if err := uc.emitenRepo.Delete(emiten.Ticker); err != nil {
+		return err
+	}
+	uc.index.Invalidate()
+	return nil
+}
+
+// Import upserts emitens from a CSV document with the columns ticker,
+// company_name, sector, sub_industry, board, listing_date,
+// shares_outstanding and is_sharia. Only ticker and company_name are
+// required; invalid rows are reported and skipped.
+func (uc *EmitenUseCase) Import(reader io.Reader) (*dto.ImportResponse, error) {
+	rows, err := readCSV(reader, "ticker", "company_name")
+	if err != nil {
+		return nil, err
+	}
+
+	result := newImportResult()
+	now := time.Now()
+	var emitens []entities.Emiten
+	seen := make(map[string]int)
+
+	for _, row := range rows {
+		req := dto.EmitenRequest{
+			Ticker:      row.get("ticker"),
+			CompanyName: row.get("company_name"),
+			Sector:      row.get("sector"),
+			SubIndustry: row.get("sub_industry"),
+			Board:       row.get("board"),
+			ListingDate: row.get("listing_date"),
+		}
+		if value := row.get("shares_outstanding"); value != "" {
+			shares, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				result.fail(row.line, fmt.Errorf("invalid shares_outstanding %q", value))
+				continue
+			}
+			req.SharesOutstanding = shares
+		}
+		if value := row.get("is_sharia"); value != "" {
+			sharia, err := strconv.ParseBool(value)
+			if err != nil {
+				result.fail(row.line, fmt.Errorf("invalid is_sharia %q", value))
+				continue
+			}
+			req.IsSharia = sharia
+		}
+
+		emiten, err := requestToEmiten(req)
+		if err != nil {
+			result.fail(row.line, err)
+			continue
+		}
+		if line, ok := seen[emiten.Ticker]; ok {
+			result.fail(row.line, fmt.Errorf("duplicate ticker %s, first seen on line %d", emiten.Ticker, line))
+			continue
+		}
+		seen[emiten.Ticker] = row.line
+
+		emiten.CreatedAt, emiten.UpdatedAt = now, now
+		emitens = append(emitens, *emiten)
+	}
+
+	if err := uc.emitenRepo.Upsert(emitens); err != nil {
+		return nil, err
+	}
+	uc.index.Invalidate()
+	result.response.Imported = len(emitens)
+
+	return &result.response, nil
+}
+
+func (uc *EmitenUseCase) find(ticker string) (*entities.Emiten, error) {
+	ticker = entities.NormalizeTicker(ticker)
+	emiten, err := uc.emitenRepo.FindByTicker(ticker)
+	if err != nil {
+		return nil, err
+	}
+	if emiten == nil {
+		return nil, fmt.Errorf("%w: %s", ErrEmitenNotFound, ticker)
+	}
+	return emiten, nil
+}
+
+// Helper functions
+
+func requestToEmiten(req dto.EmitenRequest) (*entities.Emiten, error) {
+	emiten := &entities.Emiten{
+		Ticker:            req.Ticker,
+		CompanyName:       req.CompanyName,
+		Sector:            req.Sector,
+		SubIndustry:       req.SubIndustry,
+		Board:             req.Board,
+		SharesOutstanding: req.SharesOutstanding,
+		IsSharia:          req.IsSharia,
+	}
+
+	if req.ListingDate != "" {
+		date, err := time.Parse(dateOnly, req.ListingDate)
+		if err != nil {
+			return nil, fmt.Errorf("%w: listing_date must be YYYY-MM-DD", ErrInvalidEmiten)
+		}
+		emiten.ListingDate = &date
+	}
+
+	if err := emiten.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEmiten, err)
+	}
+	return emiten, nil
+}
+
+func emitenToResponse(emiten *entities.Emiten) *dto.EmitenResponse {
+	return &dto.EmitenResponse{
+		Ticker:            emiten.Ticker,
+		CompanyName:       emiten.CompanyName,
+		Sector:            emiten.Sector,
+		SubIndustry:       emiten.SubIndustry,
+		Board:             emiten.Board,
+		ListingDate:       emiten.ListingDate,
+		SharesOutstanding: emiten.SharesOutstanding,
+		IsSharia:          emiten.IsSharia,
+		CreatedAt:         emiten.CreatedAt,
+		UpdatedAt:         emiten.UpdatedAt,
+	}
+}
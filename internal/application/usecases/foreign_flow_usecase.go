@@ -0,0 +1,261 @@
+package usecases
+
+import (
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/repositories"
+	"api-web-scrapping/internal/domain/services"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+const (
+	// defaultForeignFlowDays is the range of a foreign flow history when
+	// from is omitted
+	defaultForeignFlowDays = 30
+
+	defaultForeignFlowRankingLimit = 10
+	maxForeignFlowRankingLimit     = 100
+)
+
+var ErrInvalidForeignFlow = errors.New("invalid foreign flow")
+
+type ForeignFlowUseCase struct {
+	flowRepo repositories.ForeignFlowRepository
+}
+
+func NewForeignFlowUseCase(flowRepo repositories.ForeignFlowRepository) *ForeignFlowUseCase {
+	return &ForeignFlowUseCase{
+		flowRepo: flowRepo,
+	}
+}
+
+// History retrieves the daily foreign flows of an emiten in [from, to]. A
+// zero to means today and a zero from 30 days before to.
+func (uc *ForeignFlowUseCase) History(emiten string, from, to time.Time) (*dto.ForeignFlowHistoryResponse, error) {
+	emiten = entities.NormalizeTicker(emiten)
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -defaultForeignFlowDays)
+	}
+	from, to = services.CalendarDate(from), services.CalendarDate(to)
+	if from.After(to) {
+		return nil, fmt.Errorf("%w: from must not be after to", ErrInvalidTimeRange)
+	}
+
+	flows, err := uc.flowRepo.FindByEmiten(emiten, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.ForeignFlowResponse, len(flows))
+	for i := range flows {
+		responses[i] = foreignFlowToResponse(&flows[i])
+	}
+	total := sumForeignFlows(flows)[emiten]
+	if total == nil {
+		total = &dto.ForeignFlowTotalResponse{}
+	}
+	total.Emiten = ""
+
+	return &dto.ForeignFlowHistoryResponse{
+		Emiten: emiten,
+		From:   from.Format(dateOnly),
+		To:     to.Format(dateOnly),
+		Count:  len(responses),
+		Total:  *total,
+		Data:   responses,
+	}, nil
+}
+
+// Rankings lists the emitens with the largest foreign net buy and net sell
+// values summed over [from, to], up to limit each. Without a range it ranks
+// the latest session with data; a zero from alone means the date of to and a
+// zero to alone today.
+func (uc *ForeignFlowUseCase) Rankings(from, to time.Time, limit int) (*dto.ForeignFlowRankingResponse, error) {
+	if limit <= 0 {
+		limit = defaultForeignFlowRankingLimit
+	}
+	if limit > maxForeignFlowRankingLimit {
+		limit = maxForeignFlowRankingLimit
+	}
+
+	from, to, ok, err := sessionRange(from, to, uc.flowRepo.LatestDate)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return &dto.ForeignFlowRankingResponse{
+			NetBuys:  []dto.ForeignFlowTotalResponse{},
+			NetSells: []dto.ForeignFlowTotalResponse{},
+		}, nil
+	}
+
+	flows, err := uc.flowRepo.FindByRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var buys, sells []dto.ForeignFlowTotalResponse
+	for _, total := range sumForeignFlows(flows) {
+		switch {
+		case total.NetValue > 0:
+			buys = append(buys, *total)
+		case total.NetValue < 0:
+			sells = append(sells, *total)
+		}
+	}
+	sort.Slice(buys, func(i, j int) bool {
+		if buys[i].NetValue != buys[j].NetValue {
+			return buys[i].NetValue > buys[j].NetValue
+		}
+		return buys[i].Emiten < buys[j].Emiten
+	})
+	sort.Slice(sells, func(i, j int) bool {
+		if sells[i].NetValue != sells[j].NetValue {
+			return sells[i].NetValue < sells[j].NetValue
+		}
+		return sells[i].Emiten < sells[j].Emiten
+	})
+
+	return &dto.ForeignFlowRankingResponse{
+		From:     from.Format(dateOnly),
+		To:       to.Format(dateOnly),
+		NetBuys:  truncateForeignFlowTotals(buys, limit),
+		NetSells: truncateForeignFlowTotals(sells, limit),
+	}, nil
+}
+
+// Ingest records a batch of daily flows. A flow with the same emiten and
+// date as a stored one replaces its totals. Invalid flows are reported by
+// their 1-based position in the batch and skipped.
+func (uc *ForeignFlowUseCase) Ingest(reqs []dto.ForeignFlowRequest) (*dto.ImportResponse, error) {
+	return uc.save(newImportResult(), reqs, batchPositions(len(reqs)))
+}
+
+// Import records daily flows from a CSV document with the columns emiten and
+// date and the optional buy_volume, sell_volume, buy_value and sell_value,
+// which default to zero. A row with the same emiten and date as a stored
+// flow replaces its totals.
+func (uc *ForeignFlowUseCase) Import(reader io.Reader) (*dto.ImportResponse, error) {
+	rows, err := readCSV(reader, "emiten", "date")
+	if err != nil {
+		return nil, err
+	}
+
+	result := newImportResult()
+	var reqs []dto.ForeignFlowRequest
+	var lines []int
+
+	for _, row := range rows {
+		req := dto.ForeignFlowRequest{
+			Emiten: row.get("emiten"),
+			Date:   row.get("date"),
+		}
+		var parseErr error
+		req.BuyVolume, parseErr = row.optionalInt("buy_volume")
+		if parseErr == nil {
+			req.SellVolume, parseErr = row.optionalInt("sell_volume")
+		}
+		if parseErr == nil {
+			req.BuyValue, parseErr = row.optionalFloat("buy_value")
+		}
+		if parseErr == nil {
+			req.SellValue, parseErr = row.optionalFloat("sell_value")
+		}
+		if parseErr != nil {
+			result.fail(row.line, fmt.Errorf("%w: %v", ErrInvalidForeignFlow, parseErr))
+			continue
+		}
+
+		reqs = append(reqs, req)
+		lines = append(lines, row.line)
+	}
+
+	return uc.save(result, reqs, lines)
+}
+
+// save records the valid flows of a batch, reporting the invalid ones in
+// result by their lines
+func (uc *ForeignFlowUseCase) save(result *importResult, reqs []dto.ForeignFlowRequest, lines []int) (*dto.ImportResponse, error) {
+	now := time.Now()
+	return importRecords(result, reqs, lines, func(req dto.ForeignFlowRequest) (*entities.ForeignFlow, error) {
+		flow, err := requestToForeignFlow(req)
+		if err != nil {
+			return nil, err
+		}
+		flow.CreatedAt, flow.UpdatedAt = now, now
+		return flow, nil
+	}, uc.flowRepo.Upsert)
+}
+
+// Helper functions
+
+func requestToForeignFlow(req dto.ForeignFlowRequest) (*entities.ForeignFlow, error) {
+	date, err := time.Parse(dateOnly, req.Date)
+	if err != nil {
+		return nil, fmt.Errorf("%w: date must be YYYY-MM-DD", ErrInvalidForeignFlow)
+	}
+
+	flow := &entities.ForeignFlow{
+		Emiten:     req.Emiten,
+		Date:       date,
+		BuyVolume:  req.BuyVolume,
+		SellVolume: req.SellVolume,
+		BuyValue:   req.BuyValue,
+		SellValue:  req.SellValue,
+	}
+	if err := flow.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidForeignFlow, err)
+	}
+	return flow, nil
+}
+
+// sumForeignFlows totals flows by emiten
+func sumForeignFlows(flows []entities.ForeignFlow) map[string]*dto.ForeignFlowTotalResponse {
+	totals := make(map[string]*dto.ForeignFlowTotalResponse)
+	for i := range flows {
+		f := &flows[i]
+		total, ok := totals[f.Emiten]
+		if !ok {
+			total = &dto.ForeignFlowTotalResponse{Emiten: f.Emiten}
+			totals[f.Emiten] = total
+		}
+		total.Days++
+		total.BuyVolume += f.BuyVolume
+		total.SellVolume += f.SellVolume
+		total.NetVolume += f.NetVolume()
+		total.BuyValue += f.BuyValue
+		total.SellValue += f.SellValue
+		total.NetValue += f.NetValue()
+	}
+	return totals
+}
+
+func truncateForeignFlowTotals(totals []dto.ForeignFlowTotalResponse, limit int) []dto.ForeignFlowTotalResponse {
+	if len(totals) > limit {
+		totals = totals[:limit]
+	}
+	if totals == nil {
+		totals = []dto.ForeignFlowTotalResponse{}
+	}
+	return totals
+}
+
+func foreignFlowToResponse(f *entities.ForeignFlow) dto.ForeignFlowResponse {
+	return dto.ForeignFlowResponse{
+		Emiten:     f.Emiten,
+		Date:       f.Date.Format(dateOnly),
+		BuyVolume:  f.BuyVolume,
+		SellVolume: f.SellVolume,
+		NetVolume:  f.NetVolume(),
+		BuyValue:   f.BuyValue,
+		SellValue:  f.SellValue,
+		NetValue:   f.NetValue(),
+	}
+}
@@ -0,0 +1,266 @@
+package usecases
+
+import (
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/repositories"
+	"api-web-scrapping/internal/domain/services"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+var (
+	ErrMembershipNotFound = errors.New("index membership not found")
+	ErrMembershipOverlap  = errors.New("index membership overlaps an existing one")
+	ErrInvalidMembership  = errors.New("invalid index membership")
+)
+
+type IndexMembershipUseCase struct {
+	membershipRepo repositories.IndexMembershipRepository
+}
+
+func NewIndexMembershipUseCase(membershipRepo repositories.IndexMembershipRepository) *IndexMembershipUseCase {
+	return &IndexMembershipUseCase{
+		membershipRepo: membershipRepo,
+	}
+}
+
+// Members retrieves the members of an index on a calendar date, by default
+// today
+func (uc *IndexMembershipUseCase) Members(indexCode string, asOf time.Time) (*dto.IndexMembersResponse, error) {
+	indexCode = entities.NormalizeIndexCode(indexCode)
+	if asOf.IsZero() {
+		asOf = time.Now()
+	}
+	asOf = services.CalendarDate(asOf)
+
+	memberships, err := uc.membershipRepo.FindActive(indexCode, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.IndexMembersResponse{
+		Index: indexCode,
+		AsOf:  asOf.Format(dateOnly),
+		Count: len(memberships),
+		Data:  membershipsToResponses(memberships),
+	}, nil
+}
+
+// List retrieves the membership history of an index, optionally of one emiten
+func (uc *IndexMembershipUseCase) List(indexCode, emiten string) (*dto.IndexMembershipListResponse, error) {
+	indexCode = entities.NormalizeIndexCode(indexCode)
+	memberships, err := uc.membershipRepo.FindByIndex(indexCode, entities.NormalizeTicker(emiten))
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.IndexMembershipListResponse{
+		Index: indexCode,
+		Data:  membershipsToResponses(memberships),
+	}, nil
+}
+
+// Create records a membership that must not overlap another membership of
+// the same emiten in the same index
+func (uc *IndexMembershipUseCase) Create(req dto.IndexMembershipRequest) (*dto.IndexMembershipResponse, error) {
+	membership, err := requestToMembership(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.checkOverlap(membership); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	membership.CreatedAt, membership.UpdatedAt = now, now
+	if err := uc.membershipRepo.Create(membership); err != nil {
+		return nil, err
+	}
+	return membershipToResponse(membership), nil
+}
+
+// Update replaces the emiten and date range of a membership
+func (uc *IndexMembershipUseCase) Update(id int64, req dto.IndexMembershipRequest) (*dto.IndexMembershipResponse, error) {
+	existing, err := uc.find(id)
+	if err != nil {
+		return nil, err
+	}
+
+	membership, err := requestToMembership(req)
+	if err != nil {
+		return nil, err
+	}
+	if membership.IndexCode != existing.IndexCode {
+		return nil, fmt.Errorf("%w: index cannot be changed", ErrInvalidMembership)
+	}
+	membership.ID = existing.ID
+	if err := uc.checkOverlap(membership); err != nil {
+		return nil, err
+	}
+
+	membership.CreatedAt, membership.UpdatedAt = existing.CreatedAt, time.Now()
+	if err := uc.membershipRepo.Update(membership); err != nil {
+		return nil, err
+	}
+	return membershipToResponse(membership), nil
+}
+
+// Delete removes a membership
+func (uc *IndexMembershipUseCase) Delete(id int64) error {
+	if _, err := uc.find(id); err != nil {
+		return err
+	}
+	return uc.membershipRepo.Delete(id)
+}
+
+// Import records memberships from a CSV document with the columns index,
+// emiten, from and to. A row with the same index, emiten and from date as an
+// existing membership updates its to date; rows that overlap another
+// membership are reported and skipped.
+func (uc *IndexMembershipUseCase) Import(reader io.Reader) (*dto.ImportResponse, error) {
+	rows, err := readCSV(reader, "index", "emiten", "from")
+	if err != nil {
+		return nil, err
+	}
+
+	result := newImportResult()
+	now := time.Now()
+	existingByIndex := make(map[string][]entities.IndexMembership)
+	var imported []entities.IndexMembership
+
+	for _, row := range rows {
+		membership, err := requestToMembership(dto.IndexMembershipRequest{
+			Index:  row.get("index"),
+			Emiten: row.get("emiten"),
+			From:   row.get("from"),
+			To:     row.get("to"),
+		})
+		if err != nil {
+			result.fail(row.line, err)
+			continue
+		}
+
+		existing, ok := existingByIndex[membership.IndexCode]
+		if !ok {
+			if existing, err = uc.membershipRepo.FindByIndex(membership.IndexCode, ""); err != nil {
+				return nil, err
+			}
+			existingByIndex[membership.IndexCode] = existing
+		}
+
+		if conflict := findOverlap(membership, existing, imported); conflict != nil {
+			result.fail(row.line, fmt.Errorf("%w: %s in %s from %s", ErrMembershipOverlap,
+				conflict.Emiten, conflict.IndexCode, conflict.EffectiveFrom.Format(dateOnly)))
+			continue
+		}
+
+		membership.CreatedAt, membership.UpdatedAt = now, now
+		imported = append(imported, *membership)
+	}
+
+	if err := uc.membershipRepo.Upsert(imported); err != nil {
+		return nil, err
+	}
+	result.response.Imported = len(imported)
+
+	return &result.response, nil
+}
+
+func (uc *IndexMembershipUseCase) checkOverlap(membership *entities.IndexMembership) error {
+	existing, err := uc.membershipRepo.FindByIndex(membership.IndexCode, membership.Emiten)
+	if err != nil {
+		return err
+	}
+	for i := range existing {
+		if existing[i].ID != membership.ID && membership.Overlaps(&existing[i]) {
+			return fmt.Errorf("%w: %s in %s from %s", ErrMembershipOverlap,
+				existing[i].Emiten, existing[i].IndexCode, existing[i].EffectiveFrom.Format(dateOnly))
+		}
+	}
+	return nil
+}
+
+func (uc *IndexMembershipUseCase) find(id int64) (*entities.IndexMembership, error) {
+	membership, err := uc.membershipRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if membership == nil {
+		return nil, fmt.Errorf("%w: %d", ErrMembershipNotFound, id)
+	}
+	return membership, nil
+}
+
+// Helper functions
+
+// findOverlap returns a membership that overlaps m, ignoring a stored one
+// with the same effective-from date since importing m updates it
+func findOverlap(m *entities.IndexMembership, stored, pending []entities.IndexMembership) *entities.IndexMembership {
+	for i := range stored {
+		if stored[i].Emiten == m.Emiten && stored[i].EffectiveFrom.Equal(m.EffectiveFrom) {
+			continue
+		}
+		if m.Overlaps(&stored[i]) {
+			return &stored[i]
+		}
+	}
+	for i := range pending {
+		if m.Overlaps(&pending[i]) {
+			return &pending[i]
+		}
+	}
+	return nil
+}
+
+func requestToMembership(req dto.IndexMembershipRequest) (*entities.IndexMembership, error) {
+	membership := &entities.IndexMembership{
+		IndexCode: req.Index,
+		Emiten:    req.Emiten,
+	}
+
+	from, err := time.Parse(dateOnly, req.From)
+	if err != nil {
+		return nil, fmt.Errorf("%w: from must be YYYY-MM-DD", ErrInvalidMembership)
+	}
+	membership.EffectiveFrom = from
+
+	if req.To != "" {
+		to, err := time.Parse(dateOnly, req.To)
+		if err != nil {
+			return nil, fmt.Errorf("%w: to must be YYYY-MM-DD", ErrInvalidMembership)
+		}
+		membership.EffectiveTo = &to
+	}
+
+	if err := membership.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidMembership, err)
+	}
+	return membership, nil
+}
+
+func membershipToResponse(m *entities.IndexMembership) *dto.IndexMembershipResponse {
+	response := &dto.IndexMembershipResponse{
+		ID:        m.ID,
+		Index:     m.IndexCode,
+		Emiten:    m.Emiten,
+		From:      m.EffectiveFrom.Format(dateOnly),
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+	if m.EffectiveTo != nil {
+		to := m.EffectiveTo.Format(dateOnly)
+		response.To = &to
+	}
+	return response
+}
+
+func membershipsToResponses(memberships []entities.IndexMembership) []dto.IndexMembershipResponse {
+	responses := make([]dto.IndexMembershipResponse, len(memberships))
+	for i := range memberships {
+		responses[i] = *membershipToResponse(&memberships[i])
+	}
+	return responses
+}
@@ -0,0 +1,468 @@
+package usecases
+
+import (
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/repositories"
+	"api-web-scrapping/internal/domain/services"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrIndexNotFound = errors.New("index not found")
+	ErrIndexExists   = errors.New("index already exists")
+	ErrInvalidIndex  = errors.New("invalid index")
+)
+
+const (
+	defaultIndexBaseValue = 100
+	defaultIndexLookback  = 365 * 24 * time.Hour
+	// maxIndexRange bounds a series, which is computed from every snapshot
+	// of the constituents
+	maxIndexRange = 366 * 24 * time.Hour
+	// indexPriceLookback is how many sessions back a constituent's last price
+	// is searched for when it did not trade on a reference date
+	indexPriceLookback = 5
+)
+
+type IndexUseCase struct {
+	indexRepo      repositories.IndexRepository
+	marketDataRepo repositories.MarketDataRepository
+	emitenRepo     repositories.EmitenRepository
+}
+
+func NewIndexUseCase(indexRepo repositories.IndexRepository, marketDataRepo repositories.MarketDataRepository, emitenRepo repositories.EmitenRepository) *IndexUseCase {
+	return &IndexUseCase{
+		indexRepo:      indexRepo,
+		marketDataRepo: marketDataRepo,
+		emitenRepo:     emitenRepo,
+	}
+}
+
+// List retrieves every index with its current constituents
+func (uc *IndexUseCase) List() (*dto.IndexListResponse, error) {
+	indices, err := uc.indexRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.IndexResponse, 0, len(indices))
+	for i := range indices {
+		periods, err := uc.indexRepo.FindPeriods(indices[i].Code)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, *indexToResponse(&indices[i], periods))
+	}
+	return &dto.IndexListResponse{Data: responses}, nil
+}
+
+// Get retrieves an index with its current constituents
+func (uc *IndexUseCase) Get(code string) (*dto.IndexResponse, error) {
+	index, periods, err := uc.find(code)
+	if err != nil {
+		return nil, err
+	}
+	return indexToResponse(index, periods), nil
+}
+
+// Create defines an index. The divisor is set so the index equals its base
+// value at the constituents' closes on the base date.
+func (uc *IndexUseCase) Create(req dto.IndexRequest) (*dto.IndexResponse, error) {
+	baseValue := req.BaseValue
+	if baseValue == 0 {
+		baseValue = defaultIndexBaseValue
+	}
+	baseDate, err := parseIndexDate("base_date", req.BaseDate)
+	if err != nil {
+		return nil, err
+	}
+
+	index := &entities.Index{
+		Code:      req.Code,
+		Name:      req.Name,
+		Weighting: req.Weighting,
+		BaseDate:  baseDate,
+		BaseValue: baseValue,
+	}
+	if err := index.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIndex, err)
+	}
+
+	existing, err := uc.indexRepo.FindByCode(index.Code)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("%w: %s", ErrIndexExists, index.Code)
+	}
+
+	constituents, err := requestToConstituents(req.Constituents)
+	if err != nil {
+		return nil, err
+	}
+
+	// Base prices are the closes of the base date itself
+	prices, err := uc.referencePrices(constituentEmitens(constituents), baseDate.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	constituents, aggregate, err := uc.weigh(index.Weighting, constituents, prices)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	index.CreatedAt, index.UpdatedAt = now, now
+	base := &entities.IndexPeriod{
+		IndexCode:     index.Code,
+		EffectiveFrom: baseDate,
+		Divisor:       aggregate / baseValue,
+		Reason:        entities.IndexPeriodBase,
+		Constituents:  constituents,
+		CreatedAt:     now,
+	}
+	if err := uc.indexRepo.Create(index, base); err != nil {
+		return nil, err
+	}
+
+	return indexToResponse(index, []entities.IndexPeriod{*base}), nil
+}
+
+// Update renames an index; weighting and base cannot change once the index
+// has a history
+func (uc *IndexUseCase) Update(code string, req dto.IndexUpdateRequest) (*dto.IndexResponse, error) {
+	index, periods, err := uc.find(code)
+	if err != nil {
+		return nil, err
+	}
+
+	index.Name = req.Name
+	if err := index.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIndex, err)
+	}
+	index.UpdatedAt = time.Now()
+	if err := uc.indexRepo.Update(index); err != nil {
+		return nil, err
+	}
+	return indexToResponse(index, periods), nil
+}
+
+// Delete removes an index and its history
+func (uc *IndexUseCase) Delete(code string) error {
+	index, _, err := uc.find(code)
+	if err != nil {
+		return err
+	}
+	return uc.indexRepo.Delete(index.Code)
+}
+
+// Rebalance replaces the constituents of an index from the session of the
+// effective date. The divisor is adjusted so that, at the closes of the
+// previous session, the index has the same level under the old and new
+// constituents.
+func (uc *IndexUseCase) Rebalance(code string, req dto.IndexRebalanceRequest) (*dto.IndexResponse, error) {
+	index, periods, err := uc.find(code)
+	if err != nil {
+		return nil, err
+	}
+
+	effective := services.CalendarDate(time.Now())
+	if req.EffectiveDate != "" {
+		if effective, err = parseIndexDate("effective_date", req.EffectiveDate); err != nil {
+			return nil, err
+		}
+	}
+	current := periods[len(periods)-1]
+	if !effective.After(services.CalendarDate(current.EffectiveFrom)) {
+		return nil, fmt.Errorf("%w: effective_date must be after %s", ErrInvalidIndex, current.EffectiveFrom.Format(dateOnly))
+	}
+
+	constituents, err := requestToConstituents(req.Constituents)
+	if err != nil {
+		return nil, err
+	}
+
+	emitens := append(current.Emitens(), constituentEmitens(constituents)...)
+	prices, err := uc.referencePrices(emitens, effective)
+	if err != nil {
+		return nil, err
+	}
+	oldAggregate, err := services.IndexAggregate(current.Constituents, prices)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIndex, err)
+	}
+	constituents, newAggregate, err := uc.weigh(index.Weighting, constituents, prices)
+	if err != nil {
+		return nil, err
+	}
+
+	period := entities.IndexPeriod{
+		IndexCode:     index.Code,
+		EffectiveFrom: effective,
+		Divisor:       current.Divisor * newAggregate / oldAggregate,
+		Reason:        entities.IndexPeriodRebalance,
+		Constituents:  constituents,
+		CreatedAt:     time.Now(),
+	}
+	if err := uc.indexRepo.CreatePeriod(&period); err != nil {
+		return nil, err
+	}
+
+	return indexToResponse(index, append(periods, period)), nil
+}
+
+// ListDivisors retrieves the constituent and divisor history of an index
+func (uc *IndexUseCase) ListDivisors(code string) (*dto.IndexDivisorListResponse, error) {
+	index, periods, err := uc.find(code)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]dto.IndexDivisorResponse, len(periods))
+	for i, p := range periods {
+		data[i] = dto.IndexDivisorResponse{
+			EffectiveFrom: p.EffectiveFrom.Format(dateOnly),
+			Divisor:       p.Divisor,
+			Reason:        p.Reason,
+			Constituents:  constituentsToResponses(p.Constituents),
+		}
+	}
+	return &dto.IndexDivisorListResponse{Code: index.Code, Data: data}, nil
+}
+
+// GetSeries computes the daily OHLC series of an index within [from, to],
+// by default the last year. Items use the market data response shape with
+// the index code as emiten.
+func (uc *IndexUseCase) GetSeries(code string, from, to time.Time) (*dto.MarketDataListResponse, error) {
+	index, periods, err := uc.find(code)
+	if err != nil {
+		return nil, err
+	}
+
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if from.IsZero() {
+		from = to.Add(-defaultIndexLookback)
+	}
+	if err := validateRange(from, to, maxIndexRange); err != nil {
+		return nil, err
+	}
+
+	candles, err := uc.computeCandles(index, periods, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.MarketDataListResponse{Data: indexCandlesToResponses(candles)}, nil
+}
+
+// GetLatest computes the most recent session of an index
+func (uc *IndexUseCase) GetLatest(code string) (*dto.MarketDataResponse, error) {
+	index, periods, err := uc.find(code)
+	if err != nil {
+		return nil, err
+	}
+
+	to := time.Now()
+	candles, err := uc.computeCandles(index, periods, to.AddDate(0, 0, -sessionsToCalendarDays(1)), to)
+	if err != nil {
+		return nil, err
+	}
+	if len(candles) == 0 {
+		return nil, fmt.Errorf("%w: no recent data for index %s", ErrIndexNotFound, index.Code)
+	}
+
+	responses := indexCandlesToResponses(candles[len(candles)-1:])
+	return &responses[0], nil
+}
+
+func (uc *IndexUseCase) computeCandles(index *entities.Index, periods []entities.IndexPeriod, from, to time.Time) ([]entities.Candle, error) {
+	from = services.BucketStart(from, entities.CandleInterval1d)
+
+	seen := make(map[string]bool)
+	snapshotsByEmiten := make(map[string][]entities.MarketData)
+	historyFrom := from.AddDate(0, 0, -sessionsToCalendarDays(indexPriceLookback))
+	for _, p := range periods {
+		for _, emiten := range p.Emitens() {
+			if seen[emiten] {
+				continue
+			}
+			seen[emiten] = true
+
+			snapshots, err := uc.marketDataRepo.GetHistoryByEmiten(emiten, historyFrom, to)
+			if err != nil {
+				return nil, err
+			}
+			snapshotsByEmiten[emiten], _ = screenSnapshots(snapshots)
+		}
+	}
+
+	return services.ComputeIndexCandles(index.Code, periods, snapshotsByEmiten, from, to), nil
+}
+
+// referencePrices returns the last close before the session of date for each
+// emiten, looking back a few sessions for emitens that did not trade
+func (uc *IndexUseCase) referencePrices(emitens []string, date time.Time) (map[string]float64, error) {
+	// date is a calendar date; its session starts at midnight WIB
+	year, month, day := date.Date()
+	sessionStart := time.Date(year, month, day, 0, 0, 0, 0, services.WIB)
+	from := sessionStart.AddDate(0, 0, -sessionsToCalendarDays(indexPriceLookback))
+
+	prices := make(map[string]float64, len(emitens))
+	for _, emiten := range emitens {
+		if _, ok := prices[emiten]; ok {
+			continue
+		}
+		candles, err := loadDailyCandles(uc.marketDataRepo, emiten, from, sessionStart.Add(-time.Nanosecond))
+		if err != nil {
+			return nil, err
+		}
+		if len(candles) == 0 {
+			return nil, fmt.Errorf("%w: no price for %s before %s", ErrInvalidIndex, emiten, date.Format(dateOnly))
+		}
+		prices[emiten] = candles[len(candles)-1].Close
+	}
+	return prices, nil
+}
+
+// weigh fixes constituent weights and returns their aggregate at prices
+func (uc *IndexUseCase) weigh(weighting string, constituents []entities.IndexConstituent, prices map[string]float64) ([]entities.IndexConstituent, float64, error) {
+	shares := make(map[string]int64)
+	if weighting == entities.IndexWeightingMarketCap || weighting == entities.IndexWeightingFreeFloat {
+		emitens, err := uc.emitenRepo.FindAll()
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, e := range emitens {
+			shares[e.Ticker] = e.SharesOutstanding
+		}
+	}
+
+	weighted, err := services.IndexWeights(weighting, constituents, prices, shares)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %v", ErrInvalidIndex, err)
+	}
+	aggregate, err := services.IndexAggregate(weighted, prices)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %v", ErrInvalidIndex, err)
+	}
+	return weighted, aggregate, nil
+}
+
+func (uc *IndexUseCase) find(code string) (*entities.Index, []entities.IndexPeriod, error) {
+	code = entities.NormalizeIndexCode(code)
+	index, err := uc.indexRepo.FindByCode(code)
+	if err != nil {
+		return nil, nil, err
+	}
+	if index == nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrIndexNotFound, code)
+	}
+
+	periods, err := uc.indexRepo.FindPeriods(code)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(periods) == 0 {
+		return nil, nil, fmt.Errorf("index %s has no base period", code)
+	}
+	return index, periods, nil
+}
+
+// Helper functions
+
+// parseIndexDate parses a YYYY-MM-DD calendar date that must not be in the future
+func parseIndexDate(field, value string) (time.Time, error) {
+	date, err := time.Parse(dateOnly, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s must be YYYY-MM-DD", ErrInvalidIndex, field)
+	}
+	if date.After(services.CalendarDate(time.Now())) {
+		return time.Time{}, fmt.Errorf("%w: %s must not be in the future", ErrInvalidIndex, field)
+	}
+	return date, nil
+}
+
+func requestToConstituents(reqs []dto.IndexConstituentRequest) ([]entities.IndexConstituent, error) {
+	if len(reqs) == 0 {
+		return nil, fmt.Errorf("%w: at least one constituent is required", ErrInvalidIndex)
+	}
+
+	seen := make(map[string]bool, len(reqs))
+	constituents := make([]entities.IndexConstituent, len(reqs))
+	for i, req := range reqs {
+		emiten := entities.NormalizeTicker(req.Emiten)
+		if emiten == "" {
+			return nil, fmt.Errorf("%w: constituent emiten is required", ErrInvalidIndex)
+		}
+		if seen[emiten] {
+			return nil, fmt.Errorf("%w: duplicate constituent %s", ErrInvalidIndex, emiten)
+		}
+		seen[emiten] = true
+		constituents[i] = entities.IndexConstituent{Emiten: emiten, FreeFloat: req.FreeFloat}
+	}
+	return constituents, nil
+}
+
+func constituentEmitens(constituents []entities.IndexConstituent) []string {
+	emitens := make([]string, len(constituents))
+	for i, c := range constituents {
+		emitens[i] = c.Emiten
+	}
+	return emitens
+}
+
+func indexToResponse(index *entities.Index, periods []entities.IndexPeriod) *dto.IndexResponse {
+	response := &dto.IndexResponse{
+		Code:         index.Code,
+		Name:         index.Name,
+		Weighting:    index.Weighting,
+		BaseDate:     index.BaseDate.Format(dateOnly),
+		BaseValue:    index.BaseValue,
+		Constituents: []dto.IndexConstituentResponse{},
+		CreatedAt:    index.CreatedAt,
+		UpdatedAt:    index.UpdatedAt,
+	}
+	if len(periods) > 0 {
+		current := periods[len(periods)-1]
+		response.Divisor = current.Divisor
+		response.Constituents = constituentsToResponses(current.Constituents)
+	}
+	return response
+}
+
+func constituentsToResponses(constituents []entities.IndexConstituent) []dto.IndexConstituentResponse {
+	responses := make([]dto.IndexConstituentResponse, len(constituents))
+	for i, c := range constituents {
+		responses[i] = dto.IndexConstituentResponse{
+			Emiten:    c.Emiten,
+			FreeFloat: c.FreeFloat,
+			Weight:    c.Weight,
+		}
+	}
+	return responses
+}
+
+// indexCandlesToResponses presents index sessions in the market data shape;
+// created_at and updated_at carry the session date as the series is computed
+func indexCandlesToResponses(candles []entities.Candle) []dto.MarketDataResponse {
+	responses := make([]dto.MarketDataResponse, len(candles))
+	for i, c := range candles {
+		date := c.Time.In(services.WIB)
+		responses[i] = dto.MarketDataResponse{
+			Emiten:     c.Emiten,
+			OpenPrice:  c.Open,
+			HighPrice:  c.High,
+			LowPrice:   c.Low,
+			ClosePrice: c.Close,
+			Date:       date,
+			CreatedAt:  date,
+			UpdatedAt:  date,
+		}
+	}
+	return responses
+}
@@ -0,0 +1,236 @@
+package usecases
+
+import (
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/repositories"
+	"api-web-scrapping/internal/domain/services"
+	"api-web-scrapping/pkg/indicators"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidIndicator     = errors.New("invalid indicator")
+	ErrIndicatorUnavailable = errors.New("indicator unavailable")
+)
+
+// indicatorParams holds resolved indicator parameters after defaults are applied
+type indicatorParams struct {
+	Period     int
+	Fast       int
+	Slow       int
+	Signal     int
+	Smooth     int
+	Multiplier float64
+}
+
+type IndicatorUseCase struct {
+	marketDataRepo repositories.MarketDataRepository
+	actionRepo     repositories.CorporateActionRepository
+}
+
+func NewIndicatorUseCase(marketDataRepo repositories.MarketDataRepository, actionRepo repositories.CorporateActionRepository) *IndicatorUseCase {
+	return &IndicatorUseCase{
+		marketDataRepo: marketDataRepo,
+		actionRepo:     actionRepo,
+	}
+}
+
+// GetIndicator computes an indicator over the daily closes of an emiten. Extra
+// history before from is loaded so the requested range is already warmed up
+// wherever enough data exists. A zero to means now and a zero from means one
+// year before to.
+func (uc *IndicatorUseCase) GetIndicator(emiten string, req dto.IndicatorRequest, from, to time.Time) (*dto.IndicatorResponse, error) {
+	name := normalizeIndicatorName(req.Name)
+	params, err := resolveIndicatorParams(name, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if from.IsZero() {
+		from = to.AddDate(-1, 0, 0)
+	}
+	if from.After(to) {
+		return nil, fmt.Errorf("%w: from must not be after to", ErrInvalidTimeRange)
+	}
+
+	warmUp := indicatorWarmUp(name, params)
+	candles, err := loadDailyCandles(uc.marketDataRepo, emiten, from.AddDate(0, 0, -sessionsToCalendarDays(warmUp)), to)
+	if err != nil {
+		return nil, err
+	}
+	if len(candles) == 0 {
+		return nil, fmt.Errorf("no market data found for emiten %s", emiten)
+	}
+	if req.Adjusted {
+		adjustments, err := loadPriceAdjustments(uc.actionRepo, uc.marketDataRepo, emiten, candles[0].Time)
+		if err != nil {
+			return nil, err
+		}
+		candles = services.AdjustCandles(candles, adjustments)
+	}
+
+	series, err := computeIndicator(name, params, candles)
+	if err != nil {
+		return nil, err
+	}
+
+	rangeStart := services.BucketStart(from, entities.CandleInterval1d)
+	var points []dto.IndicatorPoint
+	for i, c := range candles {
+		if c.Time.Before(rangeStart) {
+			continue
+		}
+		values := make(map[string]*float64, len(series))
+		for key, s := range series {
+			values[key] = nullableFloat(s[i])
+		}
+		points = append(points, dto.IndicatorPoint{Date: c.Time, Values: values})
+	}
+
+	return &dto.IndicatorResponse{
+		Emiten:        emiten,
+		Indicator:     name,
+		Params:        params.toMap(name),
+		WarmUpPeriods: warmUp,
+		Adjusted:      req.Adjusted,
+		Data:          points,
+	}, nil
+}
+
+// computeIndicator returns the named output series of an indicator, each
+// aligned with candles
+func computeIndicator(name string, p indicatorParams, candles []entities.Candle) (map[string][]float64, error) {
+	highs, lows, closes := candleSeries(candles)
+
+	switch name {
+	case "sma":
+		return map[string][]float64{"sma": indicators.SMA(closes, p.Period)}, nil
+	case "ema":
+		return map[string][]float64{"ema": indicators.EMA(closes, p.Period)}, nil
+	case "wma":
+		return map[string][]float64{"wma": indicators.WMA(closes, p.Period)}, nil
+	case "rsi":
+		return map[string][]float64{"rsi": indicators.RSI(closes, p.Period)}, nil
+	case "macd":
+		macd, signal, histogram := indicators.MACD(closes, p.Fast, p.Slow, p.Signal)
+		return map[string][]float64{"macd": macd, "signal": signal, "histogram": histogram}, nil
+	case "bollinger":
+		middle, upper, lower := indicators.BollingerBands(closes, p.Period, p.Multiplier)
+		return map[string][]float64{"middle": middle, "upper": upper, "lower": lower}, nil
+	case "stochastic":
+		k, d := indicators.Stochastic(highs, lows, closes, p.Period, p.Smooth)
+		return map[string][]float64{"k": k, "d": d}, nil
+	case "atr":
+		return map[string][]float64{"atr": indicators.ATR(highs, lows, closes, p.Period)}, nil
+	case "obv":
+		volumes, ok := candleVolumes(candles)
+		if !ok {
+			return nil, fmt.Errorf("%w: obv requires traded volume, which the snapshots in range do not carry", ErrIndicatorUnavailable)
+		}
+		return map[string][]float64{"obv": indicators.OBV(closes, volumes)}, nil
+	}
+	return nil, fmt.Errorf("%w: unknown indicator %q, expected one of %s", ErrInvalidIndicator, name, strings.Join(supportedIndicators(), ", "))
+}
+
+func normalizeIndicatorName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	switch name {
+	case "bb", "bbands":
+		return "bollinger"
+	case "stoch":
+		return "stochastic"
+	}
+	return name
+}
+
+func resolveIndicatorParams(name string, req dto.IndicatorRequest) (indicatorParams, error) {
+	p := indicatorParams{
+		Period:     req.Period,
+		Fast:       req.Fast,
+		Slow:       req.Slow,
+		Signal:     req.Signal,
+		Smooth:     req.Smooth,
+		Multiplier: req.Multiplier,
+	}
+	if p.Period < 0 || p.Fast < 0 || p.Slow < 0 || p.Signal < 0 || p.Smooth < 0 || p.Multiplier < 0 {
+		return p, fmt.Errorf("%w: parameters must not be negative", ErrInvalidIndicator)
+	}
+
+	defaultPeriod := map[string]int{
+		"sma": 20, "ema": 20, "wma": 20, "rsi": 14, "bollinger": 20, "stochastic": 14, "atr": 14,
+	}
+	if p.Period == 0 {
+		p.Period = defaultPeriod[name]
+	}
+	if p.Fast == 0 {
+		p.Fast = 12
+	}
+	if p.Slow == 0 {
+		p.Slow = 26
+	}
+	if p.Signal == 0 {
+		p.Signal = 9
+	}
+	if p.Smooth == 0 {
+		p.Smooth = 3
+	}
+	if p.Multiplier == 0 {
+		p.Multiplier = 2
+	}
+	if name == "macd" && p.Fast >= p.Slow {
+		return p, fmt.Errorf("%w: fast period must be shorter than slow period", ErrInvalidIndicator)
+	}
+	return p, nil
+}
+
+// indicatorWarmUp returns the number of sessions before the first value
+func indicatorWarmUp(name string, p indicatorParams) int {
+	switch name {
+	case "macd":
+		return p.Slow + p.Signal - 2
+	case "stochastic":
+		return p.Period + p.Smooth - 2
+	case "rsi":
+		return p.Period
+	case "obv":
+		return 0
+	}
+	return p.Period - 1
+}
+
+func (p indicatorParams) toMap(name string) map[string]float64 {
+	switch name {
+	case "macd":
+		return map[string]float64{"fast": float64(p.Fast), "slow": float64(p.Slow), "signal": float64(p.Signal)}
+	case "bollinger":
+		return map[string]float64{"period": float64(p.Period), "multiplier": p.Multiplier}
+	case "stochastic":
+		return map[string]float64{"period": float64(p.Period), "smooth": float64(p.Smooth)}
+	case "obv":
+		return map[string]float64{}
+	}
+	return map[string]float64{"period": float64(p.Period)}
+}
+
+// supportedIndicators lists the indicator names accepted by computeIndicator
+func supportedIndicators() []string {
+	names := []string{"sma", "ema", "wma", "rsi", "macd", "bollinger", "stochastic", "atr", "obv"}
+	sort.Strings(names)
+	return names
+}
+
+func nullableFloat(v float64) *float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return nil
+	}
+	return &v
+}
@@ -4,34 +4,52 @@ import (
 	"api-web-scrapping/internal/application/dto"
 	"api-web-scrapping/internal/domain/entities"
 	"api-web-scrapping/internal/domain/repositories"
+	"api-web-scrapping/internal/domain/services"
 	"fmt"
+	"time"
 )
 
 type MarketDataUseCase struct {
 	marketDataRepo repositories.MarketDataRepository
+	emitenRepo     repositories.EmitenRepository
+	actionRepo     repositories.CorporateActionRepository
+	statusRepo     repositories.TradingStatusRepository
+	autoRejection  services.AutoRejectionRules
 }
 
-func NewMarketDataUseCase(marketDataRepo repositories.MarketDataRepository) *MarketDataUseCase {
+func NewMarketDataUseCase(marketDataRepo repositories.MarketDataRepository, emitenRepo repositories.EmitenRepository, actionRepo repositories.CorporateActionRepository, statusRepo repositories.TradingStatusRepository, autoRejection services.AutoRejectionRules) *MarketDataUseCase {
 	return &MarketDataUseCase{
 		marketDataRepo: marketDataRepo,
+		emitenRepo:     emitenRepo,
+		actionRepo:     actionRepo,
+		statusRepo:     statusRepo,
+		autoRejection:  autoRejection,
 	}
 }
 
-// GetAll retrieves all market data from the view
-func (uc *MarketDataUseCase) GetAll() (*dto.MarketDataListResponse, error) {
+// GetAll retrieves all market data from the view, flagged with today's
+// trading status
+func (uc *MarketDataUseCase) GetAll(query dto.MarketDataQuery) (*dto.MarketDataListResponse, error) {
 	marketDataList, err := uc.marketDataRepo.GetAll()
+	if err == nil {
+		marketDataList, err = replaceOffTick(uc.marketDataRepo, marketDataList)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	return &dto.MarketDataListResponse{
-		Data: entitiesToResponses(marketDataList),
-	}, nil
+	return uc.toListResponse(marketDataList, query, time.Now())
 }
 
-// GetByEmiten retrieves market data for a specific emiten
-func (uc *MarketDataUseCase) GetByEmiten(emiten string) (*dto.MarketDataListResponse, error) {
+// GetByEmiten retrieves market data for a specific emiten, each item flagged
+// with the trading status of its session. With query.Adjusted, prices, VWAP
+// and volumes are adjusted for its corporate actions; embedded auto-rejection
+// limits stay as traded.
+func (uc *MarketDataUseCase) GetByEmiten(emiten string, query dto.MarketDataQuery) (*dto.MarketDataListResponse, error) {
 	marketDataList, err := uc.marketDataRepo.GetByEmiten(emiten)
+	if err == nil {
+		marketDataList, err = replaceOffTick(uc.marketDataRepo, marketDataList)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -40,14 +58,56 @@ func (uc *MarketDataUseCase) GetByEmiten(emiten string) (*dto.MarketDataListResp
 		return nil, fmt.Errorf("no market data found for emiten %s", emiten)
 	}
 
-	return &dto.MarketDataListResponse{
-		Data: entitiesToResponses(marketDataList),
-	}, nil
+	response, err := uc.toListResponse(marketDataList, query, time.Time{})
+	if err != nil || !query.Adjusted {
+		return response, err
+	}
+
+	earliest := marketDataList[0].Date
+	for _, md := range marketDataList {
+		if md.Date.Before(earliest) {
+			earliest = md.Date
+		}
+	}
+	adjustments, err := loadPriceAdjustments(uc.actionRepo, uc.marketDataRepo, emiten, earliest)
+	if err != nil {
+		return nil, err
+	}
+	for i := range response.Data {
+		factor := adjustments.FactorAt(response.Data[i].Date)
+		response.Data[i].OpenPrice *= factor
+		response.Data[i].HighPrice *= factor
+		response.Data[i].LowPrice *= factor
+		response.Data[i].ClosePrice *= factor
+		if response.Data[i].VWAP != nil {
+			vwap := *response.Data[i].VWAP * factor
+			response.Data[i].VWAP = &vwap
+		}
+		if volume := services.AdjustVolume(response.Data[i].Volume, factor); volume != nil {
+			lots := *volume / entities.SharesPerLot
+			response.Data[i].Volume = volume
+			response.Data[i].VolumeLots = &lots
+		}
+	}
+	response.Adjusted = true
+	return response, nil
 }
 
-// GetLatestByEmiten retrieves the latest market data for a specific emiten
-func (uc *MarketDataUseCase) GetLatestByEmiten(emiten string) (*dto.MarketDataResponse, error) {
-	marketData, err := uc.marketDataRepo.GetLatestByEmiten(emiten)
+// GetLatestByEmiten retrieves the latest market data for a specific emiten,
+// or the data that was the latest at query.AsOf, flagged with the trading
+// status of that date rather than of the session, which may be stale. A
+// snapshot off the tick grid gives way to the last valid one of its session.
+func (uc *MarketDataUseCase) GetLatestByEmiten(emiten string, query dto.MarketDataQuery) (*dto.MarketDataResponse, error) {
+	var marketData *entities.MarketData
+	var err error
+	if query.AsOf.IsZero() {
+		marketData, err = latestSnapshot(uc.marketDataRepo, emiten)
+	} else {
+		marketData, err = uc.marketDataRepo.GetLatestByEmitenAsOf(emiten, query.AsOf)
+		if err == nil && marketData != nil {
+			marketData, err = firstSnapshot(replaceOffTick(uc.marketDataRepo, []entities.MarketData{*marketData}))
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -56,23 +116,187 @@ func (uc *MarketDataUseCase) GetLatestByEmiten(emiten string) (*dto.MarketDataRe
 		return nil, fmt.Errorf("no market data found for emiten %s", emiten)
 	}
 
-	return entityToResponse(marketData), nil
+	statusDate := query.AsOf
+	if statusDate.IsZero() {
+		statusDate = time.Now()
+	}
+	statuses, err := tradingStatusFlags(uc.statusRepo, []entities.MarketData{*marketData}, statusDate)
+	if err != nil {
+		return nil, err
+	}
+
+	response := entityToResponse(marketData)
+	response.TradingStatus = statuses[0]
+	if query.IncludeCompany || query.IncludeLimits {
+		company, err := uc.emitenRepo.FindByTicker(marketData.Emiten)
+		if err != nil {
+			return nil, err
+		}
+		if company != nil && query.IncludeCompany {
+			response.Company = emitenToResponse(company)
+		}
+
+		if query.IncludeLimits {
+			boards := map[string]string{}
+			if company != nil {
+				boards[company.Ticker] = company.Board
+			}
+			limits, err := autoRejections(uc.marketDataRepo, uc.autoRejection, boards, statuses, []entities.MarketData{*marketData})
+			if err != nil {
+				return nil, err
+			}
+			response.AutoRejection = limits[0]
+		}
+	}
+
+	return response, nil
 }
 
-// GetLatestByAllEmiten retrieves the latest market data for all emitens
-func (uc *MarketDataUseCase) GetLatestByAllEmiten() (*dto.MarketDataListResponse, error) {
-	marketDataList, err := uc.marketDataRepo.GetLatestByAllEmiten()
+// GetLatestByAllEmiten retrieves the latest market data for all emitens, or
+// the data that was the latest at query.AsOf, flagged with the trading status
+// of that date. Snapshots off the tick grid give way to the last valid one of
+// their session.
+func (uc *MarketDataUseCase) GetLatestByAllEmiten(query dto.MarketDataQuery) (*dto.MarketDataListResponse, error) {
+	if query.AsOf.IsZero() {
+		marketDataList, err := latestSnapshots(uc.marketDataRepo)
+		if err != nil {
+			return nil, err
+		}
+		return uc.toListResponse(marketDataList, query, time.Now())
+	}
+
+	marketDataList, err := uc.marketDataRepo.GetLatestByAllEmitenAsOf(query.AsOf)
+	if err == nil {
+		marketDataList, err = replaceOffTick(uc.marketDataRepo, marketDataList)
+	}
 	if err != nil {
 		return nil, err
 	}
+	response, err := uc.toListResponse(marketDataList, query, query.AsOf)
+	if err != nil {
+		return nil, err
+	}
+	response.AsOf = &query.AsOf
+	return response, nil
+}
+
+// toListResponse converts market data to responses flagged with their
+// trading status on the date of statusAsOf, or of their session when it is
+// zero. Items excluded by the query are dropped; company master data and
+// auto-rejection limits are embedded when requested.
+func (uc *MarketDataUseCase) toListResponse(marketDataList []entities.MarketData, query dto.MarketDataQuery, statusAsOf time.Time) (*dto.MarketDataListResponse, error) {
+	statuses, err := tradingStatusFlags(uc.statusRepo, marketDataList, statusAsOf)
+	if err != nil {
+		return nil, err
+	}
+	marketDataList, statuses = excludeByStatus(marketDataList, statuses, query)
+
+	responses := entitiesToResponses(marketDataList)
+	for i := range responses {
+		responses[i].TradingStatus = statuses[i]
+	}
+	if !query.IncludeCompany && !query.IncludeLimits {
+		return &dto.MarketDataListResponse{Data: responses}, nil
+	}
+
+	emitens, err := uc.emitenRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if query.IncludeCompany {
+		companies := make(map[string]*dto.EmitenResponse, len(emitens))
+		for i := range emitens {
+			companies[emitens[i].Ticker] = emitenToResponse(&emitens[i])
+		}
+		for i := range responses {
+			responses[i].Company = companies[responses[i].Emiten]
+		}
+	}
+
+	if query.IncludeLimits {
+		limits, err := autoRejections(uc.marketDataRepo, uc.autoRejection, emitenBoards(emitens), statuses, marketDataList)
+		if err != nil {
+			return nil, err
+		}
+		for i := range responses {
+			responses[i].AutoRejection = limits[i]
+		}
+	}
 
 	return &dto.MarketDataListResponse{
-		Data: entitiesToResponses(marketDataList),
+		Data: responses,
 	}, nil
 }
 
 // Helper functions
 
+// tradingStatusFlags returns the trading status of each snapshot on the
+// calendar date of asOf or, when asOf is zero, of its session
+func tradingStatusFlags(statusRepo repositories.TradingStatusRepository, data []entities.MarketData, asOf time.Time) ([]*dto.TradingStatusFlags, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var from, to time.Time
+	if !asOf.IsZero() {
+		from = services.CalendarDate(asOf)
+		to = from
+	} else {
+		from, to = services.CalendarDate(data[0].Date), services.CalendarDate(data[0].Date)
+		for _, md := range data {
+			day := services.CalendarDate(md.Date)
+			if day.Before(from) {
+				from = day
+			}
+			if day.After(to) {
+				to = day
+			}
+		}
+	}
+
+	statuses, err := statusRepo.FindByRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+	statusesByEmiten := make(map[string][]entities.TradingStatus)
+	for _, s := range statuses {
+		statusesByEmiten[s.Emiten] = append(statusesByEmiten[s.Emiten], s)
+	}
+
+	flags := make([]*dto.TradingStatusFlags, len(data))
+	for i, md := range data {
+		day := from
+		if asOf.IsZero() {
+			day = services.CalendarDate(md.Date)
+		}
+		flags[i] = statusToFlags(statusOn(statusesByEmiten[md.Emiten], day))
+	}
+	return flags, nil
+}
+
+// excludeByStatus drops the snapshots whose trading status the query
+// excludes, keeping snapshots and statuses aligned
+func excludeByStatus(data []entities.MarketData, statuses []*dto.TradingStatusFlags, query dto.MarketDataQuery) ([]entities.MarketData, []*dto.TradingStatusFlags) {
+	if !query.ExcludeSuspended && !query.ExcludeDelisted && !query.ExcludeNotations && !query.ExcludeWatchlist {
+		return data, statuses
+	}
+
+	keptData := make([]entities.MarketData, 0, len(data))
+	keptStatuses := make([]*dto.TradingStatusFlags, 0, len(statuses))
+	for i, s := range statuses {
+		if (query.ExcludeSuspended && s.Suspended) ||
+			(query.ExcludeDelisted && s.Status == entities.EmitenStatusDelisted) ||
+			(query.ExcludeNotations && len(s.Notations) > 0) ||
+			(query.ExcludeWatchlist && s.Watchlist) {
+			continue
+		}
+		keptData = append(keptData, data[i])
+		keptStatuses = append(keptStatuses, s)
+	}
+	return keptData, keptStatuses
+}
+
 func entityToResponse(entity *entities.MarketData) *dto.MarketDataResponse {
 	return &dto.MarketDataResponse{
 		ID:         entity.ID,
@@ -81,6 +305,11 @@ func entityToResponse(entity *entities.MarketData) *dto.MarketDataResponse {
 		HighPrice:  entity.HighPrice,
 		LowPrice:   entity.LowPrice,
 		ClosePrice: entity.ClosePrice,
+		Volume:     entity.Volume,
+		VolumeLots: entity.Lots(),
+		Value:      entity.Value,
+		Frequency:  entity.Frequency,
+		VWAP:       entity.VWAP(),
 		Date:       entity.Date,
 		CreatedAt:  entity.CreatedAt,
 		UpdatedAt:  entity.UpdatedAt,
@@ -89,18 +318,8 @@ func entityToResponse(entity *entities.MarketData) *dto.MarketDataResponse {
 
 func entitiesToResponses(entities []entities.MarketData) []dto.MarketDataResponse {
 	responses := make([]dto.MarketDataResponse, len(entities))
-	for i, entity := range entities {
-		responses[i] = dto.MarketDataResponse{
-			ID:         entity.ID,
-			Emiten:     entity.Emiten,
-			OpenPrice:  entity.OpenPrice,
-			HighPrice:  entity.HighPrice,
-			LowPrice:   entity.LowPrice,
-			ClosePrice: entity.ClosePrice,
-			Date:       entity.Date,
-			CreatedAt:  entity.CreatedAt,
-			UpdatedAt:  entity.UpdatedAt,
-		}
+	for i := range entities {
+		responses[i] = *entityToResponse(&entities[i])
 	}
 	return responses
 }
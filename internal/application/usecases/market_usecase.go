@@ -0,0 +1,310 @@
+package usecases
+
+import (
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/repositories"
+	"api-web-scrapping/internal/domain/services"
+	"api-web-scrapping/pkg/tradingcalendar"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// defaultBreadthLookback is the range of the breadth series when from is omitted
+const defaultBreadthLookback = 3 * 30 * 24 * time.Hour
+
+// sectorLookbackDays is how many calendar days before the market's latest
+// session an emiten must have traded to count in the sector moves. Three weeks
+// covers five sessions even across the Lebaran collective leave.
+const sectorLookbackDays = 21
+
+// maxTickList bounds the prices listed by ListTicks
+const maxTickList = 1000
+
+var ErrInvalidTickRequest = errors.New("invalid tick request")
+
+// MarketUseCase computes market-wide aggregates over the latest data of
+// every emiten
+type MarketUseCase struct {
+	marketDataRepo repositories.MarketDataRepository
+	emitenRepo     repositories.EmitenRepository
+	statusRepo     repositories.TradingStatusRepository
+	calendar       *tradingcalendar.Calendar
+	autoRejection  services.AutoRejectionRules
+}
+
+func NewMarketUseCase(marketDataRepo repositories.MarketDataRepository, emitenRepo repositories.EmitenRepository, statusRepo repositories.TradingStatusRepository, calendar *tradingcalendar.Calendar, autoRejection services.AutoRejectionRules) *MarketUseCase {
+	return &MarketUseCase{
+		marketDataRepo: marketDataRepo,
+		emitenRepo:     emitenRepo,
+		statusRepo:     statusRepo,
+		calendar:       calendar,
+		autoRejection:  autoRejection,
+	}
+}
+
+// GetStatus reports the IDX trading phase at now and the next open and close
+func (uc *MarketUseCase) GetStatus(now time.Time) *dto.MarketStatusResponse {
+	status := uc.calendar.StatusAt(now)
+	return &dto.MarketStatusResponse{
+		Time:               status.Time,
+		Phase:              string(status.Phase),
+		IsOpen:             status.Phase.AcceptsOrders(),
+		IsTradingDay:       status.TradingDay,
+		Holiday:            status.Holiday,
+		NextOpen:           status.NextOpen,
+		NextClose:          status.NextClose,
+		PreviousTradingDay: status.PreviousTradingDay.Format(dateOnly),
+	}
+}
+
+// GetSectors aggregates the latest price moves per sector. Each emiten moves
+// against the close of its previous session; emitens delisted on the latest
+// session by their trading status and emitens that have not traded within
+// sectorLookbackDays of the market's latest session are left out, and emitens
+// without master data are reported as unclassified.
+func (uc *MarketUseCase) GetSectors() (*dto.SectorListResponse, error) {
+	latest, err := latestSnapshots(uc.marketDataRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	emitens, err := uc.emitenRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	masterData := make(map[string]entities.Emiten, len(emitens))
+	for _, e := range emitens {
+		masterData[e.Ticker] = e
+	}
+
+	response := &dto.SectorListResponse{Data: []dto.SectorResponse{}}
+	if len(latest) == 0 {
+		return response, nil
+	}
+
+	to := latest[0].Date
+	for _, md := range latest {
+		if md.Date.After(to) {
+			to = md.Date
+		}
+	}
+	// The window is fixed from the market's latest session, so that one
+	// long-suspended emiten does not widen it
+	session := services.BucketStart(to, entities.CandleInterval1d)
+	windowStart := session.AddDate(0, 0, -sectorLookbackDays)
+	candlesByEmiten, err := loadDailyCandlesByEmiten(uc.marketDataRepo, windowStart, to)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := tradingStatusFlags(uc.statusRepo, latest, to)
+	if err != nil {
+		return nil, err
+	}
+
+	quotes := make([]services.SectorQuote, 0, len(latest))
+	for i, md := range latest {
+		company := masterData[md.Emiten]
+		if statuses[i].Status == entities.EmitenStatusDelisted || md.Date.Before(windowStart) {
+			continue
+		}
+		quotes = append(quotes, services.SectorQuote{
+			Emiten:            md.Emiten,
+			Sector:            company.Sector,
+			Close:             md.ClosePrice,
+			PrevClose:         previousClose(candlesByEmiten[md.Emiten], md.Date),
+			SharesOutstanding: company.SharesOutstanding,
+		})
+	}
+
+	response.Date = to.In(services.WIB)
+	for _, s := range services.AggregateSectors(quotes) {
+		sector := dto.SectorResponse{
+			Sector:                 s.Sector,
+			EmitenCount:            s.EmitenCount,
+			Advancers:              s.Advancers,
+			Decliners:              s.Decliners,
+			Unchanged:              s.Unchanged,
+			MarketCap:              s.MarketCap,
+			ChangePct:              nullableFloat(s.CapWeightedChangePct),
+			EqualWeightedChangePct: nullableFloat(s.EqualWeightedChangePct),
+		}
+		if s.TopContributor != nil {
+			sector.TopContributor = &dto.SectorContributor{
+				Emiten:       s.TopContributor.Emiten,
+				ChangePct:    s.TopContributor.ChangePct,
+				Contribution: s.TopContributor.Contribution,
+			}
+		}
+		response.Data = append(response.Data, sector)
+	}
+
+	return response, nil
+}
+
+// GetBreadth computes daily advancers, decliners, unchanged, new 52-week
+// highs and lows and the cumulative advance/decline line for the sessions in
+// [from, to]. Missing bounds default to the last three months.
+func (uc *MarketUseCase) GetBreadth(from, to time.Time) (*dto.BreadthResponse, error) {
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if from.IsZero() {
+		from = to.Add(-defaultBreadthLookback)
+	}
+	if err := validateRange(from, to, maxDailyRange); err != nil {
+		return nil, err
+	}
+	from = services.BucketStart(from, entities.CandleInterval1d)
+
+	// Load a full high/low window plus the session before from
+	historyFrom := from.Add(-services.BreadthHighLowWindow).AddDate(0, 0, -sessionsToCalendarDays(1))
+	candlesByEmiten, err := loadDailyCandlesByEmiten(uc.marketDataRepo, historyFrom, to)
+	if err != nil {
+		return nil, err
+	}
+
+	series := services.ComputeBreadth(candlesByEmiten, from, to)
+	data := make([]dto.BreadthPoint, len(series))
+	for i, d := range series {
+		data[i] = dto.BreadthPoint{
+			Date:        d.Date.Format(dateOnly),
+			Advancers:   d.Advancers,
+			Decliners:   d.Decliners,
+			Unchanged:   d.Unchanged,
+			NewHighs:    d.NewHighs,
+			NewLows:     d.NewLows,
+			NetAdvances: d.NetAdvances,
+			ADLine:      d.ADLine,
+		}
+	}
+
+	return &dto.BreadthResponse{
+		From: from.In(services.WIB),
+		To:   to.In(services.WIB),
+		Data: data,
+	}, nil
+}
+
+// GetAutoRejections lists the emitens whose latest session reached its upper
+// (ARA) or lower (ARB) auto-rejection limit. Only emitens that traded in the
+// most recent session are considered.
+func (uc *MarketUseCase) GetAutoRejections() (*dto.AutoRejectionListResponse, error) {
+	latest, err := latestSnapshots(uc.marketDataRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.AutoRejectionListResponse{
+		ARA: []dto.AutoRejectionStock{},
+		ARB: []dto.AutoRejectionStock{},
+	}
+	if len(latest) == 0 {
+		return response, nil
+	}
+
+	session := services.BucketStart(latest[0].Date, entities.CandleInterval1d)
+	for _, md := range latest {
+		if day := services.BucketStart(md.Date, entities.CandleInterval1d); day.After(session) {
+			session = day
+		}
+	}
+	var today []entities.MarketData
+	for _, md := range latest {
+		if services.BucketStart(md.Date, entities.CandleInterval1d).Equal(session) {
+			today = append(today, md)
+		}
+	}
+
+	emitens, err := uc.emitenRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	boards := emitenBoards(emitens)
+	statuses, err := tradingStatusFlags(uc.statusRepo, today, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	limits, err := autoRejections(uc.marketDataRepo, uc.autoRejection, boards, statuses, today)
+	if err != nil {
+		return nil, err
+	}
+
+	response.Date = session.Format(dateOnly)
+	for i, md := range today {
+		l := limits[i]
+		if l == nil || !(l.ARA || l.ARB) {
+			continue
+		}
+		stock := dto.AutoRejectionStock{
+			Emiten:    md.Emiten,
+			Board:     sessionBoard(boards[md.Emiten], statuses[i]),
+			PrevClose: l.PrevClose,
+			Close:     md.ClosePrice,
+			High:      md.HighPrice,
+			Low:       md.LowPrice,
+			ChangePct: (md.ClosePrice/l.PrevClose - 1) * 100,
+		}
+		if l.ARA {
+			stock.Limit = l.UpperLimit
+			stock.ClosedAtLimit = md.ClosePrice >= l.UpperLimit
+			response.ARA = append(response.ARA, stock)
+		}
+		if l.ARB {
+			stock.Limit = l.LowerLimit
+			stock.ClosedAtLimit = md.ClosePrice <= l.LowerLimit
+			response.ARB = append(response.ARB, stock)
+		}
+	}
+
+	sort.SliceStable(response.ARA, func(i, j int) bool { return response.ARA[i].ChangePct > response.ARA[j].ChangePct })
+	sort.SliceStable(response.ARB, func(i, j int) bool { return response.ARB[i].ChangePct < response.ARB[j].ChangePct })
+
+	return response, nil
+}
+
+// RoundTick rounds a price to the IDX tick grid in the given mode, which
+// defaults to nearest
+func (uc *MarketUseCase) RoundTick(price float64, modeValue string) (*dto.TickRoundResponse, error) {
+	if !(price > 0) {
+		return nil, fmt.Errorf("%w: price must be positive", ErrInvalidTickRequest)
+	}
+	mode, err := services.ParseTickRounding(modeValue)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTickRequest, err)
+	}
+
+	rounded := services.RoundToTick(price, mode)
+	return &dto.TickRoundResponse{
+		Price:    price,
+		Mode:     string(mode),
+		Rounded:  rounded,
+		TickSize: services.TickSize(rounded),
+		Valid:    services.IsValidTick(price),
+	}, nil
+}
+
+// ListTicks lists the valid prices in [from, to], ascending
+func (uc *MarketUseCase) ListTicks(from, to float64) (*dto.TickListResponse, error) {
+	if !(from > 0) || !(to > 0) {
+		return nil, fmt.Errorf("%w: from and to must be positive", ErrInvalidTickRequest)
+	}
+	if from > to {
+		return nil, fmt.Errorf("%w: from must not be above to", ErrInvalidTickRequest)
+	}
+
+	ticks, complete := services.TicksBetween(from, to, maxTickList)
+	if !complete {
+		return nil, fmt.Errorf("%w: more than %d ticks between %g and %g", ErrInvalidTickRequest, maxTickList, from, to)
+	}
+
+	return &dto.TickListResponse{
+		From:  from,
+		To:    to,
+		Count: len(ticks),
+		Ticks: ticks,
+	}, nil
+}
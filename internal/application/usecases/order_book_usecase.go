@@ -0,0 +1,151 @@
+package usecases
+
+import (
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/repositories"
+	"api-web-scrapping/internal/domain/services"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+var (
+	ErrOrderBookNotFound = errors.New("order book not found")
+	ErrInvalidOrderBook  = errors.New("invalid order book")
+)
+
+type OrderBookUseCase struct {
+	orderBookRepo repositories.OrderBookRepository
+}
+
+func NewOrderBookUseCase(orderBookRepo repositories.OrderBookRepository) *OrderBookUseCase {
+	return &OrderBookUseCase{
+		orderBookRepo: orderBookRepo,
+	}
+}
+
+// Latest retrieves the last depth snapshot of an emiten captured at or
+// before asOf, by default now, with its spread, mid price and the imbalance
+// of the best depth levels per side, or every level when depth is not
+// positive
+func (uc *OrderBookUseCase) Latest(emiten string, asOf time.Time, depth int) (*dto.OrderBookResponse, error) {
+	emiten = entities.NormalizeTicker(emiten)
+	if asOf.IsZero() {
+		asOf = time.Now()
+	}
+
+	book, err := uc.orderBookRepo.FindLatestByEmiten(emiten, asOf)
+	if err != nil {
+		return nil, err
+	}
+	if book == nil {
+		return nil, fmt.Errorf("%w: %s", ErrOrderBookNotFound, emiten)
+	}
+
+	return orderBookToResponse(book, depth), nil
+}
+
+// Ingest records a batch of depth snapshots. A snapshot with the same emiten
+// and capture time as a stored one replaces its levels. Invalid snapshots
+// are reported by their 1-based position in the batch and skipped.
+func (uc *OrderBookUseCase) Ingest(reqs []dto.OrderBookRequest) (*dto.ImportResponse, error) {
+	result := newImportResult()
+	now := time.Now()
+	var imported []entities.OrderBookSnapshot
+
+	for i, req := range reqs {
+		book := &entities.OrderBookSnapshot{
+			Emiten:    req.Emiten,
+			Time:      req.Time,
+			Bids:      levelsFromRequest(req.Bids),
+			Offers:    levelsFromRequest(req.Offers),
+			CreatedAt: now,
+		}
+		if err := book.Validate(); err != nil {
+			result.fail(i+1, fmt.Errorf("%w: %v", ErrInvalidOrderBook, err))
+			continue
+		}
+		imported = append(imported, *book)
+	}
+
+	if err := uc.orderBookRepo.Save(imported); err != nil {
+		return nil, err
+	}
+	result.response.Imported = len(imported)
+
+	return &result.response, nil
+}
+
+// Prune removes the snapshots captured more than retention before now
+func (uc *OrderBookUseCase) Prune(now time.Time, retention time.Duration) (int64, error) {
+	return uc.orderBookRepo.DeleteBefore(now.Add(-retention))
+}
+
+// StartRetentionWorker prunes snapshots older than retention every period
+// until ctx is done
+func (uc *OrderBookUseCase) StartRetentionWorker(ctx context.Context, retention, period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		if removed, err := uc.Prune(time.Now(), retention); err != nil {
+			log.Printf("Failed to prune order book snapshots: %v", err)
+		} else if removed > 0 {
+			log.Printf("Pruned %d order book snapshots older than %s", removed, retention)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Helper functions
+
+func levelsFromRequest(levels []dto.OrderBookLevel) []entities.OrderBookLevel {
+	result := make([]entities.OrderBookLevel, len(levels))
+	for i, level := range levels {
+		result[i] = entities.OrderBookLevel{Price: level.Price, Lots: level.Lots, Orders: level.Orders}
+	}
+	return result
+}
+
+func levelsToResponse(levels []entities.OrderBookLevel) []dto.OrderBookLevel {
+	result := make([]dto.OrderBookLevel, len(levels))
+	for i, level := range levels {
+		result[i] = dto.OrderBookLevel{Price: level.Price, Lots: level.Lots, Orders: level.Orders}
+	}
+	return result
+}
+
+func orderBookToResponse(book *entities.OrderBookSnapshot, depth int) *dto.OrderBookResponse {
+	metrics := services.AnalyzeOrderBook(book, depth)
+	response := &dto.OrderBookResponse{
+		Emiten: book.Emiten,
+		Time:   book.Time,
+		Bids:   levelsToResponse(book.Bids),
+		Offers: levelsToResponse(book.Offers),
+		Metrics: dto.OrderBookMetricsResponse{
+			Spread:      metrics.Spread,
+			SpreadPct:   metrics.SpreadPct,
+			SpreadTicks: metrics.SpreadTicks,
+			MidPrice:    metrics.MidPrice,
+			Depth:       metrics.Depth,
+			BidLots:     metrics.BidLots,
+			OfferLots:   metrics.OfferLots,
+			Imbalance:   metrics.Imbalance,
+		},
+	}
+	if bid := book.BestBid(); bid != nil {
+		response.Metrics.BestBid = &bid.Price
+	}
+	if offer := book.BestOffer(); offer != nil {
+		response.Metrics.BestOffer = &offer.Price
+	}
+	return response
+}
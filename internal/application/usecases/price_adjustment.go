@@ -0,0 +1,82 @@
+package usecases
+
+import (
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/repositories"
+	"api-web-scrapping/internal/domain/services"
+	"math"
+	"time"
+)
+
+// loadPriceAdjustments returns the adjustments of the corporate actions of an
+// emiten with an ex-date after the session of from, i.e. every action that
+// changes prices from that session on. Rights issues and cash dividends are
+// priced against the close of the last session before their ex-date and are
+// skipped when it is unknown.
+func loadPriceAdjustments(actionRepo repositories.CorporateActionRepository, marketDataRepo repositories.MarketDataRepository, emiten string, from time.Time) (services.PriceAdjustments, error) {
+	actions, err := actionRepo.FindByEmiten(emiten)
+	if err != nil {
+		return nil, err
+	}
+
+	firstDay := services.CalendarDate(from)
+	var pending []entities.CorporateAction
+	var earliest, latest time.Time
+	for _, action := range actions {
+		if !firstDay.Before(action.ExDate) {
+			continue
+		}
+		pending = append(pending, action)
+		if services.NeedsCumClose(action) {
+			exDay := exDaySession(action)
+			if earliest.IsZero() || exDay.Before(earliest) {
+				earliest = exDay
+			}
+			if exDay.After(latest) {
+				latest = exDay
+			}
+		}
+	}
+
+	// Load the cum closes of every action at once
+	var candles []entities.Candle
+	if !earliest.IsZero() {
+		candles, err = loadDailyCandles(marketDataRepo, emiten, earliest.AddDate(0, 0, -sessionsToCalendarDays(1)), latest.Add(-time.Nanosecond))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var adjustments services.PriceAdjustments
+	for _, action := range pending {
+		cumClose := 0.0
+		if services.NeedsCumClose(action) {
+			cumClose = cumDateClose(candles, exDaySession(action))
+		}
+
+		if factor, ok := services.AdjustmentFactor(action, cumClose); ok {
+			adjustments = append(adjustments, services.PriceAdjustment{ExDate: action.ExDate, Factor: factor})
+		}
+	}
+	return adjustments, nil
+}
+
+// exDaySession returns the start of the ex-date session of an action in WIB
+func exDaySession(action entities.CorporateAction) time.Time {
+	return time.Date(action.ExDate.Year(), action.ExDate.Month(), action.ExDate.Day(), 0, 0, 0, 0, services.WIB)
+}
+
+// cumDateClose returns the close of the last session before exDay, or NaN
+// when the candles hold none within the usual lookback of a single session
+func cumDateClose(candles []entities.Candle, exDay time.Time) float64 {
+	windowStart := exDay.AddDate(0, 0, -sessionsToCalendarDays(1))
+	for i := len(candles) - 1; i >= 0; i-- {
+		if candles[i].Time.Before(exDay) {
+			if candles[i].Time.Before(windowStart) {
+				break
+			}
+			return candles[i].Close
+		}
+	}
+	return math.NaN()
+}
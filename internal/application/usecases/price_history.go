@@ -0,0 +1,228 @@
+package usecases
+
+import (
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/repositories"
+	"api-web-scrapping/internal/domain/services"
+	"fmt"
+	"math"
+	"time"
+)
+
+// dateOnly is the layout of calendar dates in requests and responses
+const dateOnly = "2006-01-02"
+
+// maxDailyRange bounds the range of a daily series computed across several
+// emitens, about three years like maxCorrelationLookback
+const maxDailyRange = 3 * 366 * 24 * time.Hour
+
+// validateRange rejects a range that is inverted or longer than limit
+func validateRange(from, to time.Time, limit time.Duration) error {
+	if from.After(to) {
+		return fmt.Errorf("%w: from must not be after to", ErrInvalidTimeRange)
+	}
+	if to.Sub(from) > limit {
+		return fmt.Errorf("%w: the range must not exceed %d days", ErrInvalidTimeRange, int(limit.Hours()/24))
+	}
+	return nil
+}
+
+// loadDailyCandles returns the daily candles of an emiten within [from, to],
+// oldest first. The sessions are rolled up by the repository, which leaves out
+// quarantined snapshots, and screened again by screenDailyHistory.
+func loadDailyCandles(repo repositories.MarketDataRepository, emiten string, from, to time.Time) ([]entities.Candle, error) {
+	history, err := repo.GetDailyHistoryByEmiten(emiten, services.BucketStart(from, entities.CandleInterval1d), to)
+	if err != nil {
+		return nil, err
+	}
+	if history, err = screenDailyHistory(repo, history); err != nil {
+		return nil, err
+	}
+	return services.AggregateCandles(history, entities.CandleInterval1d), nil
+}
+
+// loadDailyCandlesByEmiten returns the daily candles of every emiten within
+// [from, to], keyed by emiten
+func loadDailyCandlesByEmiten(repo repositories.MarketDataRepository, from, to time.Time) (map[string][]entities.Candle, error) {
+	history, err := repo.GetDailyHistory(services.BucketStart(from, entities.CandleInterval1d), to)
+	if err != nil {
+		return nil, err
+	}
+	if history, err = screenDailyHistory(repo, history); err != nil {
+		return nil, err
+	}
+
+	candlesByEmiten := make(map[string][]entities.Candle)
+	for emiten, snapshots := range groupByEmiten(history) {
+		candlesByEmiten[emiten] = services.AggregateCandles(snapshots, entities.CandleInterval1d)
+	}
+	return candlesByEmiten, nil
+}
+
+// screenDailyHistory rolls up again, from their snapshots on the tick grid,
+// the sessions whose daily snapshot is off the grid. The rollup worker only
+// quarantines snapshots every period, so the repository still rolls in those
+// scraped since its last run, typically within the current session.
+func screenDailyHistory(repo repositories.MarketDataRepository, history []entities.MarketData) ([]entities.MarketData, error) {
+	valid, quarantined := screenSnapshots(history)
+	if len(quarantined) == 0 {
+		return history, nil
+	}
+
+	// A daily snapshot is the last of its session, so the session's
+	// snapshots lie between the session start and its time
+	from, to := quarantined[0].Date, quarantined[0].Date
+	sessions := make(map[string]map[time.Time]bool)
+	for _, q := range quarantined {
+		if q.Date.Before(from) {
+			from = q.Date
+		}
+		if q.Date.After(to) {
+			to = q.Date
+		}
+		if sessions[q.Emiten] == nil {
+			sessions[q.Emiten] = make(map[time.Time]bool)
+		}
+		sessions[q.Emiten][services.BucketStart(q.Date, entities.CandleInterval1d)] = true
+	}
+	var raw []entities.MarketData
+	var err error
+	if len(sessions) == 1 {
+		raw, err = repo.GetHistoryByEmiten(quarantined[0].Emiten, services.BucketStart(from, entities.CandleInterval1d), to)
+	} else {
+		raw, err = repo.GetHistory(services.BucketStart(from, entities.CandleInterval1d), to)
+	}
+	if err != nil {
+		return nil, err
+	}
+	onGrid, _ := screenSnapshots(raw)
+
+	for _, md := range onGrid {
+		if sessions[md.Emiten][services.BucketStart(md.Date, entities.CandleInterval1d)] {
+			valid = append(valid, md)
+		}
+	}
+	return valid, nil
+}
+
+// latestSnapshots returns the latest snapshot of every emiten, skipping
+// snapshots with a price off the tick grid (see replaceOffTick)
+func latestSnapshots(repo repositories.MarketDataRepository) ([]entities.MarketData, error) {
+	latest, err := repo.GetLatestByAllEmiten()
+	if err != nil {
+		return nil, err
+	}
+	return replaceOffTick(repo, latest)
+}
+
+// latestSnapshot returns the latest snapshot of an emiten on the tick grid, or
+// nil when there is none
+func latestSnapshot(repo repositories.MarketDataRepository, emiten string) (*entities.MarketData, error) {
+	latest, err := repo.GetLatestByEmiten(emiten)
+	if err != nil || latest == nil {
+		return nil, err
+	}
+	return firstSnapshot(replaceOffTick(repo, []entities.MarketData{*latest}))
+}
+
+// replaceOffTick replaces the snapshots with a price off the tick grid by the
+// last valid snapshot of the same emiten earlier in that session, and drops
+// them when there is none. The order of the snapshots is kept.
+func replaceOffTick(repo repositories.MarketDataRepository, snapshots []entities.MarketData) ([]entities.MarketData, error) {
+	_, quarantined := screenSnapshots(snapshots)
+	if len(quarantined) == 0 {
+		return snapshots, nil
+	}
+
+	from, to := quarantined[0].Date, quarantined[0].Date
+	for _, q := range quarantined {
+		if q.Date.Before(from) {
+			from = q.Date
+		}
+		if q.Date.After(to) {
+			to = q.Date
+		}
+	}
+	var history []entities.MarketData
+	var err error
+	if len(quarantined) == 1 {
+		history, err = repo.GetHistoryByEmiten(quarantined[0].Emiten, services.BucketStart(from, entities.CandleInterval1d), to)
+	} else {
+		history, err = repo.GetHistory(services.BucketStart(from, entities.CandleInterval1d), to)
+	}
+	if err != nil {
+		return nil, err
+	}
+	valid, _ := screenSnapshots(history)
+	earlier := groupByEmiten(valid)
+
+	result := make([]entities.MarketData, 0, len(snapshots))
+	for _, md := range snapshots {
+		if len(services.ValidateTicks(md)) == 0 {
+			result = append(result, md)
+			continue
+		}
+
+		session := services.BucketStart(md.Date, entities.CandleInterval1d)
+		candidates := earlier[md.Emiten]
+		for i := len(candidates) - 1; i >= 0; i-- {
+			c := candidates[i]
+			if !c.Date.After(md.Date) && !c.Date.Before(session) {
+				result = append(result, c)
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+// firstSnapshot returns the first of snapshots, or nil when there is none
+func firstSnapshot(snapshots []entities.MarketData, err error) (*entities.MarketData, error) {
+	if err != nil || len(snapshots) == 0 {
+		return nil, err
+	}
+	return &snapshots[0], nil
+}
+
+// previousClose returns the close of the last session before the day of at,
+// or NaN when the candles hold no earlier session
+func previousClose(candles []entities.Candle, at time.Time) float64 {
+	today := services.BucketStart(at, entities.CandleInterval1d)
+	for i := len(candles) - 1; i >= 0; i-- {
+		if candles[i].Time.Before(today) {
+			return candles[i].Close
+		}
+	}
+	return math.NaN()
+}
+
+// sessionsToCalendarDays converts a number of trading sessions into a
+// calendar lookback generous enough to cover weekends and exchange holidays
+func sessionsToCalendarDays(sessions int) int {
+	return sessions*7/5 + 14
+}
+
+// candleSeries splits daily candles into parallel high, low and close series
+func candleSeries(candles []entities.Candle) (highs, lows, closes []float64) {
+	highs = make([]float64, len(candles))
+	lows = make([]float64, len(candles))
+	closes = make([]float64, len(candles))
+	for i, c := range candles {
+		highs[i] = c.High
+		lows[i] = c.Low
+		closes[i] = c.Close
+	}
+	return highs, lows, closes
+}
+
+// candleVolumes returns the volumes of daily candles, reporting false when
+// none of them carries a volume
+func candleVolumes(candles []entities.Candle) ([]float64, bool) {
+	volumes := make([]float64, len(candles))
+	traded := false
+	for i, c := range candles {
+		volumes[i] = c.Volume
+		traded = traded || c.Volume > 0
+	}
+	return volumes, traded
+}
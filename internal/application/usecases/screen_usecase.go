@@ -0,0 +1,481 @@
+package usecases
+
+import (
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/repositories"
+	"api-web-scrapping/internal/domain/services"
+	"api-web-scrapping/pkg/cron"
+	"api-web-scrapping/pkg/screener"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrScreenNotFound = errors.New("screen not found")
+	ErrInvalidScreen  = errors.New("invalid screen")
+)
+
+const (
+	// ScreenTimezone is the zone cron schedules of saved screens are evaluated in
+	ScreenTimezone   = "Asia/Jakarta"
+	defaultRunsLimit = 20
+	maxRunsLimit     = 200
+)
+
+type ScreenUseCase struct {
+	screenRepo repositories.ScreenRepository
+	screener   *ScreenerUseCase
+}
+
+func NewScreenUseCase(screenRepo repositories.ScreenRepository, screener *ScreenerUseCase) *ScreenUseCase {
+	return &ScreenUseCase{
+		screenRepo: screenRepo,
+		screener:   screener,
+	}
+}
+
+// Create saves a new screen owned by userID
+func (uc *ScreenUseCase) Create(userID string, req dto.ScreenRequest) (*dto.ScreenResponse, error) {
+	now := time.Now()
+	screen := &entities.Screen{
+		UserID:    userID,
+		Enabled:   true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := applyScreenRequest(screen, req, now); err != nil {
+		return nil, err
+	}
+
+	if err := uc.screenRepo.Create(screen); err != nil {
+		return nil, err
+	}
+	return screenToResponse(screen), nil
+}
+
+// List returns the screens owned by userID
+func (uc *ScreenUseCase) List(userID string) (*dto.ScreenListResponse, error) {
+	screens, err := uc.screenRepo.FindByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.ScreenResponse, len(screens))
+	for i := range screens {
+		responses[i] = *screenToResponse(&screens[i])
+	}
+	return &dto.ScreenListResponse{Data: responses}, nil
+}
+
+// Get returns a screen owned by userID
+func (uc *ScreenUseCase) Get(userID string, id int64) (*dto.ScreenResponse, error) {
+	screen, err := uc.findOwned(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return screenToResponse(screen), nil
+}
+
+// Update replaces the definition and schedule of a screen owned by userID
+func (uc *ScreenUseCase) Update(userID string, id int64, req dto.ScreenRequest) (*dto.ScreenResponse, error) {
+	screen, err := uc.findOwned(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := applyScreenRequest(screen, req, now); err != nil {
+		return nil, err
+	}
+	screen.UpdatedAt = now
+
+	if err := uc.screenRepo.Update(screen); err != nil {
+		return nil, err
+	}
+	return screenToResponse(screen), nil
+}
+
+// Delete removes a screen owned by userID together with its runs
+func (uc *ScreenUseCase) Delete(userID string, id int64) error {
+	if _, err := uc.findOwned(userID, id); err != nil {
+		return err
+	}
+	return uc.screenRepo.Delete(id)
+}
+
+// RunNow evaluates a screen owned by userID immediately and stores the run
+func (uc *ScreenUseCase) RunNow(userID string, id int64) (*dto.ScreenRunResponse, error) {
+	screen, err := uc.findOwned(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	run, err := uc.run(screen, entities.ScreenTriggerManual, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return screenRunToResponse(run), nil
+}
+
+// ListRuns returns the most recent runs of a screen owned by userID
+func (uc *ScreenUseCase) ListRuns(userID string, id int64, limit int) (*dto.ScreenRunListResponse, error) {
+	if _, err := uc.findOwned(userID, id); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = defaultRunsLimit
+	}
+	if limit > maxRunsLimit {
+		limit = maxRunsLimit
+	}
+
+	runs, err := uc.screenRepo.FindRuns(id, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.ScreenRunResponse, len(runs))
+	for i := range runs {
+		responses[i] = *screenRunToResponse(&runs[i])
+	}
+	return &dto.ScreenRunListResponse{Data: responses}, nil
+}
+
+// GetRun returns a single run of a screen owned by userID
+func (uc *ScreenUseCase) GetRun(userID string, id, runID int64) (*dto.ScreenRunResponse, error) {
+	if _, err := uc.findOwned(userID, id); err != nil {
+		return nil, err
+	}
+
+	run, err := uc.screenRepo.FindRun(id, runID)
+	if err != nil {
+		return nil, err
+	}
+	if run == nil {
+		return nil, fmt.Errorf("%w: run %d of screen %d", ErrScreenNotFound, runID, id)
+	}
+	return screenRunToResponse(run), nil
+}
+
+// Diff compares a run with the run before it and reports which emitens
+// entered or left the result set. A zero runID diffs the latest run.
+func (uc *ScreenUseCase) Diff(userID string, id, runID int64) (*dto.ScreenRunDiffResponse, error) {
+	if _, err := uc.findOwned(userID, id); err != nil {
+		return nil, err
+	}
+
+	var run *entities.ScreenRun
+	if runID == 0 {
+		runs, err := uc.screenRepo.FindRuns(id, 1)
+		if err != nil {
+			return nil, err
+		}
+		if len(runs) > 0 {
+			run = &runs[0]
+		}
+	} else {
+		var err error
+		if run, err = uc.screenRepo.FindRun(id, runID); err != nil {
+			return nil, err
+		}
+	}
+	if run == nil {
+		return nil, fmt.Errorf("%w: screen %d has no matching run", ErrScreenNotFound, id)
+	}
+
+	previous, err := uc.screenRepo.FindPreviousRun(id, run.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.ScreenRunDiffResponse{
+		ScreenID: id,
+		Run:      dto.ScreenRunRef{ID: run.ID, RunAt: run.RunAt},
+	}
+	var previousEmitens []string
+	if previous != nil {
+		response.PreviousRun = &dto.ScreenRunRef{ID: previous.ID, RunAt: previous.RunAt}
+		previousEmitens = previous.Emitens()
+	}
+	response.Entered, response.Left, response.Unchanged = diffEmitens(previousEmitens, run.Emitens())
+
+	return response, nil
+}
+
+// RunDue evaluates every enabled screen whose next run is due and schedules
+// the following run. A failing screen is logged and does not block the rest.
+func (uc *ScreenUseCase) RunDue(now time.Time) error {
+	screens, err := uc.screenRepo.FindDue(now)
+	if err != nil {
+		return err
+	}
+
+	for i := range screens {
+		screen := &screens[i]
+		if _, err := uc.run(screen, entities.ScreenTriggerSchedule, now); err != nil {
+			log.Printf("Failed to run screen %d: %v", screen.ID, err)
+			// Still advance the schedule so a broken screen is not retried every tick
+			if err := uc.screenRepo.UpdateRunTimes(screen.ID, now, nextScreenRun(screen.Schedule, now)); err != nil {
+				log.Printf("Failed to reschedule screen %d: %v", screen.ID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// StartScheduler runs due screens every period until ctx is done
+func (uc *ScreenUseCase) StartScheduler(ctx context.Context, period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := uc.RunDue(now); err != nil {
+				log.Printf("Failed to run due screens: %v", err)
+			}
+		}
+	}
+}
+
+func (uc *ScreenUseCase) findOwned(userID string, id int64) (*entities.Screen, error) {
+	screen, err := uc.screenRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if screen == nil || screen.UserID != userID {
+		return nil, fmt.Errorf("%w: %d", ErrScreenNotFound, id)
+	}
+	return screen, nil
+}
+
+// run evaluates a screen against the latest market data, stores the run and
+// advances the screen's schedule
+func (uc *ScreenUseCase) run(screen *entities.Screen, trigger string, now time.Time) (*entities.ScreenRun, error) {
+	program, err := screener.Compile(screenExpression(screen.Filter), screenerSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	// change_pct is stored with every result, so always load the previous session
+	matches, err := uc.screener.evaluate(program, now, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := make(map[string]bool, len(screen.Filter.Emitens))
+	for _, emiten := range screen.Filter.Emitens {
+		allowed[emiten] = true
+	}
+
+	run := &entities.ScreenRun{
+		ScreenID: screen.ID,
+		Trigger:  trigger,
+		RunAt:    now,
+		Results:  []entities.ScreenResult{},
+	}
+	for _, m := range matches {
+		if len(allowed) > 0 && !allowed[m.latest.Emiten] {
+			continue
+		}
+		result := entities.ScreenResult{
+			Emiten:     m.latest.Emiten,
+			ClosePrice: m.latest.ClosePrice,
+		}
+		if change := m.env.Field("change_pct"); !math.IsNaN(change) {
+			result.ChangePct = &change
+		}
+		run.Results = append(run.Results, result)
+	}
+
+	if err := uc.screenRepo.CreateRun(run); err != nil {
+		return nil, err
+	}
+
+	var nextRunAt *time.Time
+	if screen.Enabled {
+		nextRunAt = nextScreenRun(screen.Schedule, now)
+	}
+	if err := uc.screenRepo.UpdateRunTimes(screen.ID, now, nextRunAt); err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+// applyScreenRequest validates req and copies it onto screen, recomputing the
+// next scheduled run
+func applyScreenRequest(screen *entities.Screen, req dto.ScreenRequest, now time.Time) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidScreen)
+	}
+
+	filter := entities.ScreenFilter{
+		MinPrice:     req.Filter.MinPrice,
+		MaxPrice:     req.Filter.MaxPrice,
+		MinChangePct: req.Filter.MinChangePct,
+		MaxChangePct: req.Filter.MaxChangePct,
+		Expression:   strings.TrimSpace(req.Filter.Expression),
+	}
+	for _, emiten := range req.Filter.Emitens {
+		if emiten = strings.ToUpper(strings.TrimSpace(emiten)); emiten != "" {
+			filter.Emitens = append(filter.Emitens, emiten)
+		}
+	}
+	if filter.Expression != "" {
+		// Validate on its own so error positions refer to what the user wrote
+		if _, err := screener.Compile(filter.Expression, screenerSchema); err != nil {
+			return err
+		}
+	}
+	// Runs evaluate the bounds and the expression combined, so validate that
+	if _, err := screener.Compile(screenExpression(filter), screenerSchema); err != nil {
+		return fmt.Errorf("%w: filter: %v", ErrInvalidScreen, err)
+	}
+
+	schedule := strings.TrimSpace(req.Schedule)
+	if schedule != "" {
+		if _, err := cron.Parse(schedule, services.WIB); err != nil {
+			return fmt.Errorf("%w: schedule: %v", ErrInvalidScreen, err)
+		}
+	}
+
+	screen.Name = name
+	screen.Filter = filter
+	screen.Schedule = schedule
+	if req.Enabled != nil {
+		screen.Enabled = *req.Enabled
+	}
+	screen.NextRunAt = nil
+	if screen.Enabled {
+		screen.NextRunAt = nextScreenRun(schedule, now)
+	}
+	return nil
+}
+
+// screenExpression turns a saved filter into a screener expression
+func screenExpression(filter entities.ScreenFilter) string {
+	var conditions []string
+	if filter.MinPrice != nil {
+		conditions = append(conditions, "close >= "+formatScreenNumber(*filter.MinPrice))
+	}
+	if filter.MaxPrice != nil {
+		conditions = append(conditions, "close <= "+formatScreenNumber(*filter.MaxPrice))
+	}
+	if filter.MinChangePct != nil {
+		conditions = append(conditions, "change_pct >= "+formatScreenNumber(*filter.MinChangePct))
+	}
+	if filter.MaxChangePct != nil {
+		conditions = append(conditions, "change_pct <= "+formatScreenNumber(*filter.MaxChangePct))
+	}
+	if filter.Expression != "" {
+		conditions = append(conditions, "("+filter.Expression+")")
+	}
+	if len(conditions) == 0 {
+		return "close > 0"
+	}
+	return strings.Join(conditions, " and ")
+}
+
+// formatScreenNumber writes a bound as a screener literal, which has no
+// exponent form
+func formatScreenNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// nextScreenRun returns the next activation of a cron schedule after now, or
+// nil for manual-only screens
+func nextScreenRun(schedule string, now time.Time) *time.Time {
+	if schedule == "" {
+		return nil
+	}
+	s, err := cron.Parse(schedule, services.WIB)
+	if err != nil {
+		return nil
+	}
+	next := s.Next(now)
+	if next.IsZero() {
+		return nil
+	}
+	return &next
+}
+
+// diffEmitens compares two result sets, each returned sorted
+func diffEmitens(previous, current []string) (entered, left, unchanged []string) {
+	before := make(map[string]bool, len(previous))
+	for _, emiten := range previous {
+		before[emiten] = true
+	}
+
+	entered, left, unchanged = []string{}, []string{}, []string{}
+	for _, emiten := range current {
+		if before[emiten] {
+			unchanged = append(unchanged, emiten)
+			delete(before, emiten)
+		} else {
+			entered = append(entered, emiten)
+		}
+	}
+	for emiten := range before {
+		left = append(left, emiten)
+	}
+
+	sort.Strings(entered)
+	sort.Strings(left)
+	sort.Strings(unchanged)
+	return entered, left, unchanged
+}
+
+func screenToResponse(screen *entities.Screen) *dto.ScreenResponse {
+	return &dto.ScreenResponse{
+		ID:   screen.ID,
+		Name: screen.Name,
+		Filter: dto.ScreenFilter{
+			MinPrice:     screen.Filter.MinPrice,
+			MaxPrice:     screen.Filter.MaxPrice,
+			MinChangePct: screen.Filter.MinChangePct,
+			MaxChangePct: screen.Filter.MaxChangePct,
+			Emitens:      screen.Filter.Emitens,
+			Expression:   screen.Filter.Expression,
+		},
+		Schedule:  screen.Schedule,
+		Timezone:  ScreenTimezone,
+		Enabled:   screen.Enabled,
+		NextRunAt: screen.NextRunAt,
+		LastRunAt: screen.LastRunAt,
+		CreatedAt: screen.CreatedAt,
+		UpdatedAt: screen.UpdatedAt,
+	}
+}
+
+func screenRunToResponse(run *entities.ScreenRun) *dto.ScreenRunResponse {
+	results := make([]dto.ScreenResultResponse, len(run.Results))
+	for i, r := range run.Results {
+		results[i] = dto.ScreenResultResponse{
+			Emiten:     r.Emiten,
+			ClosePrice: r.ClosePrice,
+			ChangePct:  r.ChangePct,
+		}
+	}
+	return &dto.ScreenRunResponse{
+		ID:       run.ID,
+		ScreenID: run.ScreenID,
+		Trigger:  run.Trigger,
+		RunAt:    run.RunAt,
+		Count:    len(run.Results),
+		Results:  results,
+	}
+}
@@ -0,0 +1,259 @@
+package usecases
+
+import (
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/repositories"
+	"api-web-scrapping/pkg/screener"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	defaultScreenerLimit = 100
+	maxScreenerLimit     = 1000
+	maxScreenerPeriod    = 250
+	// maxScreenerSettling bounds the sessions loaded beyond the period of an
+	// exponentially smoothed indicator, whose weight has decayed well before
+	maxScreenerSettling = 100
+)
+
+// screenerSchema declares what a screener expression may reference. Fields
+// come from the latest snapshot plus the previous session's close; functions
+// are indicators computed over daily closes.
+var screenerSchema = &screener.Schema{
+	Fields: []string{"open", "high", "low", "close", "prev_close", "change", "change_pct",
+		"volume", "lots", "value", "frequency", "vwap"},
+	Functions: []screener.Function{
+		periodFunction("sma", 1),
+		periodFunction("wma", 1),
+		// Exponentially smoothed indicators depend on their whole history,
+		// so load a few periods more, up to maxScreenerSettling sessions, for
+		// the value to settle
+		periodFunction("ema", 3),
+		periodFunction("rsi", 3),
+		periodFunction("atr", 3),
+	},
+}
+
+func periodFunction(name string, lookbackFactor int) screener.Function {
+	return screener.Function{
+		Name:  name,
+		Arity: 1,
+		Validate: func(args []float64) error {
+			if args[0] != math.Trunc(args[0]) || args[0] < 1 || args[0] > maxScreenerPeriod {
+				return fmt.Errorf("period must be a whole number between 1 and %d", maxScreenerPeriod)
+			}
+			return nil
+		},
+		Lookback: func(args []float64) int {
+			period := int(args[0])
+			settling := period * (lookbackFactor - 1)
+			if settling > maxScreenerSettling {
+				settling = maxScreenerSettling
+			}
+			return period + settling + 1
+		},
+	}
+}
+
+type ScreenerUseCase struct {
+	marketDataRepo repositories.MarketDataRepository
+}
+
+func NewScreenerUseCase(marketDataRepo repositories.MarketDataRepository) *ScreenerUseCase {
+	return &ScreenerUseCase{
+		marketDataRepo: marketDataRepo,
+	}
+}
+
+// Run evaluates a screener expression against the latest market data of
+// every emiten. A condition on an unavailable metric, e.g. sma(200) for a
+// listing too recent, is unknown: it never matches on its own or under not,
+// but an or with a true side still matches and reports the metric as null.
+func (uc *ScreenerUseCase) Run(req dto.ScreenerRequest) (*dto.ScreenerResponse, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultScreenerLimit
+	}
+	if limit > maxScreenerLimit {
+		limit = maxScreenerLimit
+	}
+
+	program, err := screener.Compile(req.Expression, screenerSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := uc.evaluate(program, time.Now(), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	total := len(matches)
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	data := make([]dto.ScreenerMatch, len(matches))
+	for i, m := range matches {
+		fields := make(map[string]*float64, len(m.values))
+		for key, v := range m.values {
+			fields[key] = nullableFloat(v)
+		}
+		data[i] = dto.ScreenerMatch{
+			Emiten: m.latest.Emiten,
+			Date:   m.latest.Date,
+			Fields: fields,
+		}
+	}
+
+	return &dto.ScreenerResponse{
+		Expression: req.Expression,
+		Fields:     program.Refs(),
+		Count:      total,
+		Data:       data,
+	}, nil
+}
+
+// screenerMatch is an emiten that satisfied a program, with the environment
+// it was evaluated in so callers can read further metrics
+type screenerMatch struct {
+	latest entities.MarketData
+	env    *screenerEnv
+	values map[string]float64
+}
+
+// evaluate returns the matches of a compiled program in the order of the
+// latest market data. minLookback forces history to be loaded for metrics the
+// caller reads from the environment beyond those in the expression.
+func (uc *ScreenerUseCase) evaluate(program *screener.Program, now time.Time, minLookback int) ([]screenerMatch, error) {
+	latest, err := latestSnapshots(uc.marketDataRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	lookback := int(math.Max(float64(program.Lookback()), float64(minLookback)))
+	for _, ref := range program.Refs() {
+		if ref == "prev_close" || ref == "change" || ref == "change_pct" {
+			lookback = int(math.Max(float64(lookback), 2))
+		}
+	}
+
+	var candlesByEmiten map[string][]entities.Candle
+	if lookback > 0 {
+		candlesByEmiten, err = loadDailyCandlesByEmiten(uc.marketDataRepo, now.AddDate(0, 0, -sessionsToCalendarDays(lookback)), now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var matches []screenerMatch
+	for _, md := range latest {
+		env := newScreenerEnv(md, candlesByEmiten[md.Emiten])
+		values := program.Values(env)
+		if !program.Match(env) {
+			continue
+		}
+		matches = append(matches, screenerMatch{latest: md, env: env, values: values})
+	}
+
+	return matches, nil
+}
+
+// IsExpressionError reports whether err was caused by an invalid expression
+// and returns its position
+func IsExpressionError(err error) (*screener.ParseError, bool) {
+	var parseErr *screener.ParseError
+	if errors.As(err, &parseErr) {
+		return parseErr, true
+	}
+	return nil, false
+}
+
+// screenerEnv resolves screener fields and calls for a single emiten,
+// memoizing indicator results since a call may appear in both the filter and
+// the returned field list
+type screenerEnv struct {
+	latest  entities.MarketData
+	candles []entities.Candle
+	cache   map[string]float64
+}
+
+func newScreenerEnv(latest entities.MarketData, candles []entities.Candle) *screenerEnv {
+	return &screenerEnv{latest: latest, candles: candles, cache: make(map[string]float64)}
+}
+
+func (e *screenerEnv) Field(name string) float64 {
+	switch name {
+	case "open":
+		return e.latest.OpenPrice
+	case "high":
+		return e.latest.HighPrice
+	case "low":
+		return e.latest.LowPrice
+	case "close":
+		return e.latest.ClosePrice
+	case "prev_close":
+		return e.previousClose()
+	case "change":
+		return e.latest.ClosePrice - e.previousClose()
+	case "change_pct":
+		prev := e.previousClose()
+		if prev == 0 {
+			return math.NaN()
+		}
+		return (e.latest.ClosePrice - prev) / prev * 100
+	case "volume":
+		return optionalInt(e.latest.Volume)
+	case "lots":
+		return optionalInt(e.latest.Lots())
+	case "value":
+		return optionalFloat(e.latest.Value)
+	case "frequency":
+		return optionalInt(e.latest.Frequency)
+	case "vwap":
+		return optionalFloat(e.latest.VWAP())
+	}
+	return math.NaN()
+}
+
+// optionalInt returns a snapshot total, or NaN when it was not captured
+func optionalInt(v *int64) float64 {
+	if v == nil {
+		return math.NaN()
+	}
+	return float64(*v)
+}
+
+// optionalFloat returns a snapshot total, or NaN when it was not captured
+func optionalFloat(v *float64) float64 {
+	if v == nil {
+		return math.NaN()
+	}
+	return *v
+}
+
+func (e *screenerEnv) Call(name string, args []float64) float64 {
+	key := fmt.Sprintf("%s(%g)", name, args[0])
+	if v, ok := e.cache[key]; ok {
+		return v
+	}
+
+	v := math.NaN()
+	if len(e.candles) > 0 {
+		series, err := computeIndicator(name, indicatorParams{Period: int(args[0])}, e.candles)
+		if err == nil {
+			v = series[name][len(e.candles)-1]
+		}
+	}
+	e.cache[key] = v
+	return v
+}
+
+// previousClose returns the close of the last session before the latest snapshot
+func (e *screenerEnv) previousClose() float64 {
+	return previousClose(e.candles, e.latest.Date)
+}
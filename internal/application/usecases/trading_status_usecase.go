@@ -0,0 +1,359 @@
+package usecases
+
+import (
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/repositories"
+	"api-web-scrapping/internal/domain/services"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrTradingStatusNotFound = errors.New("trading status not found")
+	ErrTradingStatusOverlap  = errors.New("trading status overlaps an existing one")
+	ErrInvalidTradingStatus  = errors.New("invalid trading status")
+)
+
+type TradingStatusUseCase struct {
+	statusRepo repositories.TradingStatusRepository
+}
+
+func NewTradingStatusUseCase(statusRepo repositories.TradingStatusRepository) *TradingStatusUseCase {
+	return &TradingStatusUseCase{
+		statusRepo: statusRepo,
+	}
+}
+
+// Current retrieves the trading status of an emiten on a calendar date, by
+// default today
+func (uc *TradingStatusUseCase) Current(emiten string, asOf time.Time) (*dto.CurrentTradingStatusResponse, error) {
+	emiten = entities.NormalizeTicker(emiten)
+	if asOf.IsZero() {
+		asOf = time.Now()
+	}
+	asOf = services.CalendarDate(asOf)
+
+	statuses, err := uc.statusRepo.FindByEmiten(emiten)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.CurrentTradingStatusResponse{
+		Emiten: emiten,
+		AsOf:   asOf.Format(dateOnly),
+	}
+	status := statusOn(statuses, asOf)
+	response.Flags = *statusToFlags(status)
+	if status != nil {
+		response.Record = tradingStatusToResponse(status)
+	}
+	return response, nil
+}
+
+// History retrieves the status records of an emiten by effective-from date
+func (uc *TradingStatusUseCase) History(emiten string) (*dto.TradingStatusListResponse, error) {
+	emiten = entities.NormalizeTicker(emiten)
+	statuses, err := uc.statusRepo.FindByEmiten(emiten)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := tradingStatusesToResponses(statuses)
+	return &dto.TradingStatusListResponse{
+		Emiten: emiten,
+		Count:  len(responses),
+		Data:   responses,
+	}, nil
+}
+
+// List retrieves the status records covering a calendar date, by default
+// today, optionally only those with a status such as suspended
+func (uc *TradingStatusUseCase) List(status string, asOf time.Time) (*dto.TradingStatusListResponse, error) {
+	if asOf.IsZero() {
+		asOf = time.Now()
+	}
+	asOf = services.CalendarDate(asOf)
+	status = strings.ToLower(strings.TrimSpace(status))
+
+	statuses, err := uc.statusRepo.FindByRange(asOf, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []dto.TradingStatusResponse{}
+	for i := range statuses {
+		if status == "" || statuses[i].Status == status {
+			responses = append(responses, *tradingStatusToResponse(&statuses[i]))
+		}
+	}
+	return &dto.TradingStatusListResponse{
+		AsOf:  asOf.Format(dateOnly),
+		Count: len(responses),
+		Data:  responses,
+	}, nil
+}
+
+// Create records a status that must not overlap another status of the same
+// emiten
+func (uc *TradingStatusUseCase) Create(req dto.TradingStatusRequest) (*dto.TradingStatusResponse, error) {
+	status, err := requestToTradingStatus(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.checkOverlap(status); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	status.CreatedAt, status.UpdatedAt = now, now
+	if err := uc.statusRepo.Create(status); err != nil {
+		return nil, err
+	}
+	return tradingStatusToResponse(status), nil
+}
+
+// Update replaces the status, notations, watchlist flag, reason and date
+// range of a status record
+func (uc *TradingStatusUseCase) Update(id int64, req dto.TradingStatusRequest) (*dto.TradingStatusResponse, error) {
+	existing, err := uc.find(id)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := requestToTradingStatus(req)
+	if err != nil {
+		return nil, err
+	}
+	if status.Emiten != existing.Emiten {
+		return nil, fmt.Errorf("%w: emiten cannot be changed", ErrInvalidTradingStatus)
+	}
+	status.ID = existing.ID
+	if err := uc.checkOverlap(status); err != nil {
+		return nil, err
+	}
+
+	status.CreatedAt, status.UpdatedAt = existing.CreatedAt, time.Now()
+	if err := uc.statusRepo.Update(status); err != nil {
+		return nil, err
+	}
+	return tradingStatusToResponse(status), nil
+}
+
+// Delete removes a status record
+func (uc *TradingStatusUseCase) Delete(id int64) error {
+	if _, err := uc.find(id); err != nil {
+		return err
+	}
+	return uc.statusRepo.Delete(id)
+}
+
+// Import records statuses from a CSV document with the columns emiten and
+// from and the optional status, notations, watchlist, reason and to.
+// Notations are letters such as "E X". A row with the same emiten and from
+// date as an existing record replaces it; rows that overlap another record
+// are reported and skipped.
+func (uc *TradingStatusUseCase) Import(reader io.Reader) (*dto.ImportResponse, error) {
+	rows, err := readCSV(reader, "emiten", "from")
+	if err != nil {
+		return nil, err
+	}
+
+	result := newImportResult()
+	now := time.Now()
+	existingByEmiten := make(map[string][]entities.TradingStatus)
+	var imported []entities.TradingStatus
+
+	for _, row := range rows {
+		req := dto.TradingStatusRequest{
+			Emiten:    row.get("emiten"),
+			Status:    row.get("status"),
+			Notations: splitNotations(row.get("notations")),
+			Reason:    row.get("reason"),
+			From:      row.get("from"),
+			To:        row.get("to"),
+		}
+		if value := row.get("watchlist"); value != "" {
+			watchlist, err := strconv.ParseBool(value)
+			if err != nil {
+				result.fail(row.line, fmt.Errorf("invalid watchlist %q", value))
+				continue
+			}
+			req.Watchlist = watchlist
+		}
+
+		status, err := requestToTradingStatus(req)
+		if err != nil {
+			result.fail(row.line, err)
+			continue
+		}
+
+		existing, ok := existingByEmiten[status.Emiten]
+		if !ok {
+			if existing, err = uc.statusRepo.FindByEmiten(status.Emiten); err != nil {
+				return nil, err
+			}
+			existingByEmiten[status.Emiten] = existing
+		}
+
+		if conflict := findStatusOverlap(status, existing, imported); conflict != nil {
+			result.fail(row.line, fmt.Errorf("%w: %s from %s", ErrTradingStatusOverlap,
+				conflict.Emiten, conflict.EffectiveFrom.Format(dateOnly)))
+			continue
+		}
+
+		status.CreatedAt, status.UpdatedAt = now, now
+		imported = append(imported, *status)
+	}
+
+	if err := uc.statusRepo.Upsert(imported); err != nil {
+		return nil, err
+	}
+	result.response.Imported = len(imported)
+
+	return &result.response, nil
+}
+
+func (uc *TradingStatusUseCase) checkOverlap(status *entities.TradingStatus) error {
+	existing, err := uc.statusRepo.FindByEmiten(status.Emiten)
+	if err != nil {
+		return err
+	}
+	for i := range existing {
+		if existing[i].ID != status.ID && status.Overlaps(&existing[i]) {
+			return fmt.Errorf("%w: %s from %s", ErrTradingStatusOverlap,
+				existing[i].Emiten, existing[i].EffectiveFrom.Format(dateOnly))
+		}
+	}
+	return nil
+}
+
+func (uc *TradingStatusUseCase) find(id int64) (*entities.TradingStatus, error) {
+	status, err := uc.statusRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if status == nil {
+		return nil, fmt.Errorf("%w: %d", ErrTradingStatusNotFound, id)
+	}
+	return status, nil
+}
+
+// Helper functions
+
+// findStatusOverlap returns a status that overlaps s, ignoring a stored one
+// with the same effective-from date since importing s replaces it
+func findStatusOverlap(s *entities.TradingStatus, stored, pending []entities.TradingStatus) *entities.TradingStatus {
+	for i := range stored {
+		if stored[i].EffectiveFrom.Equal(s.EffectiveFrom) {
+			continue
+		}
+		if s.Overlaps(&stored[i]) {
+			return &stored[i]
+		}
+	}
+	for i := range pending {
+		if s.Overlaps(&pending[i]) {
+			return &pending[i]
+		}
+	}
+	return nil
+}
+
+// splitNotations reads special notation letters separated by spaces, commas,
+// semicolons or pipes, or written together as in "EX"
+func splitNotations(value string) []string {
+	var notations []string
+	for _, r := range value {
+		switch r {
+		case ' ', ',', ';', '|':
+			continue
+		}
+		notations = append(notations, string(r))
+	}
+	return notations
+}
+
+// statusOn returns the status of an emiten's records covering a calendar
+// date, or nil
+func statusOn(statuses []entities.TradingStatus, date time.Time) *entities.TradingStatus {
+	for i := range statuses {
+		if statuses[i].ActiveOn(date) {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
+
+// statusToFlags summarizes a status for market data; nil means active
+func statusToFlags(s *entities.TradingStatus) *dto.TradingStatusFlags {
+	if s == nil {
+		return &dto.TradingStatusFlags{Status: entities.EmitenStatusActive, Notations: []string{}}
+	}
+	return &dto.TradingStatusFlags{
+		Status:    s.Status,
+		Suspended: s.IsSuspended(),
+		Notations: s.Notations,
+		Watchlist: s.Watchlist,
+	}
+}
+
+func requestToTradingStatus(req dto.TradingStatusRequest) (*entities.TradingStatus, error) {
+	status := &entities.TradingStatus{
+		Emiten:    req.Emiten,
+		Status:    req.Status,
+		Notations: req.Notations,
+		Watchlist: req.Watchlist,
+		Reason:    req.Reason,
+	}
+
+	from, err := time.Parse(dateOnly, req.From)
+	if err != nil {
+		return nil, fmt.Errorf("%w: from must be YYYY-MM-DD", ErrInvalidTradingStatus)
+	}
+	status.EffectiveFrom = from
+
+	if req.To != "" {
+		to, err := time.Parse(dateOnly, req.To)
+		if err != nil {
+			return nil, fmt.Errorf("%w: to must be YYYY-MM-DD", ErrInvalidTradingStatus)
+		}
+		status.EffectiveTo = &to
+	}
+
+	if err := status.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTradingStatus, err)
+	}
+	return status, nil
+}
+
+func tradingStatusToResponse(s *entities.TradingStatus) *dto.TradingStatusResponse {
+	response := &dto.TradingStatusResponse{
+		ID:        s.ID,
+		Emiten:    s.Emiten,
+		Status:    s.Status,
+		Notations: s.Notations,
+		Watchlist: s.Watchlist,
+		Reason:    s.Reason,
+		From:      s.EffectiveFrom.Format(dateOnly),
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+	if s.EffectiveTo != nil {
+		to := s.EffectiveTo.Format(dateOnly)
+		response.To = &to
+	}
+	return response
+}
+
+func tradingStatusesToResponses(statuses []entities.TradingStatus) []dto.TradingStatusResponse {
+	responses := make([]dto.TradingStatusResponse, len(statuses))
+	for i := range statuses {
+		responses[i] = *tradingStatusToResponse(&statuses[i])
+	}
+	return responses
+}
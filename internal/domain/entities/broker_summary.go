@@ -0,0 +1,86 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// brokerCodePattern matches IDX exchange member codes such as "YP" or "CC"
+var brokerCodePattern = regexp.MustCompile(`^[A-Z0-9]{2}$`)
+
+// BrokerSummary is the trading of one exchange member in an emiten on one
+// session, Date being its calendar date. Lots are of SharesPerLot shares and
+// values are in rupiah.
+type BrokerSummary struct {
+	ID         int64     `json:"id" db:"id"`
+	Emiten     string    `json:"emiten" db:"emiten"`
+	Date       time.Time `json:"date" db:"trade_date"`
+	BrokerCode string    `json:"broker_code" db:"broker_code"`
+	BuyLots    int64     `json:"buy_lots" db:"buy_lots"`
+	SellLots   int64     `json:"sell_lots" db:"sell_lots"`
+	BuyValue   float64   `json:"buy_value" db:"buy_value"`
+	SellValue  float64   `json:"sell_value" db:"sell_value"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// NormalizeBrokerCode trims and upper-cases a broker code
+func NormalizeBrokerCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Validate normalizes the ticker and broker code and checks the date and
+// totals
+func (b *BrokerSummary) Validate() error {
+	b.Emiten = NormalizeTicker(b.Emiten)
+	if !tickerPattern.MatchString(b.Emiten) {
+		return fmt.Errorf("emiten %q must be 1-16 letters or digits", b.Emiten)
+	}
+	b.BrokerCode = NormalizeBrokerCode(b.BrokerCode)
+	if !brokerCodePattern.MatchString(b.BrokerCode) {
+		return fmt.Errorf("broker %q must be 2 letters or digits", b.BrokerCode)
+	}
+	if b.Date.IsZero() {
+		return errors.New("date is required")
+	}
+	if b.BuyLots < 0 || b.SellLots < 0 {
+		return errors.New("lots must not be negative")
+	}
+	if b.BuyValue < 0 || b.SellValue < 0 {
+		return errors.New("values must not be negative")
+	}
+	return nil
+}
+
+// NetLots returns lots bought less lots sold; positive is a net buy
+func (b *BrokerSummary) NetLots() int64 {
+	return b.BuyLots - b.SellLots
+}
+
+// NetValue returns value bought less value sold; positive is a net buy
+func (b *BrokerSummary) NetValue() float64 {
+	return b.BuyValue - b.SellValue
+}
+
+// BuyAvgPrice returns the average price per share bought, or nil without
+// lots bought
+func (b *BrokerSummary) BuyAvgPrice() *float64 {
+	return averagePrice(b.BuyValue, b.BuyLots)
+}
+
+// SellAvgPrice returns the average price per share sold, or nil without
+// lots sold
+func (b *BrokerSummary) SellAvgPrice() *float64 {
+	return averagePrice(b.SellValue, b.SellLots)
+}
+
+func averagePrice(value float64, lots int64) *float64 {
+	if lots <= 0 {
+		return nil
+	}
+	price := value / float64(lots*SharesPerLot)
+	return &price
+}
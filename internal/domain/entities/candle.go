@@ -0,0 +1,87 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// CandleInterval is the bar size used when rolling scrape snapshots into candles
+type CandleInterval string
+
+const (
+	CandleInterval1m  CandleInterval = "1m"
+	CandleInterval5m  CandleInterval = "5m"
+	CandleInterval15m CandleInterval = "15m"
+	CandleInterval1h  CandleInterval = "1h"
+	CandleInterval1d  CandleInterval = "1d"
+	CandleInterval1w  CandleInterval = "1w"
+	CandleInterval1M  CandleInterval = "1M"
+)
+
+// ParseCandleInterval validates an interval string such as "5m" or "1d"
+func ParseCandleInterval(value string) (CandleInterval, error) {
+	switch interval := CandleInterval(value); interval {
+	case CandleInterval1m, CandleInterval5m, CandleInterval15m, CandleInterval1h,
+		CandleInterval1d, CandleInterval1w, CandleInterval1M:
+		return interval, nil
+	}
+	return "", fmt.Errorf("unsupported candle interval %q", value)
+}
+
+// IsIntraday reports whether the interval is shorter than one trading day
+func (i CandleInterval) IsIntraday() bool {
+	switch i {
+	case CandleInterval1m, CandleInterval5m, CandleInterval15m, CandleInterval1h:
+		return true
+	}
+	return false
+}
+
+// Duration returns the fixed length of an intraday interval, or zero for
+// calendar-based intervals (day, week, month)
+func (i CandleInterval) Duration() time.Duration {
+	switch i {
+	case CandleInterval1m:
+		return time.Minute
+	case CandleInterval5m:
+		return 5 * time.Minute
+	case CandleInterval15m:
+		return 15 * time.Minute
+	case CandleInterval1h:
+		return time.Hour
+	}
+	return 0
+}
+
+// DefaultLookback returns how far back a candle query reaches when the
+// caller does not pass an explicit start time
+func (i CandleInterval) DefaultLookback() time.Duration {
+	switch i {
+	case CandleInterval1m, CandleInterval5m:
+		return 24 * time.Hour
+	case CandleInterval15m:
+		return 7 * 24 * time.Hour
+	case CandleInterval1h:
+		return 30 * 24 * time.Hour
+	case CandleInterval1d:
+		return 365 * 24 * time.Hour
+	case CandleInterval1w:
+		return 3 * 365 * 24 * time.Hour
+	}
+	return 10 * 365 * 24 * time.Hour
+}
+
+// Candle is an OHLC bar built from one or more market data snapshots.
+// Volume is the shares traded in the bucket, zero when the snapshots carry
+// no volume.
+type Candle struct {
+	Emiten      string         `json:"emiten" db:"emiten"`
+	Interval    CandleInterval `json:"interval" db:"interval_code"`
+	Time        time.Time      `json:"time" db:"bucket_start"`
+	Open        float64        `json:"open" db:"open_price"`
+	High        float64        `json:"high" db:"high_price"`
+	Low         float64        `json:"low" db:"low_price"`
+	Close       float64        `json:"close" db:"close_price"`
+	Volume      float64        `json:"volume" db:"volume"`
+	SampleCount int            `json:"sample_count" db:"sample_count"`
+}
@@ -0,0 +1,171 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CorporateActionType is the kind of a corporate action that changes the
+// price of a share on its ex-date
+type CorporateActionType string
+
+const (
+	CorporateActionSplit        CorporateActionType = "split"
+	CorporateActionReverseSplit CorporateActionType = "reverse_split"
+	CorporateActionBonus        CorporateActionType = "bonus"
+	CorporateActionRights       CorporateActionType = "rights"
+	CorporateActionCashDividend CorporateActionType = "cash_dividend"
+)
+
+// ParseCorporateActionType validates a corporate action type such as "split"
+func ParseCorporateActionType(value string) (CorporateActionType, error) {
+	switch t := CorporateActionType(strings.ToLower(strings.TrimSpace(value))); t {
+	case CorporateActionSplit, CorporateActionReverseSplit, CorporateActionBonus,
+		CorporateActionRights, CorporateActionCashDividend:
+		return t, nil
+	}
+	return "", fmt.Errorf("unsupported corporate action type %q", value)
+}
+
+// HasRatio reports whether actions of the type are described by a share
+// ratio rather than a cash amount
+func (t CorporateActionType) HasRatio() bool {
+	return t != CorporateActionCashDividend
+}
+
+// CorporateAction is a split, reverse split, bonus issue, rights issue or
+// cash dividend of an emiten, effective from ExDate, a calendar date.
+//
+// RatioFrom:RatioTo reads as old:new shares for splits and reverse splits
+// (1:5 turns one share into five), held:bonus shares for bonus issues and
+// held:new shares for rights issues, whose exercise price is Price. Amount
+// is the cash dividend per share.
+//
+// The optional schedule dates are calendar dates: the announcement, the
+// last session trading with the entitlement (cum), the recording date
+// fixing the entitled holders and, for dividends, the payment date.
+type CorporateAction struct {
+	ID               int64               `json:"id" db:"id"`
+	Emiten           string              `json:"emiten" db:"emiten"`
+	Type             CorporateActionType `json:"type" db:"action_type"`
+	ExDate           time.Time           `json:"ex_date" db:"ex_date"`
+	AnnouncementDate *time.Time          `json:"announcement_date,omitempty" db:"announcement_date"`
+	CumDate          *time.Time          `json:"cum_date,omitempty" db:"cum_date"`
+	RecordingDate    *time.Time          `json:"recording_date,omitempty" db:"recording_date"`
+	PaymentDate      *time.Time          `json:"payment_date,omitempty" db:"payment_date"`
+	RatioFrom        float64             `json:"ratio_from" db:"ratio_from"`
+	RatioTo          float64             `json:"ratio_to" db:"ratio_to"`
+	Price            float64             `json:"price" db:"price"`
+	Amount           float64             `json:"amount" db:"amount"`
+	Description      string              `json:"description" db:"description"`
+	CreatedAt        time.Time           `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time           `json:"updated_at" db:"updated_at"`
+}
+
+// Validate normalizes the ticker, checks the terms required by the type and
+// clears the ones that do not apply to it
+func (a *CorporateAction) Validate() error {
+	a.Emiten = NormalizeTicker(a.Emiten)
+	if !tickerPattern.MatchString(a.Emiten) {
+		return fmt.Errorf("emiten %q must be 1-16 letters or digits", a.Emiten)
+	}
+	actionType, err := ParseCorporateActionType(string(a.Type))
+	if err != nil {
+		return err
+	}
+	a.Type = actionType
+	if a.ExDate.IsZero() {
+		return errors.New("ex_date is required")
+	}
+	a.Description = strings.TrimSpace(a.Description)
+	if err := a.validateSchedule(); err != nil {
+		return err
+	}
+
+	if !a.Type.HasRatio() {
+		if a.Amount <= 0 {
+			return errors.New("amount must be positive")
+		}
+		a.RatioFrom, a.RatioTo, a.Price = 0, 0, 0
+		return nil
+	}
+
+	a.Amount = 0
+	if a.RatioFrom <= 0 || a.RatioTo <= 0 {
+		return errors.New("ratio must be two positive numbers, e.g. 1:5")
+	}
+	switch a.Type {
+	case CorporateActionSplit:
+		if a.RatioTo <= a.RatioFrom {
+			return errors.New("a split must increase the number of shares")
+		}
+	case CorporateActionReverseSplit:
+		if a.RatioTo >= a.RatioFrom {
+			return errors.New("a reverse split must decrease the number of shares")
+		}
+	case CorporateActionRights:
+		if a.Price <= 0 {
+			return errors.New("price must be positive for a rights issue")
+		}
+	}
+	if a.Type != CorporateActionRights {
+		a.Price = 0
+	}
+	return nil
+}
+
+// validateSchedule checks that the schedule dates are in chronological order:
+// announcement <= cum < ex <= recording <= payment
+func (a *CorporateAction) validateSchedule() error {
+	if a.AnnouncementDate != nil && a.CumDate != nil && a.CumDate.Before(*a.AnnouncementDate) {
+		return errors.New("cum_date must not be before announcement_date")
+	}
+	if a.AnnouncementDate != nil && a.ExDate.Before(*a.AnnouncementDate) {
+		return errors.New("ex_date must not be before announcement_date")
+	}
+	if a.CumDate != nil && !a.CumDate.Before(a.ExDate) {
+		return errors.New("cum_date must be before ex_date")
+	}
+	if a.RecordingDate != nil && a.RecordingDate.Before(a.ExDate) {
+		return errors.New("recording_date must not be before ex_date")
+	}
+	if a.PaymentDate != nil {
+		if a.Type != CorporateActionCashDividend {
+			return errors.New("payment_date only applies to cash dividends")
+		}
+		if a.PaymentDate.Before(a.ExDate) || (a.RecordingDate != nil && a.PaymentDate.Before(*a.RecordingDate)) {
+			return errors.New("payment_date must not be before ex_date or recording_date")
+		}
+	}
+	return nil
+}
+
+// Ratio formats the share ratio as RatioFrom:RatioTo, or "" for cash
+// dividends
+func (a *CorporateAction) Ratio() string {
+	if !a.Type.HasRatio() {
+		return ""
+	}
+	return fmt.Sprintf("%g:%g", a.RatioFrom, a.RatioTo)
+}
+
+// ParseRatio reads a share ratio written as "1:5"
+func ParseRatio(value string) (float64, float64, error) {
+	invalid := fmt.Errorf("ratio %q must be written as two numbers, e.g. 1:5", value)
+	from, to, ok := strings.Cut(value, ":")
+	if !ok {
+		return 0, 0, invalid
+	}
+	a, err := strconv.ParseFloat(strings.TrimSpace(from), 64)
+	if err != nil {
+		return 0, 0, invalid
+	}
+	b, err := strconv.ParseFloat(strings.TrimSpace(to), 64)
+	if err != nil {
+		return 0, 0, invalid
+	}
+	return a, b, nil
+}
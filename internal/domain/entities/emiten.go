@@ -0,0 +1,75 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Boards of the Indonesia Stock Exchange. The watchlist board is not a
+// listing board: placement on it is a trading status.
+const (
+	BoardMain         = "main"
+	BoardDevelopment  = "development"
+	BoardAcceleration = "acceleration"
+	BoardNewEconomy   = "new_economy"
+	BoardWatchlist    = "watchlist"
+)
+
+// Statuses of an emiten, recorded as trading statuses
+const (
+	EmitenStatusActive    = "active"
+	EmitenStatusSuspended = "suspended"
+	EmitenStatusDelisted  = "delisted"
+)
+
+var tickerPattern = regexp.MustCompile(`^[A-Z0-9]{1,16}$`)
+
+// Emiten is the master data of a listed company, keyed by its ticker
+type Emiten struct {
+	Ticker            string     `json:"ticker" db:"ticker"`
+	CompanyName       string     `json:"company_name" db:"company_name"`
+	Sector            string     `json:"sector" db:"sector"`
+	SubIndustry       string     `json:"sub_industry" db:"sub_industry"`
+	Board             string     `json:"board" db:"board"`
+	ListingDate       *time.Time `json:"listing_date,omitempty" db:"listing_date"`
+	SharesOutstanding int64      `json:"shares_outstanding" db:"shares_outstanding"`
+	IsSharia          bool       `json:"is_sharia" db:"is_sharia"`
+	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// NormalizeTicker upper-cases and trims a ticker
+func NormalizeTicker(ticker string) string {
+	return strings.ToUpper(strings.TrimSpace(ticker))
+}
+
+// Validate checks the emiten's ticker, name and listing board. An empty
+// board defaults to the main board.
+func (e *Emiten) Validate() error {
+	e.Ticker = NormalizeTicker(e.Ticker)
+	if !tickerPattern.MatchString(e.Ticker) {
+		return fmt.Errorf("ticker %q must be 1-16 letters or digits", e.Ticker)
+	}
+	if strings.TrimSpace(e.CompanyName) == "" {
+		return errors.New("company name is required")
+	}
+	if e.SharesOutstanding < 0 {
+		return errors.New("shares outstanding must not be negative")
+	}
+
+	e.Board = strings.ToLower(strings.TrimSpace(e.Board))
+	switch e.Board {
+	case "":
+		e.Board = BoardMain
+	case BoardMain, BoardDevelopment, BoardAcceleration, BoardNewEconomy:
+	case BoardWatchlist:
+		return errors.New("watchlist placement is recorded as a trading status, not a listing board")
+	default:
+		return fmt.Errorf("unknown board %q", e.Board)
+	}
+
+	return nil
+}
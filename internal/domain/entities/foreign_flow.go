@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ForeignFlow is the trading of foreign investors in an emiten on one
+// session, Date being its calendar date. Volumes are in shares and values in
+// rupiah, as reported by the exchange.
+type ForeignFlow struct {
+	ID         int64     `json:"id" db:"id"`
+	Emiten     string    `json:"emiten" db:"emiten"`
+	Date       time.Time `json:"date" db:"trade_date"`
+	BuyVolume  int64     `json:"buy_volume" db:"buy_volume"`
+	SellVolume int64     `json:"sell_volume" db:"sell_volume"`
+	BuyValue   float64   `json:"buy_value" db:"buy_value"`
+	SellValue  float64   `json:"sell_value" db:"sell_value"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Validate normalizes the ticker and checks the date and totals
+func (f *ForeignFlow) Validate() error {
+	f.Emiten = NormalizeTicker(f.Emiten)
+	if !tickerPattern.MatchString(f.Emiten) {
+		return fmt.Errorf("emiten %q must be 1-16 letters or digits", f.Emiten)
+	}
+	if f.Date.IsZero() {
+		return errors.New("date is required")
+	}
+	if f.BuyVolume < 0 || f.SellVolume < 0 {
+		return errors.New("volumes must not be negative")
+	}
+	if f.BuyValue < 0 || f.SellValue < 0 {
+		return errors.New("values must not be negative")
+	}
+	return nil
+}
+
+// NetVolume returns foreign buy volume less sell volume; positive is a net buy
+func (f *ForeignFlow) NetVolume() int64 {
+	return f.BuyVolume - f.SellVolume
+}
+
+// NetValue returns foreign buy value less sell value; positive is a net buy
+func (f *ForeignFlow) NetValue() float64 {
+	return f.BuyValue - f.SellValue
+}
@@ -0,0 +1,105 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Weighting schemes of a composite index
+const (
+	// IndexWeightingMarketCap weights constituents by price times shares outstanding
+	IndexWeightingMarketCap = "market_cap"
+	// IndexWeightingFreeFloat weights constituents by price times free-float shares
+	IndexWeightingFreeFloat = "free_float"
+	// IndexWeightingEqual gives every constituent the same value at each rebalance
+	IndexWeightingEqual = "equal"
+	// IndexWeightingPrice weights constituents by price alone
+	IndexWeightingPrice = "price"
+)
+
+// Reasons a new index period starts
+const (
+	IndexPeriodBase      = "base"
+	IndexPeriodRebalance = "rebalance"
+)
+
+var indexCodePattern = regexp.MustCompile(`^[A-Z0-9_]{1,32}$`)
+
+// Index is a composite index computed from constituent prices. Its level is
+// the weighted sum of constituent prices divided by a divisor that starts at
+// the base date so the index equals BaseValue, and is adjusted on every
+// membership change so the level does not jump.
+type Index struct {
+	Code      string    `json:"code" db:"code"`
+	Name      string    `json:"name" db:"name"`
+	Weighting string    `json:"weighting" db:"weighting"`
+	BaseDate  time.Time `json:"base_date" db:"base_date"`
+	BaseValue float64   `json:"base_value" db:"base_value"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// IndexConstituent is an emiten in an index period. Weight is the number of
+// units held, fixed when the period starts: shares for market_cap,
+// free-float shares for free_float, 1 for price and 1/price for equal.
+type IndexConstituent struct {
+	Emiten    string  `json:"emiten"`
+	FreeFloat float64 `json:"free_float,omitempty"`
+	Weight    float64 `json:"weight"`
+}
+
+// IndexPeriod is a span with a fixed constituent list and divisor, from
+// EffectiveFrom until the next period of the index
+type IndexPeriod struct {
+	ID            int64              `json:"id" db:"id"`
+	IndexCode     string             `json:"index_code" db:"index_code"`
+	EffectiveFrom time.Time          `json:"effective_from" db:"effective_from"`
+	Divisor       float64            `json:"divisor" db:"divisor"`
+	Reason        string             `json:"reason" db:"reason"`
+	Constituents  []IndexConstituent `json:"constituents" db:"constituents"`
+	CreatedAt     time.Time          `json:"created_at" db:"created_at"`
+}
+
+// NormalizeIndexCode upper-cases and trims an index code
+func NormalizeIndexCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Validate checks the index's code, name, weighting and base value
+func (i *Index) Validate() error {
+	i.Code = NormalizeIndexCode(i.Code)
+	if !indexCodePattern.MatchString(i.Code) {
+		return fmt.Errorf("code %q must be 1-32 letters, digits or underscores", i.Code)
+	}
+	if strings.TrimSpace(i.Name) == "" {
+		return errors.New("name is required")
+	}
+	if !IsIndexWeighting(i.Weighting) {
+		return fmt.Errorf("unknown weighting %q", i.Weighting)
+	}
+	if i.BaseValue <= 0 {
+		return errors.New("base value must be positive")
+	}
+	return nil
+}
+
+// IsIndexWeighting reports whether w is a supported weighting scheme
+func IsIndexWeighting(w string) bool {
+	switch w {
+	case IndexWeightingMarketCap, IndexWeightingFreeFloat, IndexWeightingEqual, IndexWeightingPrice:
+		return true
+	}
+	return false
+}
+
+// Emitens returns the tickers of the period's constituents
+func (p *IndexPeriod) Emitens() []string {
+	emitens := make([]string, len(p.Constituents))
+	for i, c := range p.Constituents {
+		emitens[i] = c.Emiten
+	}
+	return emitens
+}
@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// IndexMembership records that an emiten was a member of an index from
+// EffectiveFrom through EffectiveTo, both calendar dates and inclusive. A nil
+// EffectiveTo means the emiten is still a member.
+type IndexMembership struct {
+	ID            int64      `json:"id" db:"id"`
+	IndexCode     string     `json:"index_code" db:"index_code"`
+	Emiten        string     `json:"emiten" db:"emiten"`
+	EffectiveFrom time.Time  `json:"effective_from" db:"effective_from"`
+	EffectiveTo   *time.Time `json:"effective_to,omitempty" db:"effective_to"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// Validate normalizes the index code and ticker and checks the date range
+func (m *IndexMembership) Validate() error {
+	m.IndexCode = NormalizeIndexCode(m.IndexCode)
+	if !indexCodePattern.MatchString(m.IndexCode) {
+		return fmt.Errorf("index %q must be 1-32 letters, digits or underscores", m.IndexCode)
+	}
+	m.Emiten = NormalizeTicker(m.Emiten)
+	if !tickerPattern.MatchString(m.Emiten) {
+		return fmt.Errorf("emiten %q must be 1-16 letters or digits", m.Emiten)
+	}
+	if m.EffectiveFrom.IsZero() {
+		return errors.New("from is required")
+	}
+	if m.EffectiveTo != nil && m.EffectiveTo.Before(m.EffectiveFrom) {
+		return errors.New("to must not be before from")
+	}
+	return nil
+}
+
+// ActiveOn reports whether the membership covers the calendar date
+func (m *IndexMembership) ActiveOn(date time.Time) bool {
+	return !date.Before(m.EffectiveFrom) && (m.EffectiveTo == nil || !date.After(*m.EffectiveTo))
+}
+
+// Overlaps reports whether two memberships of the same emiten in the same
+// index cover a common date
+func (m *IndexMembership) Overlaps(other *IndexMembership) bool {
+	if m.IndexCode != other.IndexCode || m.Emiten != other.Emiten {
+		return false
+	}
+	startsBeforeOtherEnds := other.EffectiveTo == nil || !m.EffectiveFrom.After(*other.EffectiveTo)
+	endsAfterOtherStarts := m.EffectiveTo == nil || !m.EffectiveTo.Before(other.EffectiveFrom)
+	return startsBeforeOtherEnds && endsAfterOtherStarts
+}
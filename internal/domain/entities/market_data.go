@@ -4,7 +4,12 @@ import (
 	"time"
 )
 
-// MarketData represents market data from v_latest_market_data view
+// SharesPerLot is the number of shares in one lot on the IDX regular market
+const SharesPerLot = 100
+
+// MarketData represents market data from v_latest_market_data view. Volume
+// (shares), Value (rupiah) and Frequency (trades) are the session totals as
+// of the scrape, nil for snapshots scraped before they were captured.
 type MarketData struct {
 	ID             int64      `json:"id" db:"id"`
 	Emiten         string     `json:"emiten" db:"emiten"`
@@ -16,4 +21,26 @@ type MarketData struct {
 	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
 	DeletedAt      *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	Volume         *int64     `json:"volume,omitempty" db:"volume"`
+	Value          *float64   `json:"value,omitempty" db:"value"`
+	Frequency      *int64     `json:"frequency,omitempty" db:"frequency"`
+}
+
+// Lots returns the volume in lots, or nil without a volume
+func (md *MarketData) Lots() *int64 {
+	if md.Volume == nil {
+		return nil
+	}
+	lots := *md.Volume / SharesPerLot
+	return &lots
+}
+
+// VWAP returns the volume-weighted average price of the session so far,
+// or nil without a positive volume and a value
+func (md *MarketData) VWAP() *float64 {
+	if md.Volume == nil || md.Value == nil || *md.Volume <= 0 {
+		return nil
+	}
+	vwap := *md.Value / float64(*md.Volume)
+	return &vwap
 }
@@ -0,0 +1,91 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// OrderBookLevel is a price level of one side of the order book: the lots
+// queued at the price and, when the source reports it, the number of orders
+type OrderBookLevel struct {
+	Price  float64 `json:"price"`
+	Lots   int64   `json:"lots"`
+	Orders int     `json:"orders"`
+}
+
+// OrderBookSnapshot is the bid and offer depth of an emiten captured by a
+// scraper at Time. Bids are ordered from the highest price and offers from
+// the lowest, so the first level of each side is the best.
+type OrderBookSnapshot struct {
+	ID        int64            `json:"id" db:"id"`
+	Emiten    string           `json:"emiten" db:"emiten"`
+	Time      time.Time        `json:"time" db:"captured_at"`
+	Bids      []OrderBookLevel `json:"bids" db:"bids"`
+	Offers    []OrderBookLevel `json:"offers" db:"offers"`
+	CreatedAt time.Time        `json:"created_at" db:"created_at"`
+}
+
+// Validate normalizes the ticker, checks every level and sorts both sides
+// best first. A side may be empty, e.g. an emiten at its auto-rejection
+// limit has no offers.
+func (b *OrderBookSnapshot) Validate() error {
+	b.Emiten = NormalizeTicker(b.Emiten)
+	if !tickerPattern.MatchString(b.Emiten) {
+		return fmt.Errorf("emiten %q must be 1-16 letters or digits", b.Emiten)
+	}
+	if b.Time.IsZero() {
+		return errors.New("time is required")
+	}
+	if len(b.Bids) == 0 && len(b.Offers) == 0 {
+		return errors.New("at least one bid or offer level is required")
+	}
+
+	if b.Bids == nil {
+		b.Bids = []OrderBookLevel{}
+	}
+	if b.Offers == nil {
+		b.Offers = []OrderBookLevel{}
+	}
+	sort.SliceStable(b.Bids, func(i, j int) bool { return b.Bids[i].Price > b.Bids[j].Price })
+	sort.SliceStable(b.Offers, func(i, j int) bool { return b.Offers[i].Price < b.Offers[j].Price })
+	if err := validateOrderBookSide("bid", b.Bids); err != nil {
+		return err
+	}
+	return validateOrderBookSide("offer", b.Offers)
+}
+
+func validateOrderBookSide(side string, levels []OrderBookLevel) error {
+	for i, level := range levels {
+		if level.Price <= 0 {
+			return fmt.Errorf("%s price must be positive", side)
+		}
+		if level.Lots <= 0 {
+			return fmt.Errorf("%s lots at %g must be positive", side, level.Price)
+		}
+		if level.Orders < 0 {
+			return fmt.Errorf("%s orders at %g must not be negative", side, level.Price)
+		}
+		if i > 0 && levels[i-1].Price == level.Price {
+			return fmt.Errorf("duplicate %s level at %g", side, level.Price)
+		}
+	}
+	return nil
+}
+
+// BestBid returns the highest bid level, or nil without bids
+func (b *OrderBookSnapshot) BestBid() *OrderBookLevel {
+	if len(b.Bids) == 0 {
+		return nil
+	}
+	return &b.Bids[0]
+}
+
+// BestOffer returns the lowest offer level, or nil without offers
+func (b *OrderBookSnapshot) BestOffer() *OrderBookLevel {
+	if len(b.Offers) == 0 {
+		return nil
+	}
+	return &b.Offers[0]
+}
@@ -0,0 +1,21 @@
+package entities
+
+import (
+	"time"
+)
+
+// QuarantinedMarketData is a scraped snapshot held back from the candle
+// rollups because it failed validation, with the reasons it was rejected.
+// MarketDataID refers to the snapshot in v_latest_market_data.
+type QuarantinedMarketData struct {
+	ID           int64     `json:"id" db:"id"`
+	MarketDataID int64     `json:"market_data_id" db:"market_data_id"`
+	Emiten       string    `json:"emiten" db:"emiten"`
+	OpenPrice    float64   `json:"open_price" db:"open_price"`
+	HighPrice    float64   `json:"high_price" db:"high_price"`
+	LowPrice     float64   `json:"low_price" db:"low_price"`
+	ClosePrice   float64   `json:"close_price" db:"last_price"`
+	Date         time.Time `json:"date" db:"date_time_scraping"`
+	Reason       string    `json:"reason" db:"reason"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,62 @@
+package entities
+
+import (
+	"time"
+)
+
+// ScreenFilter is the saved definition of a screen. Thresholds that are nil
+// are not applied; Emitens, when set, restricts the screen to those tickers;
+// Expression is an optional screener expression ANDed with the thresholds.
+type ScreenFilter struct {
+	MinPrice     *float64 `json:"min_price,omitempty"`
+	MaxPrice     *float64 `json:"max_price,omitempty"`
+	MinChangePct *float64 `json:"min_change_pct,omitempty"`
+	MaxChangePct *float64 `json:"max_change_pct,omitempty"`
+	Emitens      []string `json:"emitens,omitempty"`
+	Expression   string   `json:"expression,omitempty"`
+}
+
+// Screen is a named filter saved by a user and optionally evaluated on a cron
+// schedule in Asia/Jakarta time
+type Screen struct {
+	ID        int64        `json:"id" db:"id"`
+	UserID    string       `json:"user_id" db:"user_id"`
+	Name      string       `json:"name" db:"name"`
+	Filter    ScreenFilter `json:"filter" db:"filter"`
+	Schedule  string       `json:"schedule" db:"schedule"`
+	Enabled   bool         `json:"enabled" db:"enabled"`
+	NextRunAt *time.Time   `json:"next_run_at,omitempty" db:"next_run_at"`
+	LastRunAt *time.Time   `json:"last_run_at,omitempty" db:"last_run_at"`
+	CreatedAt time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+// ScreenResult is one emiten that matched a screen run
+type ScreenResult struct {
+	Emiten     string   `json:"emiten"`
+	ClosePrice float64  `json:"close_price"`
+	ChangePct  *float64 `json:"change_pct,omitempty"`
+}
+
+// ScreenRun is a stored evaluation of a screen
+type ScreenRun struct {
+	ID       int64          `json:"id" db:"id"`
+	ScreenID int64          `json:"screen_id" db:"screen_id"`
+	Trigger  string         `json:"trigger" db:"trigger_type"`
+	RunAt    time.Time      `json:"run_at" db:"run_at"`
+	Results  []ScreenResult `json:"results" db:"results"`
+}
+
+const (
+	ScreenTriggerSchedule = "schedule"
+	ScreenTriggerManual   = "manual"
+)
+
+// Emitens returns the tickers matched by the run
+func (r *ScreenRun) Emitens() []string {
+	emitens := make([]string, len(r.Results))
+	for i, result := range r.Results {
+		emitens[i] = result.Emiten
+	}
+	return emitens
+}
@@ -0,0 +1,101 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TradingStatus records the exchange status of an emiten from EffectiveFrom
+// through EffectiveTo, both calendar dates and inclusive. A nil EffectiveTo
+// means the status still applies. Notations are the IDX special notation
+// letters attached to the ticker, and Watchlist places the emiten on the
+// watchlist board, traded by full call auction.
+type TradingStatus struct {
+	ID            int64      `json:"id" db:"id"`
+	Emiten        string     `json:"emiten" db:"emiten"`
+	Status        string     `json:"status" db:"status"`
+	Notations     []string   `json:"notations" db:"notations"`
+	Watchlist     bool       `json:"watchlist" db:"watchlist"`
+	Reason        string     `json:"reason" db:"reason"`
+	EffectiveFrom time.Time  `json:"effective_from" db:"effective_from"`
+	EffectiveTo   *time.Time `json:"effective_to,omitempty" db:"effective_to"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// Validate normalizes the ticker, status and notations and checks the date
+// range. An empty status defaults to active.
+func (s *TradingStatus) Validate() error {
+	s.Emiten = NormalizeTicker(s.Emiten)
+	if !tickerPattern.MatchString(s.Emiten) {
+		return fmt.Errorf("emiten %q must be 1-16 letters or digits", s.Emiten)
+	}
+
+	s.Status = strings.ToLower(strings.TrimSpace(s.Status))
+	switch s.Status {
+	case "":
+		s.Status = EmitenStatusActive
+	case EmitenStatusActive, EmitenStatusSuspended, EmitenStatusDelisted:
+	default:
+		return fmt.Errorf("unknown status %q", s.Status)
+	}
+
+	notations, err := NormalizeNotations(s.Notations)
+	if err != nil {
+		return err
+	}
+	s.Notations = notations
+	s.Reason = strings.TrimSpace(s.Reason)
+
+	if s.EffectiveFrom.IsZero() {
+		return errors.New("from is required")
+	}
+	if s.EffectiveTo != nil && s.EffectiveTo.Before(s.EffectiveFrom) {
+		return errors.New("to must not be before from")
+	}
+	return nil
+}
+
+// NormalizeNotations upper-cases, sorts and deduplicates special notations,
+// each a single letter such as "E" or "X"
+func NormalizeNotations(notations []string) ([]string, error) {
+	seen := make(map[string]bool, len(notations))
+	normalized := []string{}
+	for _, n := range notations {
+		n = strings.ToUpper(strings.TrimSpace(n))
+		if n == "" || seen[n] {
+			continue
+		}
+		if len(n) != 1 || n[0] < 'A' || n[0] > 'Z' {
+			return nil, fmt.Errorf("notation %q must be a single letter", n)
+		}
+		seen[n] = true
+		normalized = append(normalized, n)
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
+
+// IsSuspended reports whether trading in the emiten is halted
+func (s *TradingStatus) IsSuspended() bool {
+	return s.Status == EmitenStatusSuspended
+}
+
+// ActiveOn reports whether the status covers the calendar date
+func (s *TradingStatus) ActiveOn(date time.Time) bool {
+	return !date.Before(s.EffectiveFrom) && (s.EffectiveTo == nil || !date.After(*s.EffectiveTo))
+}
+
+// Overlaps reports whether two statuses of the same emiten cover a common
+// date
+func (s *TradingStatus) Overlaps(other *TradingStatus) bool {
+	if s.Emiten != other.Emiten {
+		return false
+	}
+	startsBeforeOtherEnds := other.EffectiveTo == nil || !s.EffectiveFrom.After(*other.EffectiveTo)
+	endsAfterOtherStarts := s.EffectiveTo == nil || !s.EffectiveTo.Before(other.EffectiveFrom)
+	return startsBeforeOtherEnds && endsAfterOtherStarts
+}
@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"time"
+
+	"api-web-scrapping/internal/domain/entities"
+)
+
+// BrokerSummaryRepository defines the interface for daily broker summaries
+type BrokerSummaryRepository interface {
+	// Upsert inserts summaries or, for an existing emiten, date and broker,
+	// replaces their totals
+	Upsert(summaries []entities.BrokerSummary) error
+	// FindByEmiten retrieves the summaries of every broker in an emiten
+	// dated within [from, to] ordered by date and broker
+	FindByEmiten(emiten string, from, to time.Time) ([]entities.BrokerSummary, error)
+	// FindByBroker retrieves the summaries of a broker in every emiten
+	// dated within [from, to] ordered by date and emiten
+	FindByBroker(brokerCode string, from, to time.Time) ([]entities.BrokerSummary, error)
+	// LatestDate returns the most recent date with a summary, or nil if none
+	// was recorded
+	LatestDate() (*time.Time, error)
+	// LatestDateByEmiten returns the most recent date with a summary of an
+	// emiten, or nil if none was recorded
+	LatestDateByEmiten(emiten string) (*time.Time, error)
+}
@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"time"
+
+	"api-web-scrapping/internal/domain/entities"
+)
+
+// CandleRepository defines the interface for persisted candle rollups
+type CandleRepository interface {
+	SaveCandles(candles []entities.Candle) error
+	GetCandles(emiten string, interval entities.CandleInterval, from, to time.Time) ([]entities.Candle, error)
+}
@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"time"
+
+	"api-web-scrapping/internal/domain/entities"
+)
+
+// CorporateActionRepository defines the interface for corporate actions
+type CorporateActionRepository interface {
+	Create(action *entities.CorporateAction) error
+	Update(action *entities.CorporateAction) error
+	// Upsert inserts actions or, for an existing emiten, type and ex-date,
+	// updates their terms
+	Upsert(actions []entities.CorporateAction) error
+	Delete(id int64) error
+	FindByID(id int64) (*entities.CorporateAction, error)
+	// FindByEmiten retrieves the actions of an emiten ordered by ex-date
+	FindByEmiten(emiten string) ([]entities.CorporateAction, error)
+	// FindByRange retrieves the actions with an ex-date within [from, to]
+	// ordered by ex-date, optionally restricted to one emiten
+	FindByRange(emiten string, from, to time.Time) ([]entities.CorporateAction, error)
+	// FindDividendsByDate retrieves the cash dividends with a cum, ex,
+	// recording or payment date within [from, to], ordered by ex-date
+	FindDividendsByDate(from, to time.Time) ([]entities.CorporateAction, error)
+}
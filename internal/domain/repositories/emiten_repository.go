@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"api-web-scrapping/internal/domain/entities"
+)
+
+// EmitenRepository defines the interface for emiten master data
+type EmitenRepository interface {
+	Create(emiten *entities.Emiten) error
+	Update(emiten *entities.Emiten) error
+	Upsert(emitens []entities.Emiten) error
+	Delete(ticker string) error
+	FindByTicker(ticker string) (*entities.Emiten, error)
+	FindAll() ([]entities.Emiten, error)
+}
@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"time"
+
+	"api-web-scrapping/internal/domain/entities"
+)
+
+// ForeignFlowRepository defines the interface for daily foreign flows
+type ForeignFlowRepository interface {
+	// Upsert inserts flows or, for an existing emiten and date, replaces
+	// their totals
+	Upsert(flows []entities.ForeignFlow) error
+	// FindByEmiten retrieves the flows of an emiten dated within [from, to]
+	// ordered by date
+	FindByEmiten(emiten string, from, to time.Time) ([]entities.ForeignFlow, error)
+	// FindByRange retrieves the flows of every emiten dated within
+	// [from, to] ordered by date and emiten
+	FindByRange(from, to time.Time) ([]entities.ForeignFlow, error)
+	// LatestDate returns the most recent date with a flow, or nil if none
+	// was recorded
+	LatestDate() (*time.Time, error)
+}
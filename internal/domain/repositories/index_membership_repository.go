@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"time"
+
+	"api-web-scrapping/internal/domain/entities"
+)
+
+// IndexMembershipRepository defines the interface for effective-dated index
+// membership records
+type IndexMembershipRepository interface {
+	Create(membership *entities.IndexMembership) error
+	Update(membership *entities.IndexMembership) error
+	// Upsert inserts memberships or, for an existing index, emiten and
+	// effective-from date, updates the effective-to date
+	Upsert(memberships []entities.IndexMembership) error
+	Delete(id int64) error
+	FindByID(id int64) (*entities.IndexMembership, error)
+	// FindByIndex retrieves the memberships of an index ordered by emiten
+	// and effective-from date, optionally restricted to one emiten
+	FindByIndex(indexCode, emiten string) ([]entities.IndexMembership, error)
+	// FindActive retrieves the memberships of an index covering a date
+	FindActive(indexCode string, date time.Time) ([]entities.IndexMembership, error)
+}
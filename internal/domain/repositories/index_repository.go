@@ -0,0 +1,18 @@
+package repositories
+
+import "api-web-scrapping/internal/domain/entities"
+
+// IndexRepository defines the interface for composite index definitions and
+// their periods
+type IndexRepository interface {
+	// Create inserts an index together with its base period
+	Create(index *entities.Index, base *entities.IndexPeriod) error
+	Update(index *entities.Index) error
+	Delete(code string) error
+	FindByCode(code string) (*entities.Index, error)
+	FindAll() ([]entities.Index, error)
+
+	CreatePeriod(period *entities.IndexPeriod) error
+	// FindPeriods retrieves the periods of an index, oldest first
+	FindPeriods(code string) ([]entities.IndexPeriod, error)
+}
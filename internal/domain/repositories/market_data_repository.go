@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"time"
+
 	"api-web-scrapping/internal/domain/entities"
 )
 
@@ -10,4 +12,11 @@ type MarketDataRepository interface {
 	GetByEmiten(emiten string) ([]entities.MarketData, error)
 	GetLatestByEmiten(emiten string) (*entities.MarketData, error)
 	GetLatestByAllEmiten() ([]entities.MarketData, error)
+	GetLatestByEmitenAsOf(emiten string, asOf time.Time) (*entities.MarketData, error)
+	GetLatestByAllEmitenAsOf(asOf time.Time) ([]entities.MarketData, error)
+	GetHistoryByEmiten(emiten string, from, to time.Time) ([]entities.MarketData, error)
+	GetHistory(from, to time.Time) ([]entities.MarketData, error)
+	GetDailyHistoryByEmiten(emiten string, from, to time.Time) ([]entities.MarketData, error)
+	GetDailyHistory(from, to time.Time) ([]entities.MarketData, error)
+	GetHighestByEmiten(emiten string, before time.Time, limit int) ([]entities.MarketData, error)
 }
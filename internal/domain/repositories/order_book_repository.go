@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"time"
+
+	"api-web-scrapping/internal/domain/entities"
+)
+
+// OrderBookRepository defines the interface for order book depth snapshots
+type OrderBookRepository interface {
+	// Save inserts snapshots or, for an existing emiten and capture time,
+	// replaces their levels
+	Save(snapshots []entities.OrderBookSnapshot) error
+	// FindLatestByEmiten retrieves the last snapshot of an emiten captured at
+	// or before asOf, or nil if there is none
+	FindLatestByEmiten(emiten string, asOf time.Time) (*entities.OrderBookSnapshot, error)
+	// DeleteBefore removes the snapshots captured before cutoff and returns
+	// how many were removed
+	DeleteBefore(cutoff time.Time) (int64, error)
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"time"
+
+	"api-web-scrapping/internal/domain/entities"
+)
+
+// QuarantineRepository defines the interface for snapshots rejected on ingest
+type QuarantineRepository interface {
+	// Save records rejected snapshots, ignoring ones already quarantined
+	Save(records []entities.QuarantinedMarketData) error
+	// FindByRange retrieves the snapshots scraped within [from, to], newest
+	// first, optionally restricted to one emiten
+	FindByRange(emiten string, from, to time.Time) ([]entities.QuarantinedMarketData, error)
+}
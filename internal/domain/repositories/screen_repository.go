@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"time"
+
+	"api-web-scrapping/internal/domain/entities"
+)
+
+// ScreenRepository defines the interface for saved screens and their runs
+type ScreenRepository interface {
+	Create(screen *entities.Screen) error
+	Update(screen *entities.Screen) error
+	Delete(id int64) error
+	FindByID(id int64) (*entities.Screen, error)
+	FindByUser(userID string) ([]entities.Screen, error)
+	FindDue(now time.Time) ([]entities.Screen, error)
+	UpdateRunTimes(id int64, lastRunAt time.Time, nextRunAt *time.Time) error
+
+	CreateRun(run *entities.ScreenRun) error
+	FindRuns(screenID int64, limit int) ([]entities.ScreenRun, error)
+	FindRun(screenID, runID int64) (*entities.ScreenRun, error)
+	FindPreviousRun(screenID, runID int64) (*entities.ScreenRun, error)
+}
@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"time"
+
+	"api-web-scrapping/internal/domain/entities"
+)
+
+// TradingStatusRepository defines the interface for effective-dated trading
+// status records
+type TradingStatusRepository interface {
+	Create(status *entities.TradingStatus) error
+	Update(status *entities.TradingStatus) error
+	// Upsert inserts statuses or, for an existing emiten and effective-from
+	// date, updates the status, notations, watchlist flag, reason and
+	// effective-to date
+	Upsert(statuses []entities.TradingStatus) error
+	Delete(id int64) error
+	FindByID(id int64) (*entities.TradingStatus, error)
+	// FindByEmiten retrieves the statuses of an emiten ordered by
+	// effective-from date
+	FindByEmiten(emiten string) ([]entities.TradingStatus, error)
+	// FindByRange retrieves the statuses covering any date within [from, to]
+	// ordered by emiten and effective-from date
+	FindByRange(from, to time.Time) ([]entities.TradingStatus, error)
+}
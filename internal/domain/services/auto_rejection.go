@@ -0,0 +1,152 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"api-web-scrapping/internal/domain/entities"
+)
+
+// AutoRejectionTier applies to previous closes up to and including UpTo;
+// zero UpTo means no upper bound. Percentages are of the previous close.
+type AutoRejectionTier struct {
+	UpTo     float64 `json:"up_to"`
+	UpperPct float64 `json:"upper_pct"`
+	LowerPct float64 `json:"lower_pct"`
+}
+
+// AutoRejectionBoard holds the tiers of a listing board, ordered by UpTo,
+// and the lowest price the board allows, below which the lower limit never
+// falls
+type AutoRejectionBoard struct {
+	FloorPrice float64             `json:"floor_price"`
+	Tiers      []AutoRejectionTier `json:"tiers"`
+}
+
+// AutoRejectionRules maps listing boards to their auto-rejection tiers.
+// Emitens on a board without rules, or without master data, use the main
+// board's.
+type AutoRejectionRules struct {
+	Boards map[string]AutoRejectionBoard `json:"boards"`
+}
+
+// AutoRejectionLimits are the prices at which orders are rejected in a
+// session: above Upper (ARA, auto rejection atas) and below Lower (ARB,
+// auto rejection bawah)
+type AutoRejectionLimits struct {
+	PrevClose float64
+	Upper     float64
+	Lower     float64
+}
+
+// DefaultAutoRejectionRules are the symmetric IDX limits: 35% up to Rp200,
+// 25% up to Rp5,000 and 20% above on the main, development and new economy
+// boards, and 10% on the acceleration and watchlist boards
+func DefaultAutoRejectionRules() AutoRejectionRules {
+	regular := AutoRejectionBoard{
+		FloorPrice: 50,
+		Tiers: []AutoRejectionTier{
+			{UpTo: 200, UpperPct: 35, LowerPct: 35},
+			{UpTo: 5000, UpperPct: 25, LowerPct: 25},
+			{UpperPct: 20, LowerPct: 20},
+		},
+	}
+	special := AutoRejectionBoard{
+		FloorPrice: 1,
+		Tiers:      []AutoRejectionTier{{UpperPct: 10, LowerPct: 10}},
+	}
+	return AutoRejectionRules{Boards: map[string]AutoRejectionBoard{
+		entities.BoardMain:         regular,
+		entities.BoardDevelopment:  regular,
+		entities.BoardNewEconomy:   regular,
+		entities.BoardAcceleration: special,
+		entities.BoardWatchlist:    special,
+	}}
+}
+
+// ParseAutoRejectionRules reads rules from JSON and validates them. Boards
+// left out of the document keep their default rules.
+func ParseAutoRejectionRules(data []byte) (AutoRejectionRules, error) {
+	var parsed AutoRejectionRules
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return AutoRejectionRules{}, fmt.Errorf("invalid auto-rejection rules: %w", err)
+	}
+
+	rules := DefaultAutoRejectionRules()
+	for board, b := range parsed.Boards {
+		if err := b.validate(); err != nil {
+			return AutoRejectionRules{}, fmt.Errorf("board %s: %w", board, err)
+		}
+		rules.Boards[board] = b
+	}
+	return rules, nil
+}
+
+func (b AutoRejectionBoard) validate() error {
+	if len(b.Tiers) == 0 {
+		return fmt.Errorf("at least one tier is required")
+	}
+	if b.FloorPrice < 0 {
+		return fmt.Errorf("floor price must not be negative")
+	}
+	for i, t := range b.Tiers {
+		if t.UpperPct <= 0 || t.LowerPct <= 0 || t.LowerPct >= 100 {
+			return fmt.Errorf("tier %d: percentages must be positive and the lower one below 100", i+1)
+		}
+		last := i == len(b.Tiers)-1
+		if last != (t.UpTo == 0) {
+			return fmt.Errorf("tier %d: only the last tier is unbounded", i+1)
+		}
+		if i > 0 && !last && t.UpTo <= b.Tiers[i-1].UpTo {
+			return fmt.Errorf("tier %d: tiers must be ordered by price", i+1)
+		}
+	}
+	return nil
+}
+
+// Limits computes the auto-rejection prices of a session from the previous
+// close on a board. It reports false without a positive previous close.
+func (r AutoRejectionRules) Limits(board string, prevClose float64) (AutoRejectionLimits, bool) {
+	if !(prevClose > 0) {
+		return AutoRejectionLimits{}, false
+	}
+	b, ok := r.Boards[board]
+	if !ok {
+		b, ok = r.Boards[entities.BoardMain]
+	}
+	if !ok || len(b.Tiers) == 0 {
+		return AutoRejectionLimits{}, false
+	}
+
+	tier := b.Tiers[len(b.Tiers)-1]
+	for _, t := range b.Tiers {
+		if t.UpTo == 0 || prevClose <= t.UpTo {
+			tier = t
+			break
+		}
+	}
+
+	// Limits stay inside the band: the upper one rounds down and the lower
+	// one up to a valid tick. Low-priced stocks may still move at least one
+	// tick.
+	upper := math.Max(RoundToTick(prevClose*(1+tier.UpperPct/100), TickRoundDown), RoundToTick(prevClose+TickSize(prevClose), TickRoundUp))
+	lower := math.Min(RoundToTick(prevClose*(1-tier.LowerPct/100), TickRoundUp), RoundToTick(prevClose-TickSize(prevClose), TickRoundDown))
+	return AutoRejectionLimits{
+		PrevClose: prevClose,
+		Upper:     upper,
+		Lower:     math.Max(lower, b.FloorPrice),
+	}, true
+}
+
+// HitUpper reports whether a session high reached the upper limit
+func (l AutoRejectionLimits) HitUpper(high float64) bool {
+	return high >= l.Upper
+}
+
+// HitLower reports whether a session low reached the lower limit. A stock
+// resting at the board's floor price cannot fall further, but that is not
+// an auto-rejection.
+func (l AutoRejectionLimits) HitLower(low float64) bool {
+	return low <= l.Lower && l.Lower < l.PrevClose
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"sort"
+	"time"
+
+	"api-web-scrapping/internal/domain/entities"
+)
+
+// BreadthHighLowWindow is the look-back of new highs and lows
+const BreadthHighLowWindow = 52 * 7 * 24 * time.Hour
+
+// BreadthDay is the market breadth of one trading day. ADLine is the running
+// total of net advances since the first day of the series.
+type BreadthDay struct {
+	Date        time.Time
+	Advancers   int
+	Decliners   int
+	Unchanged   int
+	NewHighs    int
+	NewLows     int
+	NetAdvances int
+	ADLine      int
+}
+
+// ComputeBreadth derives daily breadth for the sessions in [from, to] from
+// daily candles per emiten, which must be oldest first and should start a
+// 52-week window before from. An emiten advances or declines against its
+// previous session's close and makes a new high (low) when its high (low)
+// exceeds every high (low) of the prior 52 weeks of its history; an emiten's
+// first session is neither.
+func ComputeBreadth(candlesByEmiten map[string][]entities.Candle, from, to time.Time) []BreadthDay {
+	days := make(map[time.Time]*BreadthDay)
+	day := func(t time.Time) *BreadthDay {
+		key := BucketStart(t, entities.CandleInterval1d)
+		d, ok := days[key]
+		if !ok {
+			d = &BreadthDay{Date: key}
+			days[key] = d
+		}
+		return d
+	}
+
+	for _, candles := range candlesByEmiten {
+		// Monotonic deques of candle indices within the trailing window, the
+		// front holding the window's highest high (lowest low)
+		var highs, lows []int
+
+		for i, c := range candles {
+			windowStart := c.Time.Add(-BreadthHighLowWindow)
+			for len(highs) > 0 && !candles[highs[0]].Time.After(windowStart) {
+				highs = highs[1:]
+			}
+			for len(lows) > 0 && !candles[lows[0]].Time.After(windowStart) {
+				lows = lows[1:]
+			}
+
+			if i > 0 && !c.Time.Before(from) && !c.Time.After(to) {
+				d := day(c.Time)
+				prev := candles[i-1].Close
+				switch {
+				case c.Close > prev:
+					d.Advancers++
+				case c.Close < prev:
+					d.Decliners++
+				default:
+					d.Unchanged++
+				}
+				if len(highs) > 0 && c.High > candles[highs[0]].High {
+					d.NewHighs++
+				}
+				if len(lows) > 0 && c.Low < candles[lows[0]].Low {
+					d.NewLows++
+				}
+			}
+
+			for len(highs) > 0 && candles[highs[len(highs)-1]].High <= c.High {
+				highs = highs[:len(highs)-1]
+			}
+			highs = append(highs, i)
+			for len(lows) > 0 && candles[lows[len(lows)-1]].Low >= c.Low {
+				lows = lows[:len(lows)-1]
+			}
+			lows = append(lows, i)
+		}
+	}
+
+	series := make([]BreadthDay, 0, len(days))
+	for _, d := range days {
+		series = append(series, *d)
+	}
+	sort.Slice(series, func(i, j int) bool { return series[i].Date.Before(series[j].Date) })
+
+	adLine := 0
+	for i := range series {
+		series[i].NetAdvances = series[i].Advancers - series[i].Decliners
+		adLine += series[i].NetAdvances
+		series[i].ADLine = adLine
+	}
+	return series
+}
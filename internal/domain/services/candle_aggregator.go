@@ -0,0 +1,117 @@
+package services
+
+import (
+	"sort"
+	"time"
+
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/pkg/tradingcalendar"
+)
+
+// WIB is Western Indonesia Time, the exchange time zone of IDX, shared with
+// the trading calendar
+var WIB = tradingcalendar.WIB
+
+// CalendarDate returns the WIB calendar date of t as midnight UTC, the form in
+// which DATE columns and YYYY-MM-DD request fields are stored. It accepts both
+// that form and WIB timestamps, so the two can be compared.
+func CalendarDate(t time.Time) time.Time {
+	year, month, day := t.In(WIB).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// Session 1 on IDX opens at 09:00 WIB; intraday buckets are anchored to it so a
+// 15m candle covers 09:00-09:15 rather than an arbitrary clock offset.
+const (
+	sessionOpenHour   = 9
+	sessionOpenMinute = 0
+)
+
+// BucketStart returns the start of the candle bucket that contains t, in WIB
+func BucketStart(t time.Time, interval entities.CandleInterval) time.Time {
+	local := t.In(WIB)
+	year, month, day := local.Date()
+
+	switch interval {
+	case entities.CandleInterval1d:
+		return time.Date(year, month, day, 0, 0, 0, 0, WIB)
+	case entities.CandleInterval1w:
+		offset := (int(local.Weekday()) + 6) % 7 // days since Monday
+		return time.Date(year, month, day-offset, 0, 0, 0, 0, WIB)
+	case entities.CandleInterval1M:
+		return time.Date(year, month, 1, 0, 0, 0, 0, WIB)
+	}
+
+	size := interval.Duration()
+	anchor := time.Date(year, month, day, sessionOpenHour, sessionOpenMinute, 0, 0, WIB)
+	elapsed := local.Sub(anchor)
+	n := elapsed / size
+	if elapsed < 0 && elapsed%size != 0 {
+		n-- // floor division for pre-opening snapshots
+	}
+	return anchor.Add(n * size)
+}
+
+// AggregateCandles rolls snapshots of a single emiten into OHLC candles: the
+// open of the first snapshot, the highest high, the lowest low and the close
+// of the last snapshot in each bucket. The result is ordered by time.
+//
+// Snapshot volumes are running session totals, so a candle's volume is the
+// growth of the total within its bucket, summed over sessions. A total below
+// an earlier one of the session is taken as a correction and adds nothing.
+func AggregateCandles(data []entities.MarketData, interval entities.CandleInterval) []entities.Candle {
+	if len(data) == 0 {
+		return nil
+	}
+
+	sorted := make([]entities.MarketData, len(data))
+	copy(sorted, data)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date.Before(sorted[j].Date)
+	})
+
+	var candles []entities.Candle
+	var session time.Time
+	var sessionVolume float64
+	for _, md := range sorted {
+		var volume float64
+		if md.Volume != nil {
+			if day := BucketStart(md.Date, entities.CandleInterval1d); !day.Equal(session) {
+				session, sessionVolume = day, 0
+			}
+			if total := float64(*md.Volume); total > sessionVolume {
+				volume, sessionVolume = total-sessionVolume, total
+			}
+		}
+
+		start := BucketStart(md.Date, interval)
+		n := len(candles)
+		if n > 0 && candles[n-1].Time.Equal(start) {
+			c := &candles[n-1]
+			c.Volume += volume
+			if md.HighPrice > c.High {
+				c.High = md.HighPrice
+			}
+			if md.LowPrice < c.Low {
+				c.Low = md.LowPrice
+			}
+			c.Close = md.ClosePrice
+			c.SampleCount++
+			continue
+		}
+
+		candles = append(candles, entities.Candle{
+			Emiten:      md.Emiten,
+			Interval:    interval,
+			Time:        start,
+			Open:        md.OpenPrice,
+			High:        md.HighPrice,
+			Low:         md.LowPrice,
+			Close:       md.ClosePrice,
+			Volume:      volume,
+			SampleCount: 1,
+		})
+	}
+
+	return candles
+}
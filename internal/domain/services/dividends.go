@@ -0,0 +1,50 @@
+package services
+
+import (
+	"time"
+
+	"api-web-scrapping/internal/domain/entities"
+)
+
+// SharesPerShare returns how many shares one share held just before the
+// first session after `after` has become by asOf, through the splits,
+// reverse splits and bonus issues with an ex-date in (after, asOf]. Rights
+// issues are left out since taking them up is optional.
+func SharesPerShare(actions []entities.CorporateAction, after, asOf time.Time) float64 {
+	shares := 1.0
+	for _, a := range actions {
+		if !a.ExDate.After(after) || a.ExDate.After(asOf) || a.RatioFrom <= 0 {
+			continue
+		}
+		switch a.Type {
+		case entities.CorporateActionSplit, entities.CorporateActionReverseSplit:
+			shares *= a.RatioTo / a.RatioFrom
+		case entities.CorporateActionBonus:
+			shares *= (a.RatioFrom + a.RatioTo) / a.RatioFrom
+		}
+	}
+	return shares
+}
+
+// RestatedDividend returns the amount of a cash dividend per share of asOf,
+// so that dividends paid before a split compare with prices after it
+func RestatedDividend(dividend entities.CorporateAction, actions []entities.CorporateAction, asOf time.Time) float64 {
+	return dividend.Amount / SharesPerShare(actions, dividend.ExDate, asOf)
+}
+
+// TrailingDividends returns the cash dividends with an ex-date in the twelve
+// months up to and including asOf, a calendar date, and their total restated
+// per share of asOf
+func TrailingDividends(actions []entities.CorporateAction, asOf time.Time) (float64, []entities.CorporateAction) {
+	windowStart := asOf.AddDate(-1, 0, 0)
+	total := 0.0
+	var dividends []entities.CorporateAction
+	for _, a := range actions {
+		if a.Type != entities.CorporateActionCashDividend || !a.ExDate.After(windowStart) || a.ExDate.After(asOf) {
+			continue
+		}
+		total += RestatedDividend(a, actions, asOf)
+		dividends = append(dividends, a)
+	}
+	return total, dividends
+}
@@ -0,0 +1,268 @@
+package services
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"api-web-scrapping/internal/domain/entities"
+)
+
+// What an emiten search result matched on
+const (
+	MatchTicker = "ticker"
+	MatchName   = "name"
+)
+
+// Scores of the match kinds, best first. Scores within a kind are adjusted so
+// closer matches rank higher without crossing into the next kind.
+const (
+	scoreTickerExact  = 1000
+	scoreTickerPrefix = 900
+	scoreNamePrefix   = 700
+	scoreWordPrefix   = 600
+	scoreNameContains = 500
+	scoreFuzzyWords   = 300
+	scoreSubsequence  = 100
+)
+
+const minSubsequenceLength = 3
+
+// EmitenSearchResult is an emiten matching a search query
+type EmitenSearchResult struct {
+	Emiten    entities.Emiten
+	Score     int
+	MatchedOn string
+}
+
+type emitenIndexEntry struct {
+	emiten entities.Emiten
+	ticker string
+	name   string
+	words  []string
+}
+
+// EmitenIndex is an in-memory search index over emiten master data. It is
+// safe for concurrent use; Rebuild swaps the whole index at once.
+type EmitenIndex struct {
+	mu         sync.RWMutex
+	entries    []emitenIndexEntry
+	built      bool
+	generation uint64
+}
+
+// NewEmitenIndex creates an empty index
+func NewEmitenIndex() *EmitenIndex {
+	return &EmitenIndex{}
+}
+
+// Rebuild replaces the indexed emitens with a list loaded at generation. The
+// index only counts as built when it was not invalidated since, so a list
+// loaded before a change is replaced on the next rebuild.
+func (ix *EmitenIndex) Rebuild(emitens []entities.Emiten, generation uint64) {
+	entries := make([]emitenIndexEntry, len(emitens))
+	for i, e := range emitens {
+		words := searchWords(e.CompanyName)
+		entries[i] = emitenIndexEntry{
+			emiten: e,
+			ticker: strings.ToLower(e.Ticker),
+			name:   strings.Join(words, " "),
+			words:  words,
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ticker < entries[j].ticker })
+
+	ix.mu.Lock()
+	ix.entries = entries
+	ix.built = generation == ix.generation
+	ix.mu.Unlock()
+}
+
+// Invalidate marks the index stale after the emiten list changed
+func (ix *EmitenIndex) Invalidate() {
+	ix.mu.Lock()
+	ix.built = false
+	ix.generation++
+	ix.mu.Unlock()
+}
+
+// Generation returns a counter advanced by every Invalidate, to be captured
+// before loading the list passed to Rebuild
+func (ix *EmitenIndex) Generation() uint64 {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return ix.generation
+}
+
+// Built reports whether the index is populated and current
+func (ix *EmitenIndex) Built() bool {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return ix.built
+}
+
+// Search returns up to limit emitens matching query, best match first. Tickers
+// match by prefix; company names match case-insensitively by prefix, word
+// prefix, substring, per-word typo tolerance and finally as a subsequence.
+func (ix *EmitenIndex) Search(query string, limit int) []EmitenSearchResult {
+	words := searchWords(query)
+	if len(words) == 0 || limit <= 0 {
+		return nil
+	}
+	q := strings.Join(words, " ")
+	compact := strings.Join(words, "")
+
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	var results []EmitenSearchResult
+	for _, entry := range ix.entries {
+		score, matchedOn := entry.match(q, compact, words)
+		if score > 0 {
+			results = append(results, EmitenSearchResult{Emiten: entry.emiten, Score: score, MatchedOn: matchedOn})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+func (e *emitenIndexEntry) match(q, compact string, words []string) (int, string) {
+	switch {
+	case e.ticker == compact:
+		return scoreTickerExact, MatchTicker
+	case strings.HasPrefix(e.ticker, compact):
+		return scoreTickerPrefix - (len(e.ticker) - len(compact)), MatchTicker
+	}
+
+	if e.name == "" {
+		return 0, ""
+	}
+	if strings.HasPrefix(e.name, q) {
+		return scoreNamePrefix, MatchName
+	}
+	if len(words) == 1 {
+		for i, word := range e.words {
+			if strings.HasPrefix(word, q) {
+				return scoreWordPrefix - min(i, 99), MatchName
+			}
+		}
+	}
+	if i := strings.Index(e.name, q); i >= 0 {
+		return scoreNameContains - min(i, 99), MatchName
+	}
+	if distance, ok := e.fuzzyWords(words); ok {
+		return scoreFuzzyWords - min(distance*10, 99), MatchName
+	}
+	// Shorter subsequences match nearly every name
+	if len(compact) < minSubsequenceLength {
+		return 0, ""
+	}
+	if spread, ok := subsequence(e.name, compact); ok {
+		return scoreSubsequence - min(spread, 99), MatchName
+	}
+	return 0, ""
+}
+
+// fuzzyWords matches when every query word is a prefix of, or within a small
+// edit distance of, some name word. It returns the summed distance.
+func (e *emitenIndexEntry) fuzzyWords(words []string) (int, bool) {
+	total := 0
+	for _, qw := range words {
+		best := -1
+		for _, nw := range e.words {
+			if strings.HasPrefix(nw, qw) {
+				best = 0
+				break
+			}
+			// Compare against the name word cut to the query length so that
+			// a typo in a partially typed word still matches
+			candidate := nw
+			if len(candidate) > len(qw)+1 {
+				candidate = candidate[:len(qw)+1]
+			}
+			d := editDistance(qw, candidate)
+			if d <= maxTypos(qw) && (best < 0 || d < best) {
+				best = d
+			}
+		}
+		if best < 0 {
+			return 0, false
+		}
+		total += best
+	}
+	return total, true
+}
+
+// maxTypos is the edit distance tolerated for a query word: none for short
+// words, where a typo is indistinguishable from a different word
+func maxTypos(word string) int {
+	switch {
+	case len(word) >= 8:
+		return 2
+	case len(word) >= 4:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// subsequence reports whether the characters of q appear in order in s,
+// returning how many characters of s lie between the first and last match
+// beyond those of q
+func subsequence(s, q string) (int, bool) {
+	start, j := -1, 0
+	for i := 0; i < len(s) && j < len(q); i++ {
+		if s[i] == q[j] {
+			if start < 0 {
+				start = i
+			}
+			j++
+			if j == len(q) {
+				return i - start + 1 - len(q), true
+			}
+		}
+	}
+	return 0, false
+}
+
+// editDistance is the optimal string alignment distance between a and b:
+// insertions, deletions, substitutions and swaps of adjacent characters,
+// the usual typing mistakes, each cost one
+func editDistance(a, b string) int {
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(b)]
+}
+
+// searchWords lower-cases s and splits it into words of letters and digits,
+// dropping punctuation such as in "Bank Central Asia, Tbk."
+func searchWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
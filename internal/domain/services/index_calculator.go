@@ -0,0 +1,188 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"api-web-scrapping/internal/domain/entities"
+)
+
+// IndexWeights fixes the units of each constituent for a weighting scheme
+// using the reference prices and shares outstanding at the start of a period.
+// FreeFloat is the free-float ratio in (0, 1]; zero means fully floating.
+func IndexWeights(weighting string, constituents []entities.IndexConstituent, prices map[string]float64, shares map[string]int64) ([]entities.IndexConstituent, error) {
+	weighted := make([]entities.IndexConstituent, len(constituents))
+	for i, c := range constituents {
+		if c.FreeFloat < 0 || c.FreeFloat > 1 {
+			return nil, fmt.Errorf("free float of %s must be between 0 and 1", c.Emiten)
+		}
+		c.Weight = 1
+
+		switch weighting {
+		case entities.IndexWeightingMarketCap, entities.IndexWeightingFreeFloat:
+			if shares[c.Emiten] <= 0 {
+				return nil, fmt.Errorf("shares outstanding of %s are unknown", c.Emiten)
+			}
+			c.Weight = float64(shares[c.Emiten])
+			if weighting == entities.IndexWeightingFreeFloat && c.FreeFloat > 0 {
+				c.Weight *= c.FreeFloat
+			}
+		case entities.IndexWeightingEqual:
+			price, ok := prices[c.Emiten]
+			if !ok || price <= 0 {
+				return nil, fmt.Errorf("no price for %s", c.Emiten)
+			}
+			c.Weight = 1 / price
+		case entities.IndexWeightingPrice:
+		default:
+			return nil, fmt.Errorf("unknown weighting %q", weighting)
+		}
+		weighted[i] = c
+	}
+	return weighted, nil
+}
+
+// IndexAggregate returns the weighted sum of constituent prices
+func IndexAggregate(constituents []entities.IndexConstituent, prices map[string]float64) (float64, error) {
+	sum := 0.0
+	for _, c := range constituents {
+		price, ok := prices[c.Emiten]
+		if !ok || price <= 0 {
+			return 0, fmt.Errorf("no price for %s", c.Emiten)
+		}
+		sum += c.Weight * price
+	}
+	return sum, nil
+}
+
+// ComputeIndexCandles computes daily index candles for the sessions in
+// [from, to] from the snapshots of every constituent, which should start a
+// few sessions before from so that each constituent has a last known price.
+//
+// The open and close apply the constituents' session opens and closes. The
+// high and low are the extremes of the level recomputed at every snapshot,
+// carrying each constituent's last price forward. A constituent that did not
+// trade in a session counts at its last close; sessions in which a
+// constituent has no price at all are skipped.
+func ComputeIndexCandles(code string, periods []entities.IndexPeriod, snapshotsByEmiten map[string][]entities.MarketData, from, to time.Time) []entities.Candle {
+	if len(periods) == 0 {
+		return nil
+	}
+
+	type session struct {
+		opens  map[string]float64
+		closes map[string]float64
+		events []indexEvent
+	}
+
+	sessions := make(map[time.Time]*session)
+	for emiten, snapshots := range snapshotsByEmiten {
+		for _, md := range snapshots {
+			day := BucketStart(md.Date, entities.CandleInterval1d)
+			s, ok := sessions[day]
+			if !ok {
+				s = &session{opens: make(map[string]float64), closes: make(map[string]float64)}
+				sessions[day] = s
+			}
+			s.events = append(s.events, indexEvent{emiten: emiten, at: md.Date, price: md.ClosePrice})
+		}
+	}
+	for emiten, snapshots := range snapshotsByEmiten {
+		for _, c := range AggregateCandles(snapshots, entities.CandleInterval1d) {
+			sessions[c.Time].opens[emiten] = c.Open
+			sessions[c.Time].closes[emiten] = c.Close
+		}
+	}
+
+	days := make([]time.Time, 0, len(sessions))
+	for day := range sessions {
+		days = append(days, day)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+
+	lastClose := make(map[string]float64)
+	var result []entities.Candle
+
+	for _, day := range days {
+		s := sessions[day]
+		period := periodAt(periods, day)
+
+		if period != nil && !day.Before(from) && !day.After(to) {
+			if c, ok := sessionCandle(period, s.opens, lastClose, s.events); ok {
+				c.Emiten = code
+				c.Time = day
+				result = append(result, c)
+			}
+		}
+
+		for emiten, price := range s.closes {
+			lastClose[emiten] = price
+		}
+	}
+
+	return result
+}
+
+// indexEvent is a constituent price observed at a snapshot
+type indexEvent struct {
+	emiten string
+	at     time.Time
+	price  float64
+}
+
+// sessionCandle computes one session of an index period, reporting false
+// when a constituent has no price
+func sessionCandle(period *entities.IndexPeriod, opens, lastClose map[string]float64, events []indexEvent) (entities.Candle, bool) {
+	weights := make(map[string]float64, len(period.Constituents))
+	prices := make(map[string]float64, len(period.Constituents))
+	for _, c := range period.Constituents {
+		weights[c.Emiten] = c.Weight
+		if open, ok := opens[c.Emiten]; ok {
+			prices[c.Emiten] = open
+		} else if last, ok := lastClose[c.Emiten]; ok {
+			prices[c.Emiten] = last
+		}
+	}
+
+	aggregate, err := IndexAggregate(period.Constituents, prices)
+	if err != nil {
+		return entities.Candle{}, false
+	}
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].at.Before(events[j].at) })
+
+	open := aggregate / period.Divisor
+	c := entities.Candle{Interval: entities.CandleInterval1d, Open: open, High: open, Low: open}
+	for _, e := range events {
+		w, ok := weights[e.emiten]
+		if !ok {
+			continue
+		}
+		aggregate += w * (e.price - prices[e.emiten])
+		prices[e.emiten] = e.price
+
+		level := aggregate / period.Divisor
+		c.High = math.Max(c.High, level)
+		c.Low = math.Min(c.Low, level)
+		c.SampleCount++
+	}
+	c.Close = aggregate / period.Divisor
+
+	return c, true
+}
+
+// periodAt returns the period in effect on day, or nil before the first one.
+// periods must be ordered by EffectiveFrom.
+func periodAt(periods []entities.IndexPeriod, day time.Time) *entities.IndexPeriod {
+	date := CalendarDate(day)
+	var current *entities.IndexPeriod
+	for i := range periods {
+		if CalendarDate(periods[i].EffectiveFrom).After(date) {
+			break
+		}
+		current = &periods[i]
+	}
+	return current
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"math"
+
+	"api-web-scrapping/internal/domain/entities"
+)
+
+// OrderBookMetrics are derived from the depth of an order book. Spread and
+// mid price need a level on both sides and are nil otherwise; a crossed book,
+// as seen during call auctions, has a negative spread.
+type OrderBookMetrics struct {
+	Spread      *float64
+	SpreadPct   *float64
+	SpreadTicks *int
+	MidPrice    *float64
+	// Depth is the number of levels per side summed into the lot totals
+	Depth     int
+	BidLots   int64
+	OfferLots int64
+	// Imbalance is (bid lots − offer lots) ÷ (bid lots + offer lots), from −1
+	// (offers only) to 1 (bids only)
+	Imbalance *float64
+}
+
+// maxSpreadTicks bounds the tick count of a spread; wider spreads leave
+// SpreadTicks nil
+const maxSpreadTicks = 1000
+
+// AnalyzeOrderBook computes the spread, mid price and bid/offer imbalance of
+// a snapshot. The imbalance covers the best depth levels of each side, or
+// every level when depth is not positive. SpreadTicks counts the valid
+// prices stepped over from the best bid to the best offer, so a spread
+// across a price band boundary is counted in the ticks of both bands.
+func AnalyzeOrderBook(book *entities.OrderBookSnapshot, depth int) OrderBookMetrics {
+	var metrics OrderBookMetrics
+
+	if bid, offer := book.BestBid(), book.BestOffer(); bid != nil && offer != nil {
+		spread := offer.Price - bid.Price
+		mid := (offer.Price + bid.Price) / 2
+		spreadPct := spread / mid * 100
+		metrics.Spread = &spread
+		metrics.MidPrice = &mid
+		metrics.SpreadPct = &spreadPct
+		metrics.SpreadTicks = spreadTicks(bid.Price, offer.Price)
+	}
+
+	metrics.Depth = depth
+	if depth <= 0 {
+		metrics.Depth = int(math.Max(float64(len(book.Bids)), float64(len(book.Offers))))
+	}
+	metrics.BidLots = sumOrderBookLots(book.Bids, metrics.Depth)
+	metrics.OfferLots = sumOrderBookLots(book.Offers, metrics.Depth)
+	if total := metrics.BidLots + metrics.OfferLots; total > 0 {
+		imbalance := float64(metrics.BidLots-metrics.OfferLots) / float64(total)
+		metrics.Imbalance = &imbalance
+	}
+
+	return metrics
+}
+
+// spreadTicks returns the number of ticks from bid to offer, negative for a
+// crossed book
+func spreadTicks(bid, offer float64) *int {
+	low, high, sign := bid, offer, 1
+	if offer < bid {
+		low, high, sign = offer, bid, -1
+	}
+	ticks, complete := TicksBetween(low, high, maxSpreadTicks+1)
+	if !complete || len(ticks) == 0 {
+		return nil
+	}
+	n := sign * (len(ticks) - 1)
+	return &n
+}
+
+func sumOrderBookLots(levels []entities.OrderBookLevel, depth int) int64 {
+	var lots int64
+	for i, level := range levels {
+		if i >= depth {
+			break
+		}
+		lots += level.Lots
+	}
+	return lots
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"api-web-scrapping/internal/domain/entities"
+)
+
+// RebasedSeries holds several close series on a shared grid of sessions,
+// each scaled so that it equals the base value on the first session
+type RebasedSeries struct {
+	Dates  []time.Time
+	Values [][]float64
+	// Closes are the unscaled closes behind Values
+	Closes [][]float64
+}
+
+// Rebase aligns the daily closes of several series and scales each to base
+// from the first session on which all of them have a close, so the series
+// start together. A series that did not trade in a later session carries its
+// last close forward. It reports false when no session has a close for every
+// series.
+func Rebase(series [][]entities.Candle, base float64) (RebasedSeries, bool) {
+	closesByDay := make(map[time.Time][]float64)
+	for i, candles := range series {
+		for _, c := range candles {
+			row, ok := closesByDay[c.Time]
+			if !ok {
+				row = make([]float64, len(series))
+				for j := range row {
+					row[j] = math.NaN()
+				}
+				closesByDay[c.Time] = row
+			}
+			if c.Close > 0 {
+				row[i] = c.Close
+			}
+		}
+	}
+
+	grid := make([]time.Time, 0, len(closesByDay))
+	for day := range closesByDay {
+		grid = append(grid, day)
+	}
+	sort.Slice(grid, func(i, j int) bool { return grid[i].Before(grid[j]) })
+
+	start := -1
+	for t, day := range grid {
+		if complete(closesByDay[day]) {
+			start = t
+			break
+		}
+	}
+	if start < 0 {
+		return RebasedSeries{}, false
+	}
+
+	rebased := RebasedSeries{
+		Dates:  grid[start:],
+		Values: make([][]float64, len(series)),
+		Closes: make([][]float64, len(series)),
+	}
+	first := closesByDay[grid[start]]
+	for i := range series {
+		values := make([]float64, len(rebased.Dates))
+		closes := make([]float64, len(rebased.Dates))
+		last := first[i]
+		for t, day := range rebased.Dates {
+			if c := closesByDay[day][i]; !math.IsNaN(c) {
+				last = c
+			}
+			closes[t] = last
+			values[t] = base * last / first[i]
+		}
+		rebased.Values[i] = values
+		rebased.Closes[i] = closes
+	}
+	return rebased, true
+}
+
+// CAGR returns the compound annual growth rate that turns start into end
+// between two dates, as a fraction
+func CAGR(start, end float64, from, to time.Time) float64 {
+	years := to.Sub(from).Hours() / 24 / 365.25
+	if start <= 0 || years <= 0 {
+		return math.NaN()
+	}
+	return math.Pow(end/start, 1/years) - 1
+}
+
+func complete(values []float64) bool {
+	for _, v := range values {
+		if math.IsNaN(v) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"math"
+	"time"
+
+	"api-web-scrapping/internal/domain/entities"
+)
+
+// PriceAdjustment scales prices of sessions before ExDate, a calendar date,
+// by Factor so they are comparable with prices from ExDate on
+type PriceAdjustment struct {
+	ExDate time.Time
+	Factor float64
+}
+
+// AdjustmentFactor returns the backward price factor of a corporate action.
+// Rights issues and cash dividends depend on cumClose, the close of the last
+// session before the ex-date; they report false when it is unknown, when a
+// dividend is not below it, or when the rights are worthless because their
+// exercise price is not below it.
+func AdjustmentFactor(action entities.CorporateAction, cumClose float64) (float64, bool) {
+	switch action.Type {
+	case entities.CorporateActionSplit, entities.CorporateActionReverseSplit:
+		if action.RatioFrom > 0 && action.RatioTo > 0 {
+			return action.RatioFrom / action.RatioTo, true
+		}
+	case entities.CorporateActionBonus:
+		if action.RatioFrom > 0 && action.RatioTo > 0 {
+			return action.RatioFrom / (action.RatioFrom + action.RatioTo), true
+		}
+	case entities.CorporateActionRights:
+		// The theoretical ex-rights price blends the held shares at the cum
+		// price with the new ones at the exercise price
+		if cumClose > 0 && action.Price > 0 && action.Price < cumClose {
+			terp := (action.RatioFrom*cumClose + action.RatioTo*action.Price) / (action.RatioFrom + action.RatioTo)
+			return terp / cumClose, true
+		}
+	case entities.CorporateActionCashDividend:
+		if cumClose > 0 && action.Amount > 0 && action.Amount < cumClose {
+			return (cumClose - action.Amount) / cumClose, true
+		}
+	}
+	return 0, false
+}
+
+// NeedsCumClose reports whether the factor of an action depends on the price
+// before its ex-date
+func NeedsCumClose(action entities.CorporateAction) bool {
+	return action.Type == entities.CorporateActionRights || action.Type == entities.CorporateActionCashDividend
+}
+
+// PriceAdjustments are the adjustments of one emiten
+type PriceAdjustments []PriceAdjustment
+
+// FactorAt returns the cumulative factor of the session of t: the product of
+// the factors of every adjustment with an ex-date after that session
+func (a PriceAdjustments) FactorAt(t time.Time) float64 {
+	day := CalendarDate(t)
+	factor := 1.0
+	for _, adj := range a {
+		if day.Before(adj.ExDate) {
+			factor *= adj.Factor
+		}
+	}
+	return factor
+}
+
+// AdjustMarketData returns copies of snapshots with their OHLC prices
+// scaled by the cumulative factor of their session and their volume divided
+// by it, so the traded value is unchanged
+func AdjustMarketData(data []entities.MarketData, adjustments PriceAdjustments) []entities.MarketData {
+	adjusted := make([]entities.MarketData, len(data))
+	for i, md := range data {
+		factor := adjustments.FactorAt(md.Date)
+		md.OpenPrice *= factor
+		md.HighPrice *= factor
+		md.LowPrice *= factor
+		md.ClosePrice *= factor
+		md.Volume = AdjustVolume(md.Volume, factor)
+		adjusted[i] = md
+	}
+	return adjusted
+}
+
+// AdjustCandles returns copies of daily candles with their OHLC prices
+// scaled by the cumulative factor of their session and their volume divided
+// by it
+func AdjustCandles(candles []entities.Candle, adjustments PriceAdjustments) []entities.Candle {
+	adjusted := make([]entities.Candle, len(candles))
+	for i, c := range candles {
+		factor := adjustments.FactorAt(c.Time)
+		c.Open *= factor
+		c.High *= factor
+		c.Low *= factor
+		c.Close *= factor
+		c.Volume /= factor
+		adjusted[i] = c
+	}
+	return adjusted
+}
+
+// AdjustVolume divides a share volume by a price factor, rounded to whole
+// shares. It returns nil without a volume.
+func AdjustVolume(volume *int64, factor float64) *int64 {
+	if volume == nil {
+		return nil
+	}
+	adjusted := int64(math.Round(float64(*volume) / factor))
+	return &adjusted
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"math"
+	"time"
+
+	"api-web-scrapping/internal/domain/entities"
+)
+
+// SummaryHighLowWindow is the look-back of the 52-week high and low
+const SummaryHighLowWindow = 52 * 7 * 24 * time.Hour
+
+// SummaryAveragePeriods are the sessions of the average closes in a summary
+var SummaryAveragePeriods = []int{20, 50, 200}
+
+// SummaryHistorySessions is the number of sessions a summary needs besides
+// the 52-week window, for the longest average close
+const SummaryHistorySessions = 200
+
+// PriceLevel is a price and the session in which it was reached. A zero
+// Date means the level is unknown.
+type PriceLevel struct {
+	Price float64
+	Date  time.Time
+}
+
+// PriceSummary describes the last session of an emiten against its history.
+// Averages are keyed by period and NaN when there are fewer sessions;
+// YTDBase is the last close of the previous year, NaN when the history does
+// not reach back that far.
+type PriceSummary struct {
+	Date        time.Time
+	Close       float64
+	High52w     PriceLevel
+	Low52w      PriceLevel
+	Averages    map[int]float64
+	AllTimeHigh PriceLevel
+	YTDBase     float64
+}
+
+// ComputePriceSummary summarizes daily candles, oldest first, as of the last
+// one. The candles need to cover 52 weeks and 200 sessions; earlierHigh is
+// the highest high of any history before them, so that the all-time high
+// does not require loading every session. It reports false without candles.
+func ComputePriceSummary(candles []entities.Candle, earlierHigh PriceLevel) (PriceSummary, bool) {
+	if len(candles) == 0 {
+		return PriceSummary{}, false
+	}
+	last := candles[len(candles)-1]
+
+	summary := PriceSummary{
+		Date:        last.Time,
+		Close:       last.Close,
+		High52w:     PriceLevel{Price: last.High, Date: last.Time},
+		Low52w:      PriceLevel{Price: last.Low, Date: last.Time},
+		Averages:    make(map[int]float64, len(SummaryAveragePeriods)),
+		AllTimeHigh: earlierHigh,
+		YTDBase:     math.NaN(),
+	}
+
+	windowStart := last.Time.Add(-SummaryHighLowWindow)
+	yearStart := time.Date(last.Time.In(WIB).Year(), time.January, 1, 0, 0, 0, 0, WIB)
+	for _, c := range candles {
+		if c.High > summary.AllTimeHigh.Price {
+			summary.AllTimeHigh = PriceLevel{Price: c.High, Date: c.Time}
+		}
+		if c.Time.Before(yearStart) {
+			summary.YTDBase = c.Close
+		}
+		if c.Time.Before(windowStart) {
+			continue
+		}
+		// Ties keep the most recent session
+		if c.High >= summary.High52w.Price {
+			summary.High52w = PriceLevel{Price: c.High, Date: c.Time}
+		}
+		if c.Low <= summary.Low52w.Price {
+			summary.Low52w = PriceLevel{Price: c.Low, Date: c.Time}
+		}
+	}
+
+	for _, period := range SummaryAveragePeriods {
+		summary.Averages[period] = math.NaN()
+		if len(candles) < period {
+			continue
+		}
+		sum := 0.0
+		for _, c := range candles[len(candles)-period:] {
+			sum += c.Close
+		}
+		summary.Averages[period] = sum / float64(period)
+	}
+
+	return summary, true
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"api-web-scrapping/internal/domain/entities"
+)
+
+// AlignedReturns holds daily log returns of several series on a shared grid
+// of sessions. Returns[i][t] is the return of series i into Dates[t], or NaN
+// when the series did not trade in that session or the one before it.
+type AlignedReturns struct {
+	Dates   []time.Time
+	Returns [][]float64
+}
+
+// AlignLogReturns puts the daily candles of several series on the union of
+// their sessions and computes log returns between consecutive sessions of
+// that grid. Missing sessions are not filled forward, so a return spanning a
+// gap is dropped rather than attributed to a single day.
+func AlignLogReturns(series [][]entities.Candle) AlignedReturns {
+	seen := make(map[time.Time]bool)
+	for _, candles := range series {
+		for _, c := range candles {
+			seen[c.Time] = true
+		}
+	}
+	grid := make([]time.Time, 0, len(seen))
+	for day := range seen {
+		grid = append(grid, day)
+	}
+	sort.Slice(grid, func(i, j int) bool { return grid[i].Before(grid[j]) })
+
+	position := make(map[time.Time]int, len(grid))
+	for i, day := range grid {
+		position[day] = i
+	}
+
+	aligned := AlignedReturns{Returns: make([][]float64, len(series))}
+	if len(grid) > 1 {
+		aligned.Dates = grid[1:]
+	}
+
+	for i, candles := range series {
+		closes := make([]float64, len(grid))
+		for t := range closes {
+			closes[t] = math.NaN()
+		}
+		for _, c := range candles {
+			if c.Close > 0 {
+				closes[position[c.Time]] = c.Close
+			}
+		}
+
+		returns := make([]float64, len(aligned.Dates))
+		for t := range returns {
+			// NaN propagates when either close is missing
+			returns[t] = math.Log(closes[t+1] / closes[t])
+		}
+		aligned.Returns[i] = returns
+	}
+	return aligned
+}
+
+// Tail keeps the last n sessions of the grid
+func (a AlignedReturns) Tail(n int) AlignedReturns {
+	if n >= len(a.Dates) {
+		return a
+	}
+	start := len(a.Dates) - n
+	tail := AlignedReturns{Dates: a.Dates[start:], Returns: make([][]float64, len(a.Returns))}
+	for i, r := range a.Returns {
+		tail.Returns[i] = r[start:]
+	}
+	return tail
+}
+
+// PairwiseComplete returns the observations of series i and j on the
+// sessions where both have a return
+func (a AlignedReturns) PairwiseComplete(i, j int) (x, y []float64) {
+	for t := range a.Dates {
+		ri, rj := a.Returns[i][t], a.Returns[j][t]
+		if math.IsNaN(ri) || math.IsNaN(rj) {
+			continue
+		}
+		x = append(x, ri)
+		y = append(y, rj)
+	}
+	return x, y
+}
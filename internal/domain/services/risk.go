@@ -0,0 +1,100 @@
+package services
+
+import (
+	"math"
+	"time"
+
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/pkg/stats"
+)
+
+// TradingSessionsPerYear annualizes daily volatility
+const TradingSessionsPerYear = 252
+
+// Drawdown is the largest peak-to-trough fall of a close series. Depth is
+// the fall as a fraction of the peak close.
+type Drawdown struct {
+	Depth       float64
+	Peak        time.Time
+	PeakClose   float64
+	Trough      time.Time
+	TroughClose float64
+}
+
+// LogReturns returns the log returns between consecutive candles
+func LogReturns(candles []entities.Candle) []float64 {
+	if len(candles) < 2 {
+		return nil
+	}
+	returns := make([]float64, len(candles)-1)
+	for i := 1; i < len(candles); i++ {
+		returns[i-1] = math.Log(candles[i].Close / candles[i-1].Close)
+	}
+	return returns
+}
+
+// CloseToCloseVolatility returns the annualized sample standard deviation of
+// daily log returns
+func CloseToCloseVolatility(candles []entities.Candle) float64 {
+	return stats.StdDev(LogReturns(candles)) * math.Sqrt(TradingSessionsPerYear)
+}
+
+// ParkinsonVolatility returns the annualized Parkinson estimator, which uses
+// each session's high/low range instead of closes and so also captures
+// intraday swings that close near where they opened
+func ParkinsonVolatility(candles []entities.Candle) float64 {
+	if len(candles) == 0 {
+		return math.NaN()
+	}
+	sum := 0.0
+	for _, c := range candles {
+		if c.Low <= 0 {
+			return math.NaN()
+		}
+		r := math.Log(c.High / c.Low)
+		sum += r * r
+	}
+	variance := sum / (4 * math.Ln2 * float64(len(candles)))
+	return math.Sqrt(variance * TradingSessionsPerYear)
+}
+
+// AnnualizedDownsideDeviation returns the annualized deviation of daily log
+// returns below zero
+func AnnualizedDownsideDeviation(candles []entities.Candle) float64 {
+	return stats.DownsideDeviation(LogReturns(candles), 0) * math.Sqrt(TradingSessionsPerYear)
+}
+
+// MaxDrawdown finds the largest fall from a running peak close to a later
+// close. It reports false when the closes never fall below a prior peak.
+func MaxDrawdown(candles []entities.Candle) (Drawdown, bool) {
+	var worst Drawdown
+	found := false
+	peak := 0
+	for i, c := range candles {
+		if c.Close > candles[peak].Close {
+			peak = i
+			continue
+		}
+		depth := 1 - c.Close/candles[peak].Close
+		if depth > worst.Depth {
+			worst = Drawdown{
+				Depth:       depth,
+				Peak:        candles[peak].Time,
+				PeakClose:   candles[peak].Close,
+				Trough:      c.Time,
+				TroughClose: c.Close,
+			}
+			found = true
+		}
+	}
+	return worst, found
+}
+
+// Beta measures how the daily log returns of an asset move with those of a
+// benchmark over their last window sessions, using only the sessions where
+// both have a return. It also returns the number of such sessions.
+func Beta(asset, benchmark []entities.Candle, window int) (float64, int) {
+	aligned := AlignLogReturns([][]entities.Candle{asset, benchmark}).Tail(window)
+	x, y := aligned.PairwiseComplete(0, 1)
+	return stats.Beta(x, y), len(x)
+}
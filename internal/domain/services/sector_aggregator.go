@@ -0,0 +1,132 @@
+package services
+
+import (
+	"math"
+	"sort"
+)
+
+// UnclassifiedSector groups emitens without a sector in their master data
+const UnclassifiedSector = "Unclassified"
+
+// SectorQuote is the latest and previous close of an emiten with the data
+// needed to weight it within its sector
+type SectorQuote struct {
+	Emiten            string
+	Sector            string
+	Close             float64
+	PrevClose         float64
+	SharesOutstanding int64
+}
+
+// SectorContribution is how much an emiten moved its sector, in percentage
+// points of the market-cap-weighted change
+type SectorContribution struct {
+	Emiten       string
+	ChangePct    float64
+	Contribution float64
+}
+
+// SectorSummary aggregates the quotes of one sector. Change percentages are
+// NaN when no emiten in the sector has a previous close (or, for the
+// market-cap-weighted change, shares outstanding).
+type SectorSummary struct {
+	Sector                 string
+	EmitenCount            int
+	Advancers              int
+	Decliners              int
+	Unchanged              int
+	MarketCap              float64
+	CapWeightedChangePct   float64
+	EqualWeightedChangePct float64
+	TopContributor         *SectorContribution
+}
+
+// AggregateSectors summarizes quotes per sector, ordered by market cap
+// descending. The market-cap-weighted change weights each emiten by its
+// previous market cap, so it equals the change of the sector's total value.
+// Quotes without a previous close count towards EmitenCount only.
+func AggregateSectors(quotes []SectorQuote) []SectorSummary {
+	type accumulator struct {
+		summary    SectorSummary
+		prevCap    float64
+		changeSum  float64
+		changeN    int
+		candidates []SectorContribution
+	}
+
+	bySector := make(map[string]*accumulator)
+	for _, q := range quotes {
+		sector := q.Sector
+		if sector == "" {
+			sector = UnclassifiedSector
+		}
+		acc, ok := bySector[sector]
+		if !ok {
+			acc = &accumulator{summary: SectorSummary{Sector: sector}}
+			bySector[sector] = acc
+		}
+
+		acc.summary.EmitenCount++
+		acc.summary.MarketCap += q.Close * float64(q.SharesOutstanding)
+
+		if q.PrevClose <= 0 || math.IsNaN(q.PrevClose) {
+			continue
+		}
+		switch {
+		case q.Close > q.PrevClose:
+			acc.summary.Advancers++
+		case q.Close < q.PrevClose:
+			acc.summary.Decliners++
+		default:
+			acc.summary.Unchanged++
+		}
+
+		change := (q.Close - q.PrevClose) / q.PrevClose * 100
+		acc.changeSum += change
+		acc.changeN++
+
+		if q.SharesOutstanding > 0 {
+			prevCap := q.PrevClose * float64(q.SharesOutstanding)
+			acc.prevCap += prevCap
+			// Contribution is scaled by the sector's total once it is known
+			acc.candidates = append(acc.candidates, SectorContribution{
+				Emiten:       q.Emiten,
+				ChangePct:    change,
+				Contribution: prevCap * change,
+			})
+		}
+	}
+
+	summaries := make([]SectorSummary, 0, len(bySector))
+	for _, acc := range bySector {
+		s := acc.summary
+		s.CapWeightedChangePct = math.NaN()
+		s.EqualWeightedChangePct = math.NaN()
+
+		if acc.changeN > 0 {
+			s.EqualWeightedChangePct = acc.changeSum / float64(acc.changeN)
+		}
+		if acc.prevCap > 0 {
+			weighted := 0.0
+			for i := range acc.candidates {
+				c := &acc.candidates[i]
+				c.Contribution /= acc.prevCap
+				weighted += c.Contribution
+				if s.TopContributor == nil || math.Abs(c.Contribution) > math.Abs(s.TopContributor.Contribution) {
+					s.TopContributor = c
+				}
+			}
+			s.CapWeightedChangePct = weighted
+		}
+
+		summaries = append(summaries, s)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].MarketCap != summaries[j].MarketCap {
+			return summaries[i].MarketCap > summaries[j].MarketCap
+		}
+		return summaries[i].Sector < summaries[j].Sector
+	})
+	return summaries
+}
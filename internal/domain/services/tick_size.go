@@ -0,0 +1,147 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"api-web-scrapping/internal/domain/entities"
+)
+
+// TickBand is a price band starting at From, inclusive, in which prices move
+// in multiples of Tick
+type TickBand struct {
+	From float64
+	Tick float64
+}
+
+// IDXTickBands are the price fractions of the IDX regular market, ordered by
+// price. Every band starts on a multiple of its own tick, so rounding with
+// the tick of the band below never skips a valid price.
+var IDXTickBands = []TickBand{
+	{From: 0, Tick: 1},
+	{From: 200, Tick: 2},
+	{From: 500, Tick: 5},
+	{From: 2000, Tick: 10},
+	{From: 5000, Tick: 25},
+}
+
+// TickRounding is the direction in which a price is rounded to a valid tick
+type TickRounding string
+
+const (
+	TickRoundNearest TickRounding = "nearest"
+	TickRoundDown    TickRounding = "down"
+	TickRoundUp      TickRounding = "up"
+)
+
+// ParseTickRounding validates a rounding mode, defaulting to nearest
+func ParseTickRounding(value string) (TickRounding, error) {
+	switch mode := TickRounding(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "":
+		return TickRoundNearest, nil
+	case TickRoundNearest, TickRoundDown, TickRoundUp:
+		return mode, nil
+	}
+	return "", fmt.Errorf("unsupported rounding mode %q", value)
+}
+
+// tickEpsilon absorbs the binary error of prices read from DECIMAL columns
+const tickEpsilon = 1e-6
+
+// TickSize returns the price fraction of the band containing price
+func TickSize(price float64) float64 {
+	tick := IDXTickBands[0].Tick
+	for _, b := range IDXTickBands {
+		if price+tickEpsilon < b.From {
+			break
+		}
+		tick = b.Tick
+	}
+	return tick
+}
+
+// IsValidTick reports whether price is positive and a multiple of the tick
+// of its band
+func IsValidTick(price float64) bool {
+	if !(price > 0) {
+		return false
+	}
+	steps := price / TickSize(price)
+	return math.Abs(steps-math.Round(steps)) < tickEpsilon
+}
+
+// RoundToTick rounds price to a valid tick. Rounding up from the top of a
+// band lands on the start of the next one; nearest breaks ties upwards. The
+// result is never below the lowest tick.
+func RoundToTick(price float64, mode TickRounding) float64 {
+	minimum := IDXTickBands[0].Tick
+	if price <= minimum {
+		return minimum
+	}
+
+	tick := TickSize(price)
+	down := math.Floor(price/tick+tickEpsilon) * tick
+	up := math.Ceil(price/tick-tickEpsilon) * tick
+
+	switch mode {
+	case TickRoundDown:
+		return math.Max(down, minimum)
+	case TickRoundUp:
+		return up
+	}
+	if up-price <= price-down {
+		return up
+	}
+	return math.Max(down, minimum)
+}
+
+// TicksBetween lists the valid ticks in [from, to], ascending. It stops
+// after limit ticks and then reports false.
+func TicksBetween(from, to float64, limit int) ([]float64, bool) {
+	ticks := []float64{}
+	if to < from {
+		return ticks, true
+	}
+	for price := RoundToTick(from, TickRoundUp); price <= to+tickEpsilon; price += TickSize(price) {
+		if len(ticks) == limit {
+			return ticks, false
+		}
+		ticks = append(ticks, price)
+	}
+	return ticks, true
+}
+
+// TickViolation is an OHLC price of a snapshot that is off the tick grid
+type TickViolation struct {
+	Field string
+	Price float64
+	Tick  float64
+}
+
+func (v TickViolation) String() string {
+	return fmt.Sprintf("%s %g is not a multiple of %g", v.Field, v.Price, v.Tick)
+}
+
+// ValidateTicks checks the OHLC prices of a snapshot against the tick table.
+// A zero price is not a violation: the scraper reports it for the prices of an
+// emiten that has not traded in the session.
+func ValidateTicks(md entities.MarketData) []TickViolation {
+	prices := []struct {
+		field string
+		price float64
+	}{
+		{"open_price", md.OpenPrice},
+		{"high_price", md.HighPrice},
+		{"low_price", md.LowPrice},
+		{"close_price", md.ClosePrice},
+	}
+
+	var violations []TickViolation
+	for _, p := range prices {
+		if p.price != 0 && !IsValidTick(p.price) {
+			violations = append(violations, TickViolation{Field: p.field, Price: p.price, Tick: TickSize(p.price)})
+		}
+	}
+	return violations
+}
@@ -10,9 +10,13 @@ import (
 )
 
 type Config struct {
-	Server   ServerConfig
-	Auth     AuthConfig
-	Database DatabaseConfig
+	Server    ServerConfig
+	Auth      AuthConfig
+	Database  DatabaseConfig
+	Candle    CandleConfig
+	Screen    ScreenConfig
+	Market    MarketConfig
+	OrderBook OrderBookConfig
 }
 
 type ServerConfig struct {
@@ -22,6 +26,7 @@ type ServerConfig struct {
 type AuthConfig struct {
 	JWTSecret     string
 	TokenDuration time.Duration
+	AdminEmails   []string
 }
 
 type DatabaseConfig struct {
@@ -32,6 +37,30 @@ type DatabaseConfig struct {
 	Database string
 }
 
+type CandleConfig struct {
+	RollupEnabled  bool
+	RollupInterval time.Duration
+}
+
+type ScreenConfig struct {
+	SchedulerEnabled  bool
+	SchedulerInterval time.Duration
+}
+
+type MarketConfig struct {
+	// HolidaysFile is the JSON list of IDX holidays for the trading calendar
+	HolidaysFile string
+	// AutoRejectionFile optionally overrides the auto-rejection tiers per board
+	AutoRejectionFile string
+}
+
+type OrderBookConfig struct {
+	// Retention is how long depth snapshots are kept before being pruned
+	Retention     time.Duration
+	PruneEnabled  bool
+	PruneInterval time.Duration
+}
+
 func LoadConfig() *Config {
 	loadEnvFile()
 
@@ -50,6 +79,7 @@ func LoadConfig() *Config {
 		Auth: AuthConfig{
 			JWTSecret:     getEnv("JWT_SECRET", "your-secret-key-change-in-production"),
 			TokenDuration: 24 * time.Hour,
+			AdminEmails:   getEnvList("ADMIN_EMAILS"),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -58,6 +88,23 @@ func LoadConfig() *Config {
 			Password: getEnv("DB_PASSWORD", "secret"),
 			Database: getEnv("DB_NAME", "api_web_scrapping"),
 		},
+		Candle: CandleConfig{
+			RollupEnabled:  getEnvBool("CANDLE_ROLLUP_ENABLED", false),
+			RollupInterval: getEnvDuration("CANDLE_ROLLUP_INTERVAL", 5*time.Minute),
+		},
+		Screen: ScreenConfig{
+			SchedulerEnabled:  getEnvBool("SCREEN_SCHEDULER_ENABLED", false),
+			SchedulerInterval: getEnvDuration("SCREEN_SCHEDULER_INTERVAL", time.Minute),
+		},
+		Market: MarketConfig{
+			HolidaysFile:      getEnv("MARKET_HOLIDAYS_FILE", "configs/idx_holidays.json"),
+			AutoRejectionFile: getEnv("MARKET_AUTO_REJECTION_FILE", ""),
+		},
+		OrderBook: OrderBookConfig{
+			Retention:     getEnvDuration("ORDER_BOOK_RETENTION", 7*24*time.Hour),
+			PruneEnabled:  getEnvBool("ORDER_BOOK_PRUNE_ENABLED", true),
+			PruneInterval: getEnvDuration("ORDER_BOOK_PRUNE_INTERVAL", time.Hour),
+		},
 	}
 }
 
@@ -68,6 +115,30 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+func getEnvBool(key string, defaultValue bool) bool {
+	if value, err := strconv.ParseBool(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(key)); err == nil && value > 0 {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 func loadEnvFile() {
 	file, err := os.Open(".env")
 	if err != nil {
@@ -0,0 +1,137 @@
+package persistence
+
+import (
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/repositories"
+	"database/sql"
+	"time"
+)
+
+type brokerSummaryRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewBrokerSummaryRepository creates a new broker summary repository
+func NewBrokerSummaryRepository(db *sql.DB) repositories.BrokerSummaryRepository {
+	return &brokerSummaryRepositoryImpl{db: db}
+}
+
+const brokerSummaryColumns = `id, emiten, trade_date, broker_code, buy_lots, sell_lots, buy_value, sell_value, created_at, updated_at`
+
+// Upsert inserts or updates summaries in a single transaction
+func (r *brokerSummaryRepositoryImpl) Upsert(summaries []entities.BrokerSummary) error {
+	if len(summaries) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO broker_summaries (emiten, trade_date, broker_code, buy_lots, sell_lots, buy_value, sell_value, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			buy_lots = VALUES(buy_lots),
+			sell_lots = VALUES(sell_lots),
+			buy_value = VALUES(buy_value),
+			sell_value = VALUES(sell_value),
+			updated_at = VALUES(updated_at)
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, b := range summaries {
+		_, err := stmt.Exec(b.Emiten, b.Date, b.BrokerCode, b.BuyLots, b.SellLots, b.BuyValue, b.SellValue, b.CreatedAt, b.UpdatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// FindByEmiten retrieves the summaries of an emiten within a date range
+func (r *brokerSummaryRepositoryImpl) FindByEmiten(emiten string, from, to time.Time) ([]entities.BrokerSummary, error) {
+	rows, err := r.db.Query(`
+		SELECT `+brokerSummaryColumns+`
+		FROM broker_summaries
+		WHERE emiten = ? AND trade_date BETWEEN ? AND ?
+		ORDER BY trade_date, broker_code
+	`, emiten, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanBrokerSummaryRows(rows)
+}
+
+// FindByBroker retrieves the summaries of a broker within a date range
+func (r *brokerSummaryRepositoryImpl) FindByBroker(brokerCode string, from, to time.Time) ([]entities.BrokerSummary, error) {
+	rows, err := r.db.Query(`
+		SELECT `+brokerSummaryColumns+`
+		FROM broker_summaries
+		WHERE broker_code = ? AND trade_date BETWEEN ? AND ?
+		ORDER BY trade_date, emiten
+	`, brokerCode, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanBrokerSummaryRows(rows)
+}
+
+// LatestDate returns the most recent trade date
+func (r *brokerSummaryRepositoryImpl) LatestDate() (*time.Time, error) {
+	var latest sql.NullTime
+	if err := r.db.QueryRow(`SELECT MAX(trade_date) FROM broker_summaries`).Scan(&latest); err != nil {
+		return nil, err
+	}
+	if !latest.Valid {
+		return nil, nil
+	}
+	return &latest.Time, nil
+}
+
+// LatestDateByEmiten returns the most recent trade date of an emiten
+func (r *brokerSummaryRepositoryImpl) LatestDateByEmiten(emiten string) (*time.Time, error) {
+	var latest sql.NullTime
+	if err := r.db.QueryRow(`SELECT MAX(trade_date) FROM broker_summaries WHERE emiten = ?`, emiten).Scan(&latest); err != nil {
+		return nil, err
+	}
+	if !latest.Valid {
+		return nil, nil
+	}
+	return &latest.Time, nil
+}
+
+func scanBrokerSummaryRows(rows *sql.Rows) ([]entities.BrokerSummary, error) {
+	var summaries []entities.BrokerSummary
+	for rows.Next() {
+		var b entities.BrokerSummary
+		err := rows.Scan(
+			&b.ID,
+			&b.Emiten,
+			&b.Date,
+			&b.BrokerCode,
+			&b.BuyLots,
+			&b.SellLots,
+			&b.BuyValue,
+			&b.SellValue,
+			&b.CreatedAt,
+			&b.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, b)
+	}
+
+	return summaries, rows.Err()
+}
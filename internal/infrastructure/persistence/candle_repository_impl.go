@@ -0,0 +1,94 @@
+package persistence
+
+import (
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/repositories"
+	"database/sql"
+	"time"
+)
+
+type candleRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewCandleRepository creates a new candle rollup repository
+func NewCandleRepository(db *sql.DB) repositories.CandleRepository {
+	return &candleRepositoryImpl{db: db}
+}
+
+// SaveCandles upserts candles into the rollup table in a single transaction
+func (r *candleRepositoryImpl) SaveCandles(candles []entities.Candle) error {
+	if len(candles) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO market_data_candles
+			(emiten, interval_code, bucket_start, open_price, high_price, low_price, close_price, volume, sample_count)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			open_price = VALUES(open_price),
+			high_price = VALUES(high_price),
+			low_price = VALUES(low_price),
+			close_price = VALUES(close_price),
+			volume = VALUES(volume),
+			sample_count = VALUES(sample_count),
+			updated_at = CURRENT_TIMESTAMP
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, c := range candles {
+		if _, err := stmt.Exec(c.Emiten, string(c.Interval), c.Time, c.Open, c.High, c.Low, c.Close, c.Volume, c.SampleCount); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetCandles retrieves persisted candles of an emiten within [from, to], oldest first
+func (r *candleRepositoryImpl) GetCandles(emiten string, interval entities.CandleInterval, from, to time.Time) ([]entities.Candle, error) {
+	query := `
+		SELECT emiten, interval_code, bucket_start, open_price, high_price, low_price, close_price, volume, sample_count
+		FROM market_data_candles
+		WHERE emiten = ? AND interval_code = ? AND bucket_start BETWEEN ? AND ?
+		ORDER BY bucket_start ASC
+	`
+
+	rows, err := r.db.Query(query, emiten, string(interval), from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var candles []entities.Candle
+	for rows.Next() {
+		var c entities.Candle
+		err := rows.Scan(
+			&c.Emiten,
+			&c.Interval,
+			&c.Time,
+			&c.Open,
+			&c.High,
+			&c.Low,
+			&c.Close,
+			&c.Volume,
+			&c.SampleCount,
+		)
+		if err != nil {
+			return nil, err
+		}
+		candles = append(candles, c)
+	}
+
+	return candles, rows.Err()
+}
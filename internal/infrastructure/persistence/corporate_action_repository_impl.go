@@ -0,0 +1,195 @@
+package persistence
+
+import (
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/repositories"
+	"database/sql"
+	"time"
+)
+
+type corporateActionRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewCorporateActionRepository creates a new corporate action repository
+func NewCorporateActionRepository(db *sql.DB) repositories.CorporateActionRepository {
+	return &corporateActionRepositoryImpl{db: db}
+}
+
+const corporateActionColumns = `id, emiten, action_type, ex_date, announcement_date, cum_date, recording_date, payment_date, ratio_from, ratio_to, price, amount, description, created_at, updated_at`
+
+// Create inserts a corporate action and sets its ID
+func (r *corporateActionRepositoryImpl) Create(a *entities.CorporateAction) error {
+	result, err := r.db.Exec(`
+		INSERT INTO corporate_actions (emiten, action_type, ex_date, announcement_date, cum_date, recording_date, payment_date,
+			ratio_from, ratio_to, price, amount, description, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, a.Emiten, string(a.Type), a.ExDate, a.AnnouncementDate, a.CumDate, a.RecordingDate, a.PaymentDate,
+		a.RatioFrom, a.RatioTo, a.Price, a.Amount, a.Description, a.CreatedAt, a.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	a.ID, err = result.LastInsertId()
+	return err
+}
+
+// Update saves every field of a corporate action except its emiten
+func (r *corporateActionRepositoryImpl) Update(a *entities.CorporateAction) error {
+	_, err := r.db.Exec(`
+		UPDATE corporate_actions
+		SET action_type = ?, ex_date = ?, announcement_date = ?, cum_date = ?, recording_date = ?, payment_date = ?,
+			ratio_from = ?, ratio_to = ?, price = ?, amount = ?, description = ?, updated_at = ?
+		WHERE id = ?
+	`, string(a.Type), a.ExDate, a.AnnouncementDate, a.CumDate, a.RecordingDate, a.PaymentDate,
+		a.RatioFrom, a.RatioTo, a.Price, a.Amount, a.Description, a.UpdatedAt, a.ID)
+	return err
+}
+
+// Upsert inserts or updates corporate actions in a single transaction
+func (r *corporateActionRepositoryImpl) Upsert(actions []entities.CorporateAction) error {
+	if len(actions) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO corporate_actions (emiten, action_type, ex_date, announcement_date, cum_date, recording_date, payment_date,
+			ratio_from, ratio_to, price, amount, description, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			announcement_date = VALUES(announcement_date),
+			cum_date = VALUES(cum_date),
+			recording_date = VALUES(recording_date),
+			payment_date = VALUES(payment_date),
+			ratio_from = VALUES(ratio_from),
+			ratio_to = VALUES(ratio_to),
+			price = VALUES(price),
+			amount = VALUES(amount),
+			description = VALUES(description),
+			updated_at = VALUES(updated_at)
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, a := range actions {
+		_, err := stmt.Exec(a.Emiten, string(a.Type), a.ExDate, a.AnnouncementDate, a.CumDate, a.RecordingDate, a.PaymentDate,
+			a.RatioFrom, a.RatioTo, a.Price, a.Amount, a.Description, a.CreatedAt, a.UpdatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Delete removes a corporate action
+func (r *corporateActionRepositoryImpl) Delete(id int64) error {
+	_, err := r.db.Exec(`DELETE FROM corporate_actions WHERE id = ?`, id)
+	return err
+}
+
+// FindByID retrieves a corporate action, or nil if it does not exist
+func (r *corporateActionRepositoryImpl) FindByID(id int64) (*entities.CorporateAction, error) {
+	rows, err := r.db.Query(`SELECT `+corporateActionColumns+` FROM corporate_actions WHERE id = ?`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	actions, err := scanCorporateActionRows(rows)
+	if err != nil || len(actions) == 0 {
+		return nil, err
+	}
+	return &actions[0], nil
+}
+
+// FindByEmiten retrieves the corporate actions of an emiten
+func (r *corporateActionRepositoryImpl) FindByEmiten(emiten string) ([]entities.CorporateAction, error) {
+	rows, err := r.db.Query(`
+		SELECT `+corporateActionColumns+`
+		FROM corporate_actions
+		WHERE emiten = ?
+		ORDER BY ex_date, id
+	`, emiten)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanCorporateActionRows(rows)
+}
+
+// FindByRange retrieves corporate actions by ex-date, optionally of one emiten
+func (r *corporateActionRepositoryImpl) FindByRange(emiten string, from, to time.Time) ([]entities.CorporateAction, error) {
+	rows, err := r.db.Query(`
+		SELECT `+corporateActionColumns+`
+		FROM corporate_actions
+		WHERE ex_date BETWEEN ? AND ? AND (? = '' OR emiten = ?)
+		ORDER BY ex_date, emiten, id
+	`, from, to, emiten, emiten)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanCorporateActionRows(rows)
+}
+
+// FindDividendsByDate retrieves the cash dividends with a cum, ex, recording
+// or payment date within [from, to], ordered by ex-date
+func (r *corporateActionRepositoryImpl) FindDividendsByDate(from, to time.Time) ([]entities.CorporateAction, error) {
+	rows, err := r.db.Query(`
+		SELECT `+corporateActionColumns+`
+		FROM corporate_actions
+		WHERE action_type = ?
+		  AND (ex_date BETWEEN ? AND ?
+		    OR cum_date BETWEEN ? AND ?
+		    OR recording_date BETWEEN ? AND ?
+		    OR payment_date BETWEEN ? AND ?)
+		ORDER BY ex_date, emiten, id
+	`, string(entities.CorporateActionCashDividend), from, to, from, to, from, to, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanCorporateActionRows(rows)
+}
+
+func scanCorporateActionRows(rows *sql.Rows) ([]entities.CorporateAction, error) {
+	var actions []entities.CorporateAction
+	for rows.Next() {
+		var a entities.CorporateAction
+		err := rows.Scan(
+			&a.ID,
+			&a.Emiten,
+			&a.Type,
+			&a.ExDate,
+			&a.AnnouncementDate,
+			&a.CumDate,
+			&a.RecordingDate,
+			&a.PaymentDate,
+			&a.RatioFrom,
+			&a.RatioTo,
+			&a.Price,
+			&a.Amount,
+			&a.Description,
+			&a.CreatedAt,
+			&a.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, a)
+	}
+
+	return actions, rows.Err()
+}
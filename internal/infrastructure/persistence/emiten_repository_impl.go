@@ -0,0 +1,139 @@
+package persistence
+
+import (
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/repositories"
+	"database/sql"
+)
+
+type emitenRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewEmitenRepository creates a new emiten master data repository
+func NewEmitenRepository(db *sql.DB) repositories.EmitenRepository {
+	return &emitenRepositoryImpl{db: db}
+}
+
+const emitenColumns = `ticker, company_name, sector, sub_industry, board, listing_date,
+		       shares_outstanding, is_sharia, created_at, updated_at`
+
+// Create inserts an emiten
+func (r *emitenRepositoryImpl) Create(e *entities.Emiten) error {
+	_, err := r.db.Exec(`
+		INSERT INTO emitens (`+emitenColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, e.Ticker, e.CompanyName, e.Sector, e.SubIndustry, e.Board, e.ListingDate,
+		e.SharesOutstanding, e.IsSharia, e.CreatedAt, e.UpdatedAt)
+	return err
+}
+
+// Update saves every field of an emiten except its ticker and creation time
+func (r *emitenRepositoryImpl) Update(e *entities.Emiten) error {
+	_, err := r.db.Exec(`
+		UPDATE emitens
+		SET company_name = ?, sector = ?, sub_industry = ?, board = ?, listing_date = ?,
+		    shares_outstanding = ?, is_sharia = ?, updated_at = ?
+		WHERE ticker = ?
+	`, e.CompanyName, e.Sector, e.SubIndustry, e.Board, e.ListingDate,
+		e.SharesOutstanding, e.IsSharia, e.UpdatedAt, e.Ticker)
+	return err
+}
+
+// Upsert inserts or updates emitens by ticker in a single transaction
+func (r *emitenRepositoryImpl) Upsert(emitens []entities.Emiten) error {
+	if len(emitens) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO emitens (` + emitenColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			company_name = VALUES(company_name),
+			sector = VALUES(sector),
+			sub_industry = VALUES(sub_industry),
+			board = VALUES(board),
+			listing_date = VALUES(listing_date),
+			shares_outstanding = VALUES(shares_outstanding),
+			is_sharia = VALUES(is_sharia),
+			updated_at = VALUES(updated_at)
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, e := range emitens {
+		_, err := stmt.Exec(e.Ticker, e.CompanyName, e.Sector, e.SubIndustry, e.Board, e.ListingDate,
+			e.SharesOutstanding, e.IsSharia, e.CreatedAt, e.UpdatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Delete removes an emiten
+func (r *emitenRepositoryImpl) Delete(ticker string) error {
+	_, err := r.db.Exec(`DELETE FROM emitens WHERE ticker = ?`, ticker)
+	return err
+}
+
+// FindByTicker retrieves an emiten, or nil if it does not exist
+func (r *emitenRepositoryImpl) FindByTicker(ticker string) (*entities.Emiten, error) {
+	rows, err := r.db.Query(`SELECT `+emitenColumns+` FROM emitens WHERE ticker = ?`, ticker)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	emitens, err := scanEmitenRows(rows)
+	if err != nil || len(emitens) == 0 {
+		return nil, err
+	}
+	return &emitens[0], nil
+}
+
+// FindAll retrieves every emiten ordered by ticker
+func (r *emitenRepositoryImpl) FindAll() ([]entities.Emiten, error) {
+	rows, err := r.db.Query(`SELECT ` + emitenColumns + ` FROM emitens ORDER BY ticker`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanEmitenRows(rows)
+}
+
+func scanEmitenRows(rows *sql.Rows) ([]entities.Emiten, error) {
+	var emitens []entities.Emiten
+	for rows.Next() {
+		var e entities.Emiten
+		err := rows.Scan(
+			&e.Ticker,
+			&e.CompanyName,
+			&e.Sector,
+			&e.SubIndustry,
+			&e.Board,
+			&e.ListingDate,
+			&e.SharesOutstanding,
+			&e.IsSharia,
+			&e.CreatedAt,
+			&e.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		emitens = append(emitens, e)
+	}
+
+	return emitens, rows.Err()
+}
@@ -0,0 +1,124 @@
+package persistence
+
+import (
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/repositories"
+	"database/sql"
+	"time"
+)
+
+type foreignFlowRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewForeignFlowRepository creates a new foreign flow repository
+func NewForeignFlowRepository(db *sql.DB) repositories.ForeignFlowRepository {
+	return &foreignFlowRepositoryImpl{db: db}
+}
+
+const foreignFlowColumns = `id, emiten, trade_date, buy_volume, sell_volume, buy_value, sell_value, created_at, updated_at`
+
+// Upsert inserts or updates flows in a single transaction
+func (r *foreignFlowRepositoryImpl) Upsert(flows []entities.ForeignFlow) error {
+	if len(flows) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO foreign_flows (emiten, trade_date, buy_volume, sell_volume, buy_value, sell_value, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			buy_volume = VALUES(buy_volume),
+			sell_volume = VALUES(sell_volume),
+			buy_value = VALUES(buy_value),
+			sell_value = VALUES(sell_value),
+			updated_at = VALUES(updated_at)
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, f := range flows {
+		_, err := stmt.Exec(f.Emiten, f.Date, f.BuyVolume, f.SellVolume, f.BuyValue, f.SellValue, f.CreatedAt, f.UpdatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// FindByEmiten retrieves the flows of an emiten within a date range
+func (r *foreignFlowRepositoryImpl) FindByEmiten(emiten string, from, to time.Time) ([]entities.ForeignFlow, error) {
+	rows, err := r.db.Query(`
+		SELECT `+foreignFlowColumns+`
+		FROM foreign_flows
+		WHERE emiten = ? AND trade_date BETWEEN ? AND ?
+		ORDER BY trade_date
+	`, emiten, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanForeignFlowRows(rows)
+}
+
+// FindByRange retrieves the flows of every emiten within a date range
+func (r *foreignFlowRepositoryImpl) FindByRange(from, to time.Time) ([]entities.ForeignFlow, error) {
+	rows, err := r.db.Query(`
+		SELECT `+foreignFlowColumns+`
+		FROM foreign_flows
+		WHERE trade_date BETWEEN ? AND ?
+		ORDER BY trade_date, emiten
+	`, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanForeignFlowRows(rows)
+}
+
+// LatestDate returns the most recent trade date
+func (r *foreignFlowRepositoryImpl) LatestDate() (*time.Time, error) {
+	var latest sql.NullTime
+	if err := r.db.QueryRow(`SELECT MAX(trade_date) FROM foreign_flows`).Scan(&latest); err != nil {
+		return nil, err
+	}
+	if !latest.Valid {
+		return nil, nil
+	}
+	return &latest.Time, nil
+}
+
+func scanForeignFlowRows(rows *sql.Rows) ([]entities.ForeignFlow, error) {
+	var flows []entities.ForeignFlow
+	for rows.Next() {
+		var f entities.ForeignFlow
+		err := rows.Scan(
+			&f.ID,
+			&f.Emiten,
+			&f.Date,
+			&f.BuyVolume,
+			&f.SellVolume,
+			&f.BuyValue,
+			&f.SellValue,
+			&f.CreatedAt,
+			&f.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		flows = append(flows, f)
+	}
+
+	return flows, rows.Err()
+}
@@ -0,0 +1,154 @@
+package persistence
+
+import (
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/repositories"
+	"database/sql"
+	"time"
+)
+
+type indexMembershipRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewIndexMembershipRepository creates a new index membership repository
+func NewIndexMembershipRepository(db *sql.DB) repositories.IndexMembershipRepository {
+	return &indexMembershipRepositoryImpl{db: db}
+}
+
+const indexMembershipColumns = `id, index_code, emiten, effective_from, effective_to, created_at, updated_at`
+
+// Create inserts a membership and sets its ID
+func (r *indexMembershipRepositoryImpl) Create(m *entities.IndexMembership) error {
+	result, err := r.db.Exec(`
+		INSERT INTO index_memberships (index_code, emiten, effective_from, effective_to, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, m.IndexCode, m.Emiten, m.EffectiveFrom, m.EffectiveTo, m.CreatedAt, m.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	m.ID, err = result.LastInsertId()
+	return err
+}
+
+// Update saves the emiten and date range of a membership
+func (r *indexMembershipRepositoryImpl) Update(m *entities.IndexMembership) error {
+	_, err := r.db.Exec(`
+		UPDATE index_memberships
+		SET emiten = ?, effective_from = ?, effective_to = ?, updated_at = ?
+		WHERE id = ?
+	`, m.Emiten, m.EffectiveFrom, m.EffectiveTo, m.UpdatedAt, m.ID)
+	return err
+}
+
+// Upsert inserts or updates memberships in a single transaction
+func (r *indexMembershipRepositoryImpl) Upsert(memberships []entities.IndexMembership) error {
+	if len(memberships) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO index_memberships (index_code, emiten, effective_from, effective_to, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			effective_to = VALUES(effective_to),
+			updated_at = VALUES(updated_at)
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, m := range memberships {
+		_, err := stmt.Exec(m.IndexCode, m.Emiten, m.EffectiveFrom, m.EffectiveTo, m.CreatedAt, m.UpdatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Delete removes a membership
+func (r *indexMembershipRepositoryImpl) Delete(id int64) error {
+	_, err := r.db.Exec(`DELETE FROM index_memberships WHERE id = ?`, id)
+	return err
+}
+
+// FindByID retrieves a membership, or nil if it does not exist
+func (r *indexMembershipRepositoryImpl) FindByID(id int64) (*entities.IndexMembership, error) {
+	rows, err := r.db.Query(`SELECT `+indexMembershipColumns+` FROM index_memberships WHERE id = ?`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	memberships, err := scanIndexMembershipRows(rows)
+	if err != nil || len(memberships) == 0 {
+		return nil, err
+	}
+	return &memberships[0], nil
+}
+
+// FindByIndex retrieves the memberships of an index, optionally of one emiten
+func (r *indexMembershipRepositoryImpl) FindByIndex(indexCode, emiten string) ([]entities.IndexMembership, error) {
+	rows, err := r.db.Query(`
+		SELECT `+indexMembershipColumns+`
+		FROM index_memberships
+		WHERE index_code = ? AND (? = '' OR emiten = ?)
+		ORDER BY emiten, effective_from
+	`, indexCode, emiten, emiten)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanIndexMembershipRows(rows)
+}
+
+// FindActive retrieves the memberships of an index covering a date
+func (r *indexMembershipRepositoryImpl) FindActive(indexCode string, date time.Time) ([]entities.IndexMembership, error) {
+	rows, err := r.db.Query(`
+		SELECT `+indexMembershipColumns+`
+		FROM index_memberships
+		WHERE index_code = ?
+		  AND effective_from <= ?
+		  AND (effective_to IS NULL OR effective_to >= ?)
+		ORDER BY emiten
+	`, indexCode, date, date)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanIndexMembershipRows(rows)
+}
+
+func scanIndexMembershipRows(rows *sql.Rows) ([]entities.IndexMembership, error) {
+	var memberships []entities.IndexMembership
+	for rows.Next() {
+		var m entities.IndexMembership
+		err := rows.Scan(
+			&m.ID,
+			&m.IndexCode,
+			&m.Emiten,
+			&m.EffectiveFrom,
+			&m.EffectiveTo,
+			&m.CreatedAt,
+			&m.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		memberships = append(memberships, m)
+	}
+
+	return memberships, rows.Err()
+}
@@ -0,0 +1,168 @@
+package persistence
+
+import (
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/repositories"
+	"database/sql"
+	"encoding/json"
+)
+
+type indexRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewIndexRepository creates a new composite index repository
+func NewIndexRepository(db *sql.DB) repositories.IndexRepository {
+	return &indexRepositoryImpl{db: db}
+}
+
+const indexColumns = `code, name, weighting, base_date, base_value, created_at, updated_at`
+
+// Create inserts an index and its base period in a single transaction
+func (r *indexRepositoryImpl) Create(index *entities.Index, base *entities.IndexPeriod) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		INSERT INTO indices (`+indexColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, index.Code, index.Name, index.Weighting, index.BaseDate, index.BaseValue, index.CreatedAt, index.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	if err := insertIndexPeriod(tx, base); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Update saves the name of an index; weighting and base are fixed
+func (r *indexRepositoryImpl) Update(index *entities.Index) error {
+	_, err := r.db.Exec(`UPDATE indices SET name = ?, updated_at = ? WHERE code = ?`, index.Name, index.UpdatedAt, index.Code)
+	return err
+}
+
+// Delete removes an index together with its periods
+func (r *indexRepositoryImpl) Delete(code string) error {
+	_, err := r.db.Exec(`DELETE FROM indices WHERE code = ?`, code)
+	return err
+}
+
+// FindByCode retrieves an index, or nil if it does not exist
+func (r *indexRepositoryImpl) FindByCode(code string) (*entities.Index, error) {
+	rows, err := r.db.Query(`SELECT `+indexColumns+` FROM indices WHERE code = ?`, code)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	indices, err := scanIndexRows(rows)
+	if err != nil || len(indices) == 0 {
+		return nil, err
+	}
+	return &indices[0], nil
+}
+
+// FindAll retrieves every index ordered by code
+func (r *indexRepositoryImpl) FindAll() ([]entities.Index, error) {
+	rows, err := r.db.Query(`SELECT ` + indexColumns + ` FROM indices ORDER BY code`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanIndexRows(rows)
+}
+
+// CreatePeriod inserts an index period and sets its ID
+func (r *indexRepositoryImpl) CreatePeriod(period *entities.IndexPeriod) error {
+	return insertIndexPeriod(r.db, period)
+}
+
+// FindPeriods retrieves the periods of an index, oldest first
+func (r *indexRepositoryImpl) FindPeriods(code string) ([]entities.IndexPeriod, error) {
+	rows, err := r.db.Query(`
+		SELECT id, index_code, effective_from, divisor, reason, constituents, created_at
+		FROM index_periods
+		WHERE index_code = ?
+		ORDER BY effective_from
+	`, code)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var periods []entities.IndexPeriod
+	for rows.Next() {
+		var p entities.IndexPeriod
+		var constituents []byte
+		err := rows.Scan(
+			&p.ID,
+			&p.IndexCode,
+			&p.EffectiveFrom,
+			&p.Divisor,
+			&p.Reason,
+			&constituents,
+			&p.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(constituents, &p.Constituents); err != nil {
+			return nil, err
+		}
+		periods = append(periods, p)
+	}
+
+	return periods, rows.Err()
+}
+
+// execer is satisfied by both *sql.DB and *sql.Tx
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+func insertIndexPeriod(db execer, period *entities.IndexPeriod) error {
+	constituents, err := json.Marshal(period.Constituents)
+	if err != nil {
+		return err
+	}
+
+	result, err := db.Exec(`
+		INSERT INTO index_periods (index_code, effective_from, divisor, reason, constituents, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, period.IndexCode, period.EffectiveFrom, period.Divisor, period.Reason, constituents, period.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	period.ID, err = result.LastInsertId()
+	return err
+}
+
+func scanIndexRows(rows *sql.Rows) ([]entities.Index, error) {
+	var indices []entities.Index
+	for rows.Next() {
+		var i entities.Index
+		err := rows.Scan(
+			&i.Code,
+			&i.Name,
+			&i.Weighting,
+			&i.BaseDate,
+			&i.BaseValue,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		indices = append(indices, i)
+	}
+
+	return indices, rows.Err()
+}
@@ -4,6 +4,8 @@ import (
 	"api-web-scrapping/internal/domain/entities"
 	"api-web-scrapping/internal/domain/repositories"
 	"database/sql"
+	"fmt"
+	"time"
 )
 
 type marketDataRepositoryImpl struct {
@@ -15,13 +17,25 @@ func NewMarketDataRepository(db *sql.DB) repositories.MarketDataRepository {
 	return &marketDataRepositoryImpl{db: db}
 }
 
+// latestMarketDataColumns selects the view columns plus the session totals,
+// which are read from market_data since the view predates them
+const latestMarketDataColumns = `v.id, v.emiten, v.open_price, v.high_price, v.low_price, v.last_price,
+		       v.date_time_scraping, v.created_at, v.updated_at, v.deleted_at,
+		       m.volume, m.value, m.frequency`
+
+// snapshotColumns selects every scraped snapshot from market_data, since
+// v_latest_market_data only holds the latest one per emiten
+const snapshotColumns = `id, emiten, open_price, high_price, low_price, last_price,
+		       date_time_scraping, created_at, updated_at, deleted_at,
+		       volume, value, frequency`
+
 // GetAll retrieves all market data from the view
 func (r *marketDataRepositoryImpl) GetAll() ([]entities.MarketData, error) {
 	query := `
-		SELECT id, emiten, open_price, high_price, low_price, last_price,
-		       date_time_scraping, created_at, updated_at, deleted_at
-		FROM v_latest_market_data
-		ORDER BY emiten
+		SELECT ` + latestMarketDataColumns + `
+		FROM v_latest_market_data v
+		JOIN market_data m ON m.id = v.id
+		ORDER BY v.emiten
 	`
 
 	rows, err := r.db.Query(query)
@@ -44,6 +58,9 @@ func (r *marketDataRepositoryImpl) GetAll() ([]entities.MarketData, error) {
 			&md.CreatedAt,
 			&md.UpdatedAt,
 			&md.DeletedAt,
+			&md.Volume,
+			&md.Value,
+			&md.Frequency,
 		)
 		if err != nil {
 			return nil, err
@@ -57,11 +74,11 @@ func (r *marketDataRepositoryImpl) GetAll() ([]entities.MarketData, error) {
 // GetByEmiten retrieves market data for a specific emiten
 func (r *marketDataRepositoryImpl) GetByEmiten(emiten string) ([]entities.MarketData, error) {
 	query := `
-		SELECT id, emiten, open_price, high_price, low_price, last_price,
-		       date_time_scraping, created_at, updated_at, deleted_at
-		FROM v_latest_market_data
-		WHERE emiten = ?
-		ORDER BY date_time_scraping DESC
+		SELECT ` + latestMarketDataColumns + `
+		FROM v_latest_market_data v
+		JOIN market_data m ON m.id = v.id
+		WHERE v.emiten = ?
+		ORDER BY v.date_time_scraping DESC
 	`
 
 	rows, err := r.db.Query(query, emiten)
@@ -84,6 +101,9 @@ func (r *marketDataRepositoryImpl) GetByEmiten(emiten string) ([]entities.Market
 			&md.CreatedAt,
 			&md.UpdatedAt,
 			&md.DeletedAt,
+			&md.Volume,
+			&md.Value,
+			&md.Frequency,
 		)
 		if err != nil {
 			return nil, err
@@ -97,10 +117,11 @@ func (r *marketDataRepositoryImpl) GetByEmiten(emiten string) ([]entities.Market
 // GetLatestByEmiten retrieves the latest market data for a specific emiten
 func (r *marketDataRepositoryImpl) GetLatestByEmiten(emiten string) (*entities.MarketData, error) {
 	query := `
-		SELECT id, emiten, open_price, high_price, low_price, last_price,
-		       date_time_scraping, created_at, updated_at, deleted_at
-		FROM v_latest_market_data
-		WHERE emiten = ?
+		SELECT ` + latestMarketDataColumns + `
+		FROM v_latest_market_data v
+		JOIN market_data m ON m.id = v.id
+		WHERE v.emiten = ?
+		ORDER BY v.date_time_scraping DESC
 		LIMIT 1
 	`
 
@@ -116,6 +137,9 @@ func (r *marketDataRepositoryImpl) GetLatestByEmiten(emiten string) (*entities.M
 		&md.CreatedAt,
 		&md.UpdatedAt,
 		&md.DeletedAt,
+		&md.Volume,
+		&md.Value,
+		&md.Frequency,
 	)
 
 	if err == sql.ErrNoRows {
@@ -131,10 +155,10 @@ func (r *marketDataRepositoryImpl) GetLatestByEmiten(emiten string) (*entities.M
 // GetLatestByAllEmiten retrieves the latest market data for all emitens
 func (r *marketDataRepositoryImpl) GetLatestByAllEmiten() ([]entities.MarketData, error) {
 	query := `
-		SELECT id, emiten, open_price, high_price, low_price, last_price,
-		       date_time_scraping, created_at, updated_at, deleted_at
-		FROM v_latest_market_data
-		ORDER BY emiten
+		SELECT ` + latestMarketDataColumns + `
+		FROM v_latest_market_data v
+		JOIN market_data m ON m.id = v.id
+		ORDER BY v.emiten
 	`
 
 	rows, err := r.db.Query(query)
@@ -157,6 +181,9 @@ func (r *marketDataRepositoryImpl) GetLatestByAllEmiten() ([]entities.MarketData
 			&md.CreatedAt,
 			&md.UpdatedAt,
 			&md.DeletedAt,
+			&md.Volume,
+			&md.Value,
+			&md.Frequency,
 		)
 		if err != nil {
 			return nil, err
@@ -166,3 +193,190 @@ func (r *marketDataRepositoryImpl) GetLatestByAllEmiten() ([]entities.MarketData
 
 	return marketDataList, nil
 }
+
+// latestAsOfQuery ranks the records of each emiten that had been scraped and
+// stored by an instant and not yet deleted, so that records inserted or
+// deleted later, such as corrections of an earlier scrape, do not change the
+// result
+const latestAsOfQuery = `
+	SELECT ` + snapshotColumns + `
+	FROM (
+		SELECT ` + snapshotColumns + `,
+		       ROW_NUMBER() OVER (
+		           PARTITION BY emiten
+		           ORDER BY date_time_scraping DESC, created_at DESC, id DESC
+		       ) AS position
+		FROM market_data
+		WHERE (deleted_at IS NULL OR deleted_at > ?) AND date_time_scraping <= ? AND created_at <= ? %s
+	) ranked
+	WHERE position = 1
+	ORDER BY emiten
+`
+
+// GetLatestByEmitenAsOf retrieves the market data of an emiten that was
+// the latest at asOf
+func (r *marketDataRepositoryImpl) GetLatestByEmitenAsOf(emiten string, asOf time.Time) (*entities.MarketData, error) {
+	rows, err := r.db.Query(fmt.Sprintf(latestAsOfQuery, "AND emiten = ?"), asOf, asOf, asOf, emiten)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	marketDataList, err := scanMarketDataRows(rows)
+	if err != nil || len(marketDataList) == 0 {
+		return nil, err
+	}
+	return &marketDataList[0], nil
+}
+
+// GetLatestByAllEmitenAsOf retrieves the market data of every emiten that
+// was the latest at asOf
+func (r *marketDataRepositoryImpl) GetLatestByAllEmitenAsOf(asOf time.Time) ([]entities.MarketData, error) {
+	rows, err := r.db.Query(fmt.Sprintf(latestAsOfQuery, ""), asOf, asOf, asOf)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanMarketDataRows(rows)
+}
+
+// GetHistoryByEmiten retrieves the snapshots of an emiten scraped within
+// [from, to], oldest first
+func (r *marketDataRepositoryImpl) GetHistoryByEmiten(emiten string, from, to time.Time) ([]entities.MarketData, error) {
+	query := `
+		SELECT ` + snapshotColumns + `
+		FROM market_data
+		WHERE deleted_at IS NULL AND emiten = ? AND date_time_scraping BETWEEN ? AND ?
+		ORDER BY date_time_scraping ASC
+	`
+
+	rows, err := r.db.Query(query, emiten, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanMarketDataRows(rows)
+}
+
+// GetHistory retrieves the snapshots of every emiten scraped within
+// [from, to], ordered by emiten and then oldest first
+func (r *marketDataRepositoryImpl) GetHistory(from, to time.Time) ([]entities.MarketData, error) {
+	query := `
+		SELECT ` + snapshotColumns + `
+		FROM market_data
+		WHERE deleted_at IS NULL AND date_time_scraping BETWEEN ? AND ?
+		ORDER BY emiten, date_time_scraping ASC
+	`
+
+	rows, err := r.db.Query(query, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanMarketDataRows(rows)
+}
+
+// dailyHistoryQuery rolls the snapshots of each emiten into one per session:
+// the open of the first, the highest high, the lowest low, the highest session
+// totals and the rest of the last. Sessions are WIB calendar days of the UTC
+// scrape times. Quarantined snapshots are left out.
+const dailyHistoryQuery = `
+	SELECT ` + snapshotColumns + `
+	FROM (
+		SELECT id, emiten,
+		       FIRST_VALUE(open_price) OVER ordered AS open_price,
+		       MAX(high_price) OVER daily AS high_price,
+		       MIN(low_price) OVER daily AS low_price,
+		       last_price, date_time_scraping, created_at, updated_at, deleted_at,
+		       MAX(volume) OVER daily AS volume,
+		       MAX(value) OVER daily AS value,
+		       MAX(frequency) OVER daily AS frequency,
+		       ROW_NUMBER() OVER (daily ORDER BY date_time_scraping DESC, id DESC) AS position
+		FROM (
+			SELECT ` + snapshotColumns + `,
+			       DATE(date_time_scraping + INTERVAL 7 HOUR) AS session
+			FROM market_data m
+			WHERE deleted_at IS NULL AND date_time_scraping BETWEEN ? AND ? %s
+			  AND NOT EXISTS (SELECT 1 FROM market_data_quarantine q WHERE q.market_data_id = m.id)
+		) snapshots
+		WINDOW daily AS (PARTITION BY emiten, session),
+		       ordered AS (daily ORDER BY date_time_scraping, id)
+	) sessions
+	WHERE position = 1
+	ORDER BY emiten, date_time_scraping ASC
+`
+
+// GetDailyHistoryByEmiten retrieves one snapshot per session of an emiten
+// scraped within [from, to], oldest first
+func (r *marketDataRepositoryImpl) GetDailyHistoryByEmiten(emiten string, from, to time.Time) ([]entities.MarketData, error) {
+	rows, err := r.db.Query(fmt.Sprintf(dailyHistoryQuery, "AND emiten = ?"), from, to, emiten)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanMarketDataRows(rows)
+}
+
+// GetDailyHistory retrieves one snapshot per session of every emiten scraped
+// within [from, to], ordered by emiten and then oldest first
+func (r *marketDataRepositoryImpl) GetDailyHistory(from, to time.Time) ([]entities.MarketData, error) {
+	rows, err := r.db.Query(fmt.Sprintf(dailyHistoryQuery, ""), from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanMarketDataRows(rows)
+}
+
+// GetHighestByEmiten retrieves up to limit snapshots of an emiten scraped
+// before the given time with the highest high prices, highest first
+func (r *marketDataRepositoryImpl) GetHighestByEmiten(emiten string, before time.Time, limit int) ([]entities.MarketData, error) {
+	query := `
+		SELECT ` + snapshotColumns + `
+		FROM market_data
+		WHERE deleted_at IS NULL AND emiten = ? AND date_time_scraping < ?
+		ORDER BY high_price DESC, date_time_scraping ASC
+		LIMIT ?
+	`
+
+	rows, err := r.db.Query(query, emiten, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanMarketDataRows(rows)
+}
+
+func scanMarketDataRows(rows *sql.Rows) ([]entities.MarketData, error) {
+	var marketDataList []entities.MarketData
+	for rows.Next() {
+		var md entities.MarketData
+		err := rows.Scan(
+			&md.ID,
+			&md.Emiten,
+			&md.OpenPrice,
+			&md.HighPrice,
+			&md.LowPrice,
+			&md.ClosePrice,
+			&md.Date,
+			&md.CreatedAt,
+			&md.UpdatedAt,
+			&md.DeletedAt,
+			&md.Volume,
+			&md.Value,
+			&md.Frequency,
+		)
+		if err != nil {
+			return nil, err
+		}
+		marketDataList = append(marketDataList, md)
+	}
+
+	return marketDataList, rows.Err()
+}
@@ -0,0 +1,126 @@
+package persistence
+
+import (
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/repositories"
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+type orderBookRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewOrderBookRepository creates a new order book snapshot repository
+func NewOrderBookRepository(db *sql.DB) repositories.OrderBookRepository {
+	return &orderBookRepositoryImpl{db: db}
+}
+
+const orderBookColumns = `id, emiten, captured_at, bids, offers, created_at`
+
+// Save inserts or updates snapshots in a single transaction
+func (r *orderBookRepositoryImpl) Save(snapshots []entities.OrderBookSnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO order_book_snapshots (emiten, captured_at, bids, offers, created_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			bids = VALUES(bids),
+			offers = VALUES(offers)
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, s := range snapshots {
+		bids, err := json.Marshal(s.Bids)
+		if err != nil {
+			return err
+		}
+		offers, err := json.Marshal(s.Offers)
+		if err != nil {
+			return err
+		}
+		if _, err := stmt.Exec(s.Emiten, s.Time, bids, offers, s.CreatedAt); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// FindLatestByEmiten retrieves the last snapshot of an emiten as of a time
+func (r *orderBookRepositoryImpl) FindLatestByEmiten(emiten string, asOf time.Time) (*entities.OrderBookSnapshot, error) {
+	rows, err := r.db.Query(`
+		SELECT `+orderBookColumns+`
+		FROM order_book_snapshots
+		WHERE emiten = ? AND captured_at <= ?
+		ORDER BY captured_at DESC
+		LIMIT 1
+	`, emiten, asOf)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	snapshots, err := scanOrderBookRows(rows)
+	if err != nil || len(snapshots) == 0 {
+		return nil, err
+	}
+	return &snapshots[0], nil
+}
+
+// orderBookDeleteBatch bounds the rows removed by one DELETE, so pruning a
+// large backlog does not hold long locks on the table
+const orderBookDeleteBatch = 5000
+
+// DeleteBefore removes snapshots older than cutoff in batches of
+// orderBookDeleteBatch rows
+func (r *orderBookRepositoryImpl) DeleteBefore(cutoff time.Time) (int64, error) {
+	var total int64
+	for {
+		result, err := r.db.Exec(`DELETE FROM order_book_snapshots WHERE captured_at < ? LIMIT ?`, cutoff, orderBookDeleteBatch)
+		if err != nil {
+			return total, err
+		}
+		deleted, err := result.RowsAffected()
+		if err != nil {
+			return total, err
+		}
+		total += deleted
+		if deleted < orderBookDeleteBatch {
+			return total, nil
+		}
+	}
+}
+
+func scanOrderBookRows(rows *sql.Rows) ([]entities.OrderBookSnapshot, error) {
+	var snapshots []entities.OrderBookSnapshot
+	for rows.Next() {
+		var s entities.OrderBookSnapshot
+		var bids, offers []byte
+		if err := rows.Scan(&s.ID, &s.Emiten, &s.Time, &bids, &offers, &s.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(bids, &s.Bids); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(offers, &s.Offers); err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, s)
+	}
+
+	return snapshots, rows.Err()
+}
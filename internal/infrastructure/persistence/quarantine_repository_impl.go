@@ -0,0 +1,93 @@
+package persistence
+
+import (
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/repositories"
+	"database/sql"
+	"time"
+)
+
+type quarantineRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewQuarantineRepository creates a new market data quarantine repository
+func NewQuarantineRepository(db *sql.DB) repositories.QuarantineRepository {
+	return &quarantineRepositoryImpl{db: db}
+}
+
+// Save inserts rejected snapshots in a single transaction. A snapshot is
+// quarantined once; the rollup worker revisits the same window every run.
+func (r *quarantineRepositoryImpl) Save(records []entities.QuarantinedMarketData) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`
+		INSERT IGNORE INTO market_data_quarantine
+			(market_data_id, emiten, open_price, high_price, low_price, last_price, date_time_scraping, reason)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, q := range records {
+		if _, err := stmt.Exec(q.MarketDataID, q.Emiten, q.OpenPrice, q.HighPrice, q.LowPrice, q.ClosePrice, q.Date, q.Reason); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// FindByRange retrieves quarantined snapshots scraped within [from, to]
+func (r *quarantineRepositoryImpl) FindByRange(emiten string, from, to time.Time) ([]entities.QuarantinedMarketData, error) {
+	query := `
+		SELECT id, market_data_id, emiten, open_price, high_price, low_price, last_price, date_time_scraping, reason, created_at
+		FROM market_data_quarantine
+		WHERE date_time_scraping BETWEEN ? AND ?
+	`
+	args := []interface{}{from, to}
+	if emiten != "" {
+		query += ` AND emiten = ?`
+		args = append(args, emiten)
+	}
+	query += ` ORDER BY date_time_scraping DESC, id DESC`
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []entities.QuarantinedMarketData
+	for rows.Next() {
+		var q entities.QuarantinedMarketData
+		err := rows.Scan(
+			&q.ID,
+			&q.MarketDataID,
+			&q.Emiten,
+			&q.OpenPrice,
+			&q.HighPrice,
+			&q.LowPrice,
+			&q.ClosePrice,
+			&q.Date,
+			&q.Reason,
+			&q.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, q)
+	}
+
+	return records, rows.Err()
+}
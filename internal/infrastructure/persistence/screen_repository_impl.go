@@ -0,0 +1,238 @@
+package persistence
+
+import (
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/repositories"
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+type screenRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewScreenRepository creates a new saved screen repository
+func NewScreenRepository(db *sql.DB) repositories.ScreenRepository {
+	return &screenRepositoryImpl{db: db}
+}
+
+const screenColumns = `id, user_id, name, filter, schedule, enabled, next_run_at, last_run_at, created_at, updated_at`
+
+// Create inserts a screen and sets its ID
+func (r *screenRepositoryImpl) Create(screen *entities.Screen) error {
+	filter, err := json.Marshal(screen.Filter)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec(`
+		INSERT INTO screens (user_id, name, filter, schedule, enabled, next_run_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, screen.UserID, screen.Name, filter, screen.Schedule, screen.Enabled, screen.NextRunAt, screen.CreatedAt, screen.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	screen.ID, err = result.LastInsertId()
+	return err
+}
+
+// Update saves the definition and schedule of a screen
+func (r *screenRepositoryImpl) Update(screen *entities.Screen) error {
+	filter, err := json.Marshal(screen.Filter)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(`
+		UPDATE screens
+		SET name = ?, filter = ?, schedule = ?, enabled = ?, next_run_at = ?, updated_at = ?
+		WHERE id = ?
+	`, screen.Name, filter, screen.Schedule, screen.Enabled, screen.NextRunAt, screen.UpdatedAt, screen.ID)
+	return err
+}
+
+// Delete removes a screen together with its runs
+func (r *screenRepositoryImpl) Delete(id int64) error {
+	_, err := r.db.Exec(`DELETE FROM screens WHERE id = ?`, id)
+	return err
+}
+
+// FindByID retrieves a screen, or nil if it does not exist
+func (r *screenRepositoryImpl) FindByID(id int64) (*entities.Screen, error) {
+	rows, err := r.db.Query(`SELECT `+screenColumns+` FROM screens WHERE id = ?`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	screens, err := scanScreenRows(rows)
+	if err != nil || len(screens) == 0 {
+		return nil, err
+	}
+	return &screens[0], nil
+}
+
+// FindByUser retrieves the screens owned by a user
+func (r *screenRepositoryImpl) FindByUser(userID string) ([]entities.Screen, error) {
+	rows, err := r.db.Query(`SELECT `+screenColumns+` FROM screens WHERE user_id = ? ORDER BY name`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanScreenRows(rows)
+}
+
+// FindDue retrieves enabled screens whose next run is at or before now
+func (r *screenRepositoryImpl) FindDue(now time.Time) ([]entities.Screen, error) {
+	rows, err := r.db.Query(`
+		SELECT `+screenColumns+`
+		FROM screens
+		WHERE enabled = TRUE AND next_run_at IS NOT NULL AND next_run_at <= ?
+		ORDER BY next_run_at
+	`, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanScreenRows(rows)
+}
+
+// UpdateRunTimes records when a screen last ran and when it runs next
+func (r *screenRepositoryImpl) UpdateRunTimes(id int64, lastRunAt time.Time, nextRunAt *time.Time) error {
+	_, err := r.db.Exec(`UPDATE screens SET last_run_at = ?, next_run_at = ? WHERE id = ?`, lastRunAt, nextRunAt, id)
+	return err
+}
+
+// CreateRun inserts a screen run and sets its ID
+func (r *screenRepositoryImpl) CreateRun(run *entities.ScreenRun) error {
+	results, err := json.Marshal(run.Results)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec(`
+		INSERT INTO screen_runs (screen_id, trigger_type, run_at, result_count, results)
+		VALUES (?, ?, ?, ?, ?)
+	`, run.ScreenID, run.Trigger, run.RunAt, len(run.Results), results)
+	if err != nil {
+		return err
+	}
+
+	run.ID, err = result.LastInsertId()
+	return err
+}
+
+// FindRuns retrieves the most recent runs of a screen, newest first
+func (r *screenRepositoryImpl) FindRuns(screenID int64, limit int) ([]entities.ScreenRun, error) {
+	rows, err := r.db.Query(`
+		SELECT id, screen_id, trigger_type, run_at, results
+		FROM screen_runs
+		WHERE screen_id = ?
+		ORDER BY run_at DESC, id DESC
+		LIMIT ?
+	`, screenID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanScreenRunRows(rows)
+}
+
+// FindRun retrieves a run of a screen, or nil if it does not exist
+func (r *screenRepositoryImpl) FindRun(screenID, runID int64) (*entities.ScreenRun, error) {
+	rows, err := r.db.Query(`
+		SELECT id, screen_id, trigger_type, run_at, results
+		FROM screen_runs
+		WHERE screen_id = ? AND id = ?
+	`, screenID, runID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	runs, err := scanScreenRunRows(rows)
+	if err != nil || len(runs) == 0 {
+		return nil, err
+	}
+	return &runs[0], nil
+}
+
+// FindPreviousRun retrieves the run of a screen immediately before runID, or
+// nil if runID is the first run
+func (r *screenRepositoryImpl) FindPreviousRun(screenID, runID int64) (*entities.ScreenRun, error) {
+	rows, err := r.db.Query(`
+		SELECT id, screen_id, trigger_type, run_at, results
+		FROM screen_runs
+		WHERE screen_id = ? AND id < ?
+		ORDER BY id DESC
+		LIMIT 1
+	`, screenID, runID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	runs, err := scanScreenRunRows(rows)
+	if err != nil || len(runs) == 0 {
+		return nil, err
+	}
+	return &runs[0], nil
+}
+
+func scanScreenRows(rows *sql.Rows) ([]entities.Screen, error) {
+	var screens []entities.Screen
+	for rows.Next() {
+		var s entities.Screen
+		var filter []byte
+		err := rows.Scan(
+			&s.ID,
+			&s.UserID,
+			&s.Name,
+			&filter,
+			&s.Schedule,
+			&s.Enabled,
+			&s.NextRunAt,
+			&s.LastRunAt,
+			&s.CreatedAt,
+			&s.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(filter, &s.Filter); err != nil {
+			return nil, err
+		}
+		screens = append(screens, s)
+	}
+
+	return screens, rows.Err()
+}
+
+func scanScreenRunRows(rows *sql.Rows) ([]entities.ScreenRun, error) {
+	var runs []entities.ScreenRun
+	for rows.Next() {
+		var run entities.ScreenRun
+		var results []byte
+		err := rows.Scan(
+			&run.ID,
+			&run.ScreenID,
+			&run.Trigger,
+			&run.RunAt,
+			&results,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(results, &run.Results); err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+
+	return runs, rows.Err()
+}
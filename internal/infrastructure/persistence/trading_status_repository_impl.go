@@ -0,0 +1,173 @@
+package persistence
+
+import (
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/repositories"
+	"database/sql"
+	"strings"
+	"time"
+)
+
+type tradingStatusRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewTradingStatusRepository creates a new trading status repository
+func NewTradingStatusRepository(db *sql.DB) repositories.TradingStatusRepository {
+	return &tradingStatusRepositoryImpl{db: db}
+}
+
+const tradingStatusColumns = `id, emiten, status, notations, watchlist, reason, effective_from, effective_to, created_at, updated_at`
+
+// notationSeparator joins the special notations stored in one column
+const notationSeparator = ","
+
+// Create inserts a status and sets its ID
+func (r *tradingStatusRepositoryImpl) Create(s *entities.TradingStatus) error {
+	result, err := r.db.Exec(`
+		INSERT INTO trading_statuses (emiten, status, notations, watchlist, reason, effective_from, effective_to, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, s.Emiten, s.Status, strings.Join(s.Notations, notationSeparator), s.Watchlist, s.Reason,
+		s.EffectiveFrom, s.EffectiveTo, s.CreatedAt, s.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	s.ID, err = result.LastInsertId()
+	return err
+}
+
+// Update saves the status, notations, watchlist flag, reason and date range
+// of a status
+func (r *tradingStatusRepositoryImpl) Update(s *entities.TradingStatus) error {
+	_, err := r.db.Exec(`
+		UPDATE trading_statuses
+		SET status = ?, notations = ?, watchlist = ?, reason = ?, effective_from = ?, effective_to = ?, updated_at = ?
+		WHERE id = ?
+	`, s.Status, strings.Join(s.Notations, notationSeparator), s.Watchlist, s.Reason,
+		s.EffectiveFrom, s.EffectiveTo, s.UpdatedAt, s.ID)
+	return err
+}
+
+// Upsert inserts or updates statuses in a single transaction
+func (r *tradingStatusRepositoryImpl) Upsert(statuses []entities.TradingStatus) error {
+	if len(statuses) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO trading_statuses (emiten, status, notations, watchlist, reason, effective_from, effective_to, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			status = VALUES(status),
+			notations = VALUES(notations),
+			watchlist = VALUES(watchlist),
+			reason = VALUES(reason),
+			effective_to = VALUES(effective_to),
+			updated_at = VALUES(updated_at)
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, s := range statuses {
+		_, err := stmt.Exec(s.Emiten, s.Status, strings.Join(s.Notations, notationSeparator), s.Watchlist, s.Reason,
+			s.EffectiveFrom, s.EffectiveTo, s.CreatedAt, s.UpdatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Delete removes a status
+func (r *tradingStatusRepositoryImpl) Delete(id int64) error {
+	_, err := r.db.Exec(`DELETE FROM trading_statuses WHERE id = ?`, id)
+	return err
+}
+
+// FindByID retrieves a status, or nil if it does not exist
+func (r *tradingStatusRepositoryImpl) FindByID(id int64) (*entities.TradingStatus, error) {
+	rows, err := r.db.Query(`SELECT `+tradingStatusColumns+` FROM trading_statuses WHERE id = ?`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	statuses, err := scanTradingStatusRows(rows)
+	if err != nil || len(statuses) == 0 {
+		return nil, err
+	}
+	return &statuses[0], nil
+}
+
+// FindByEmiten retrieves the statuses of an emiten
+func (r *tradingStatusRepositoryImpl) FindByEmiten(emiten string) ([]entities.TradingStatus, error) {
+	rows, err := r.db.Query(`
+		SELECT `+tradingStatusColumns+`
+		FROM trading_statuses
+		WHERE emiten = ?
+		ORDER BY effective_from
+	`, emiten)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanTradingStatusRows(rows)
+}
+
+// FindByRange retrieves the statuses covering any date within [from, to]
+func (r *tradingStatusRepositoryImpl) FindByRange(from, to time.Time) ([]entities.TradingStatus, error) {
+	rows, err := r.db.Query(`
+		SELECT `+tradingStatusColumns+`
+		FROM trading_statuses
+		WHERE effective_from <= ?
+		  AND (effective_to IS NULL OR effective_to >= ?)
+		ORDER BY emiten, effective_from
+	`, to, from)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanTradingStatusRows(rows)
+}
+
+func scanTradingStatusRows(rows *sql.Rows) ([]entities.TradingStatus, error) {
+	var statuses []entities.TradingStatus
+	for rows.Next() {
+		var s entities.TradingStatus
+		var notations string
+		err := rows.Scan(
+			&s.ID,
+			&s.Emiten,
+			&s.Status,
+			&notations,
+			&s.Watchlist,
+			&s.Reason,
+			&s.EffectiveFrom,
+			&s.EffectiveTo,
+			&s.CreatedAt,
+			&s.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		s.Notations = []string{}
+		if notations != "" {
+			s.Notations = strings.Split(notations, notationSeparator)
+		}
+		statuses = append(statuses, s)
+	}
+
+	return statuses, rows.Err()
+}
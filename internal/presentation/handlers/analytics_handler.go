@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/application/usecases"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AnalyticsHandler struct {
+	useCase *usecases.AnalyticsUseCase
+}
+
+func NewAnalyticsHandler(useCase *usecases.AnalyticsUseCase) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		useCase: useCase,
+	}
+}
+
+// Correlation handles POST /api/v1/analytics/correlation
+// Computes correlation and covariance matrices of daily log returns
+func (h *AnalyticsHandler) Correlation(c *gin.Context) {
+	var req dto.CorrelationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	correlation, err := h.useCase.Correlation(req)
+	if err != nil {
+		writeAnalyticsError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, correlation)
+}
+
+// GetRisk handles GET /api/v1/market-data/emiten/:emiten/risk
+// Computes volatility, drawdown and beta over the recent daily history
+func (h *AnalyticsHandler) GetRisk(c *gin.Context) {
+	var req dto.RiskRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	risk, err := h.useCase.GetRisk(c.Param("emiten"), req)
+	if err != nil {
+		writeAnalyticsError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, risk)
+}
+
+// GetSummary handles GET /api/v1/market-data/emiten/:emiten/summary
+// Summarizes the last session against 52-week, average and all-time levels
+func (h *AnalyticsHandler) GetSummary(c *gin.Context) {
+	summary, err := h.useCase.GetSummary(c.Param("emiten"))
+	if err != nil {
+		writeAnalyticsError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// Compare handles GET /api/v1/analytics/compare
+// Rebases several emitens to a common start value for comparison
+func (h *AnalyticsHandler) Compare(c *gin.Context) {
+	var req dto.CompareRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	from, to, err := parseTimeRange(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	comparison, err := h.useCase.Compare(req, from, to)
+	if err != nil {
+		writeAnalyticsError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, comparison)
+}
+
+func writeAnalyticsError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrInvalidAnalytics), errors.Is(err, usecases.ErrInvalidTimeRange):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+	case errors.Is(err, usecases.ErrNoMarketData), errors.Is(err, usecases.ErrEmitenNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "not_found",
+			Message: err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "internal_error",
+			Message: err.Error(),
+		})
+	}
+}
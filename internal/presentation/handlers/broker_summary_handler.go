@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/application/usecases"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BrokerSummaryHandler struct {
+	useCase *usecases.BrokerSummaryUseCase
+}
+
+func NewBrokerSummaryHandler(useCase *usecases.BrokerSummaryUseCase) *BrokerSummaryHandler {
+	return &BrokerSummaryHandler{
+		useCase: useCase,
+	}
+}
+
+// TopBrokers handles GET /api/v1/market-data/emiten/:emiten/brokers
+// Ranks the brokers of an emiten by net buy and net sell value over a range
+// (?from=, ?to=; defaults to the latest session) up to ?limit= each
+func (h *BrokerSummaryHandler) TopBrokers(c *gin.Context) {
+	from, to, err := parseTimeRange(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	ranking, err := h.useCase.TopBrokers(c.Param("emiten"), from, to, limit)
+	if err != nil {
+		writeBrokerSummaryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ranking)
+}
+
+// Accumulation handles GET /api/v1/brokers/:code/accumulation
+// Ranks the emitens a broker net bought and net sold most over a range
+// (?from=, ?to=; defaults to the latest session) up to ?limit= each
+func (h *BrokerSummaryHandler) Accumulation(c *gin.Context) {
+	from, to, err := parseTimeRange(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	accumulation, err := h.useCase.Accumulation(c.Param("code"), from, to, limit)
+	if err != nil {
+		writeBrokerSummaryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, accumulation)
+}
+
+// Ingest handles POST /api/v1/broker-summaries
+// Records a JSON batch of daily broker summaries (admin only)
+func (h *BrokerSummaryHandler) Ingest(c *gin.Context) {
+	var req dto.BrokerSummaryBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	result, err := h.useCase.Ingest(req.Data)
+	if err != nil {
+		writeBrokerSummaryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Import handles POST /api/v1/broker-summaries/import
+// Records daily broker summaries from a CSV sent as a multipart "file" field
+// or as a text/csv body (admin only)
+func (h *BrokerSummaryHandler) Import(c *gin.Context) {
+	body, ok := csvBody(c)
+	if !ok {
+		return
+	}
+	defer body.Close()
+
+	result, err := h.useCase.Import(body)
+	if err != nil {
+		writeBrokerSummaryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func writeBrokerSummaryError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrInvalidBrokerSummary), errors.Is(err, usecases.ErrInvalidCSV),
+		errors.Is(err, usecases.ErrInvalidTimeRange):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "internal_error",
+			Message: err.Error(),
+		})
+	}
+}
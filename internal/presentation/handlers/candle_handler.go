@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/application/usecases"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CandleHandler struct {
+	useCase *usecases.CandleUseCase
+}
+
+func NewCandleHandler(useCase *usecases.CandleUseCase) *CandleHandler {
+	return &CandleHandler{
+		useCase: useCase,
+	}
+}
+
+// GetCandles handles GET /api/v1/market-data/emiten/:emiten/candles
+// Rolls market data snapshots of an emiten into OHLC candles
+func (h *CandleHandler) GetCandles(c *gin.Context) {
+	emiten := c.Param("emiten")
+
+	from, to, err := parseTimeRange(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	adjusted, ok := parseAdjusted(c)
+	if !ok {
+		return
+	}
+
+	candles, err := h.useCase.GetCandles(emiten, c.DefaultQuery("interval", "1d"), from, to, adjusted)
+	if err != nil {
+		if errors.Is(err, usecases.ErrInvalidCandleInterval) || errors.Is(err, usecases.ErrInvalidTimeRange) {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "invalid_request",
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "not_found",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, candles)
+}
+
+// ListQuarantine handles GET /api/v1/market-data/quarantine
+// Lists the snapshots held back from the rollups, optionally for one emiten
+func (h *CandleHandler) ListQuarantine(c *gin.Context) {
+	from, to, err := parseTimeRange(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	quarantine, err := h.useCase.ListQuarantine(c.Query("emiten"), from, to)
+	if err != nil {
+		if errors.Is(err, usecases.ErrInvalidTimeRange) {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "invalid_request",
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "internal_error",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, quarantine)
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/application/usecases"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CorporateActionHandler struct {
+	useCase *usecases.CorporateActionUseCase
+}
+
+func NewCorporateActionHandler(useCase *usecases.CorporateActionUseCase) *CorporateActionHandler {
+	return &CorporateActionHandler{
+		useCase: useCase,
+	}
+}
+
+// List handles GET /api/v1/corporate-actions
+// Retrieves corporate actions by ex-date (?emiten=, ?from=, ?to=)
+func (h *CorporateActionHandler) List(c *gin.Context) {
+	from, to, err := parseTimeRange(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	actions, err := h.useCase.List(c.Query("emiten"), from, to)
+	if err != nil {
+		writeCorporateActionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, actions)
+}
+
+// Get handles GET /api/v1/corporate-actions/:id
+// Retrieves a corporate action
+func (h *CorporateActionHandler) Get(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	action, err := h.useCase.Get(id)
+	if err != nil {
+		writeCorporateActionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, action)
+}
+
+// Create handles POST /api/v1/corporate-actions
+// Records a corporate action (admin only)
+func (h *CorporateActionHandler) Create(c *gin.Context) {
+	var req dto.CorporateActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	action, err := h.useCase.Create(req)
+	if err != nil {
+		writeCorporateActionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, action)
+}
+
+// Update handles PUT /api/v1/corporate-actions/:id
+// Replaces the type, ex-date and terms of a corporate action (admin only)
+func (h *CorporateActionHandler) Update(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.CorporateActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	action, err := h.useCase.Update(id, req)
+	if err != nil {
+		writeCorporateActionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, action)
+}
+
+// Delete handles DELETE /api/v1/corporate-actions/:id
+// Removes a corporate action (admin only)
+func (h *CorporateActionHandler) Delete(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.useCase.Delete(id); err != nil {
+		writeCorporateActionError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Import handles POST /api/v1/corporate-actions/import
+// Records corporate actions from a CSV sent as a multipart "file" field or
+// as a text/csv body (admin only)
+func (h *CorporateActionHandler) Import(c *gin.Context) {
+	body, ok := csvBody(c)
+	if !ok {
+		return
+	}
+	defer body.Close()
+
+	result, err := h.useCase.Import(body)
+	if err != nil {
+		writeCorporateActionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func writeCorporateActionError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrInvalidCorporateAction), errors.Is(err, usecases.ErrInvalidCSV),
+		errors.Is(err, usecases.ErrInvalidTimeRange):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+	case errors.Is(err, usecases.ErrCorporateActionNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "not_found",
+			Message: err.Error(),
+		})
+	case errors.Is(err, usecases.ErrCorporateActionExists):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error:   "conflict",
+			Message: err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "internal_error",
+			Message: err.Error(),
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/application/usecases"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DividendHandler struct {
+	useCase *usecases.DividendUseCase
+}
+
+func NewDividendHandler(useCase *usecases.DividendUseCase) *DividendHandler {
+	return &DividendHandler{
+		useCase: useCase,
+	}
+}
+
+// Upcoming handles GET /api/v1/dividends/upcoming
+// Retrieves the dividends with a cum, ex, recording or payment date in a range
+// (?from=, ?to=; defaults to the next 90 days)
+func (h *DividendHandler) Upcoming(c *gin.Context) {
+	from, to, err := parseTimeRange(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	calendar, err := h.useCase.Upcoming(from, to)
+	if err != nil {
+		writeCorporateActionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, calendar)
+}
+
+// History handles GET /api/v1/market-data/emiten/:emiten/dividends
+// Retrieves the dividend history of an emiten with its trailing-12-month yield
+func (h *DividendHandler) History(c *gin.Context) {
+	history, err := h.useCase.History(c.Param("emiten"))
+	if err != nil {
+		writeCorporateActionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// Yields handles GET /api/v1/dividends/yields
+// Ranks emitens by trailing-12-month dividend yield
+func (h *DividendHandler) Yields(c *gin.Context) {
+	yields, err := h.useCase.Yields()
+	if err != nil {
+		writeCorporateActionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, yields)
+}
@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/application/usecases"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EmitenHandler struct {
+	useCase *usecases.EmitenUseCase
+}
+
+func NewEmitenHandler(useCase *usecases.EmitenUseCase) *EmitenHandler {
+	return &EmitenHandler{
+		useCase: useCase,
+	}
+}
+
+// List handles GET /api/v1/emitens
+// Retrieves the master data of every emiten
+func (h *EmitenHandler) List(c *gin.Context) {
+	emitens, err := h.useCase.List()
+	if err != nil {
+		writeEmitenError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, emitens)
+}
+
+// Search handles GET /api/v1/emitens/search
+// Finds emitens by ticker prefix or company name (?q=, ?limit=)
+func (h *EmitenHandler) Search(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "q is required",
+		})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	results, err := h.useCase.Search(query, limit)
+	if err != nil {
+		writeEmitenError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+// Get handles GET /api/v1/emitens/:ticker
+// Retrieves the master data of a single emiten
+func (h *EmitenHandler) Get(c *gin.Context) {
+	emiten, err := h.useCase.Get(c.Param("ticker"))
+	if err != nil {
+		writeEmitenError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, emiten)
+}
+
+// Create handles POST /api/v1/emitens
+// Adds a new emiten (admin only)
+func (h *EmitenHandler) Create(c *gin.Context) {
+	var req dto.EmitenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	emiten, err := h.useCase.Create(req)
+	if err != nil {
+		writeEmitenError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, emiten)
+}
+
+// Update handles PUT /api/v1/emitens/:ticker
+// Replaces the master data of an emiten (admin only)
+func (h *EmitenHandler) Update(c *gin.Context) {
+	var req dto.EmitenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	emiten, err := h.useCase.Update(c.Param("ticker"), req)
+	if err != nil {
+		writeEmitenError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, emiten)
+}
+
+// Delete handles DELETE /api/v1/emitens/:ticker
+// Removes an emiten (admin only)
+func (h *EmitenHandler) Delete(c *gin.Context) {
+	if err := h.useCase.Delete(c.Param("ticker")); err != nil {
+		writeEmitenError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Import handles POST /api/v1/emitens/import
+// Upserts emitens from a CSV sent as a multipart "file" field or as a
+// text/csv body (admin only)
+func (h *EmitenHandler) Import(c *gin.Context) {
+	body, ok := csvBody(c)
+	if !ok {
+		return
+	}
+	defer body.Close()
+
+	result, err := h.useCase.Import(body)
+	if err != nil {
+		writeEmitenError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// csvBody returns the CSV document of an import request, writing a 400
+// response when the request carries none
+func csvBody(c *gin.Context) (io.ReadCloser, bool) {
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		header, err := c.FormFile("file")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "invalid_request",
+				Message: "multipart field \"file\" is required",
+			})
+			return nil, false
+		}
+		file, err := header.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "invalid_request",
+				Message: err.Error(),
+			})
+			return nil, false
+		}
+		return file, true
+	}
+
+	if c.Request.Body == nil || c.Request.ContentLength == 0 {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "CSV body is required",
+		})
+		return nil, false
+	}
+	return c.Request.Body, true
+}
+
+func writeEmitenError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrInvalidEmiten), errors.Is(err, usecases.ErrInvalidCSV):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+	case errors.Is(err, usecases.ErrEmitenNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "not_found",
+			Message: err.Error(),
+		})
+	case errors.Is(err, usecases.ErrEmitenExists):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error:   "conflict",
+			Message: err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "internal_error",
+			Message: err.Error(),
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/application/usecases"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ForeignFlowHandler struct {
+	useCase *usecases.ForeignFlowUseCase
+}
+
+func NewForeignFlowHandler(useCase *usecases.ForeignFlowUseCase) *ForeignFlowHandler {
+	return &ForeignFlowHandler{
+		useCase: useCase,
+	}
+}
+
+// History handles GET /api/v1/market-data/emiten/:emiten/foreign-flow
+// Retrieves the daily foreign buys, sells and net flow of an emiten
+// (?from=, ?to=; defaults to the last 30 days)
+func (h *ForeignFlowHandler) History(c *gin.Context) {
+	from, to, err := parseTimeRange(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	history, err := h.useCase.History(c.Param("emiten"), from, to)
+	if err != nil {
+		writeForeignFlowError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// Rankings handles GET /api/v1/foreign-flows/rankings
+// Ranks emitens by foreign net buy and net sell value over a range (?from=,
+// ?to=; defaults to the latest session) up to ?limit= each
+func (h *ForeignFlowHandler) Rankings(c *gin.Context) {
+	from, to, err := parseTimeRange(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	rankings, err := h.useCase.Rankings(from, to, limit)
+	if err != nil {
+		writeForeignFlowError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rankings)
+}
+
+// Ingest handles POST /api/v1/foreign-flows
+// Records a JSON batch of daily foreign flows (admin only)
+func (h *ForeignFlowHandler) Ingest(c *gin.Context) {
+	var req dto.ForeignFlowBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	result, err := h.useCase.Ingest(req.Data)
+	if err != nil {
+		writeForeignFlowError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Import handles POST /api/v1/foreign-flows/import
+// Records daily foreign flows from a CSV sent as a multipart "file" field or
+// as a text/csv body (admin only)
+func (h *ForeignFlowHandler) Import(c *gin.Context) {
+	body, ok := csvBody(c)
+	if !ok {
+		return
+	}
+	defer body.Close()
+
+	result, err := h.useCase.Import(body)
+	if err != nil {
+		writeForeignFlowError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func writeForeignFlowError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrInvalidForeignFlow), errors.Is(err, usecases.ErrInvalidCSV),
+		errors.Is(err, usecases.ErrInvalidTimeRange):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "internal_error",
+			Message: err.Error(),
+		})
+	}
+}
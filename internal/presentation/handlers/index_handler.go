@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/application/usecases"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IndexHandler struct {
+	useCase *usecases.IndexUseCase
+}
+
+func NewIndexHandler(useCase *usecases.IndexUseCase) *IndexHandler {
+	return &IndexHandler{
+		useCase: useCase,
+	}
+}
+
+// List handles GET /api/v1/indices
+// Retrieves every composite index with its current constituents
+func (h *IndexHandler) List(c *gin.Context) {
+	indices, err := h.useCase.List()
+	if err != nil {
+		writeIndexError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, indices)
+}
+
+// Get handles GET /api/v1/indices/:code
+// Retrieves a composite index with its current constituents
+func (h *IndexHandler) Get(c *gin.Context) {
+	index, err := h.useCase.Get(c.Param("code"))
+	if err != nil {
+		writeIndexError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, index)
+}
+
+// Create handles POST /api/v1/indices
+// Defines a composite index (admin only)
+func (h *IndexHandler) Create(c *gin.Context) {
+	var req dto.IndexRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	index, err := h.useCase.Create(req)
+	if err != nil {
+		writeIndexError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, index)
+}
+
+// Update handles PUT /api/v1/indices/:code
+// Renames a composite index (admin only)
+func (h *IndexHandler) Update(c *gin.Context) {
+	var req dto.IndexUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	index, err := h.useCase.Update(c.Param("code"), req)
+	if err != nil {
+		writeIndexError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, index)
+}
+
+// Delete handles DELETE /api/v1/indices/:code
+// Removes a composite index and its history (admin only)
+func (h *IndexHandler) Delete(c *gin.Context) {
+	if err := h.useCase.Delete(c.Param("code")); err != nil {
+		writeIndexError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Rebalance handles PUT /api/v1/indices/:code/constituents
+// Replaces the constituents from an effective date, adjusting the divisor
+// (admin only)
+func (h *IndexHandler) Rebalance(c *gin.Context) {
+	var req dto.IndexRebalanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	index, err := h.useCase.Rebalance(c.Param("code"), req)
+	if err != nil {
+		writeIndexError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, index)
+}
+
+// ListDivisors handles GET /api/v1/indices/:code/divisors
+// Retrieves the constituent and divisor history of an index
+func (h *IndexHandler) ListDivisors(c *gin.Context) {
+	divisors, err := h.useCase.ListDivisors(c.Param("code"))
+	if err != nil {
+		writeIndexError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, divisors)
+}
+
+// GetSeries handles GET /api/v1/indices/:code/series
+// Computes the daily OHLC series of an index
+func (h *IndexHandler) GetSeries(c *gin.Context) {
+	from, to, err := parseTimeRange(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	series, err := h.useCase.GetSeries(c.Param("code"), from, to)
+	if err != nil {
+		writeIndexError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+// GetLatest handles GET /api/v1/indices/:code/latest
+// Computes the most recent session of an index
+func (h *IndexHandler) GetLatest(c *gin.Context) {
+	latest, err := h.useCase.GetLatest(c.Param("code"))
+	if err != nil {
+		writeIndexError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, latest)
+}
+
+func writeIndexError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrInvalidIndex), errors.Is(err, usecases.ErrInvalidTimeRange):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+	case errors.Is(err, usecases.ErrIndexNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "not_found",
+			Message: err.Error(),
+		})
+	case errors.Is(err, usecases.ErrIndexExists):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error:   "conflict",
+			Message: err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "internal_error",
+			Message: err.Error(),
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/application/usecases"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IndexMembershipHandler struct {
+	useCase *usecases.IndexMembershipUseCase
+}
+
+func NewIndexMembershipHandler(useCase *usecases.IndexMembershipUseCase) *IndexMembershipHandler {
+	return &IndexMembershipHandler{
+		useCase: useCase,
+	}
+}
+
+// Members handles GET /api/v1/indices/:code/members
+// Retrieves the members of an index on a date (?as_of=, default today)
+func (h *IndexMembershipHandler) Members(c *gin.Context) {
+	asOf, err := parseTimeQuery(c.Query("as_of"), false)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "invalid as_of: " + err.Error(),
+		})
+		return
+	}
+
+	members, err := h.useCase.Members(c.Param("code"), asOf)
+	if err != nil {
+		writeMembershipError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, members)
+}
+
+// List handles GET /api/v1/indices/:code/memberships
+// Retrieves the membership history of an index (?emiten= to filter)
+func (h *IndexMembershipHandler) List(c *gin.Context) {
+	memberships, err := h.useCase.List(c.Param("code"), c.Query("emiten"))
+	if err != nil {
+		writeMembershipError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, memberships)
+}
+
+// Create handles POST /api/v1/index-memberships
+// Records an index membership (admin only)
+func (h *IndexMembershipHandler) Create(c *gin.Context) {
+	var req dto.IndexMembershipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	membership, err := h.useCase.Create(req)
+	if err != nil {
+		writeMembershipError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, membership)
+}
+
+// Update handles PUT /api/v1/index-memberships/:id
+// Replaces the emiten and date range of a membership (admin only)
+func (h *IndexMembershipHandler) Update(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.IndexMembershipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	membership, err := h.useCase.Update(id, req)
+	if err != nil {
+		writeMembershipError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, membership)
+}
+
+// Delete handles DELETE /api/v1/index-memberships/:id
+// Removes a membership (admin only)
+func (h *IndexMembershipHandler) Delete(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.useCase.Delete(id); err != nil {
+		writeMembershipError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Import handles POST /api/v1/index-memberships/import
+// Records memberships from a CSV sent as a multipart "file" field or as a
+// text/csv body (admin only)
+func (h *IndexMembershipHandler) Import(c *gin.Context) {
+	body, ok := csvBody(c)
+	if !ok {
+		return
+	}
+	defer body.Close()
+
+	result, err := h.useCase.Import(body)
+	if err != nil {
+		writeMembershipError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func writeMembershipError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrInvalidMembership), errors.Is(err, usecases.ErrInvalidCSV):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+	case errors.Is(err, usecases.ErrMembershipNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "not_found",
+			Message: err.Error(),
+		})
+	case errors.Is(err, usecases.ErrMembershipOverlap):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error:   "conflict",
+			Message: err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "internal_error",
+			Message: err.Error(),
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/application/usecases"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IndicatorHandler struct {
+	useCase *usecases.IndicatorUseCase
+}
+
+func NewIndicatorHandler(useCase *usecases.IndicatorUseCase) *IndicatorHandler {
+	return &IndicatorHandler{
+		useCase: useCase,
+	}
+}
+
+// GetIndicator handles GET /api/v1/market-data/emiten/:emiten/indicators
+// Computes a technical indicator over the daily closes of an emiten
+func (h *IndicatorHandler) GetIndicator(c *gin.Context) {
+	emiten := c.Param("emiten")
+
+	var req dto.IndicatorRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	from, to, err := parseTimeRange(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	response, err := h.useCase.GetIndicator(emiten, req, from, to)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecases.ErrInvalidIndicator), errors.Is(err, usecases.ErrInvalidTimeRange):
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "invalid_request",
+				Message: err.Error(),
+			})
+		case errors.Is(err, usecases.ErrIndicatorUnavailable):
+			c.JSON(http.StatusUnprocessableEntity, dto.ErrorResponse{
+				Error:   "unavailable",
+				Message: err.Error(),
+			})
+		default:
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:   "not_found",
+				Message: err.Error(),
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -21,7 +21,12 @@ func NewMarketDataHandler(useCase *usecases.MarketDataUseCase) *MarketDataHandle
 // GetAll handles GET /api/v1/market-data
 // Retrieves all market data from v_latest_market_data view
 func (h *MarketDataHandler) GetAll(c *gin.Context) {
-	marketDataList, err := h.useCase.GetAll()
+	query, ok := parseMarketDataQuery(c)
+	if !ok {
+		return
+	}
+
+	marketDataList, err := h.useCase.GetAll(query)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error:   "internal_error",
@@ -38,7 +43,17 @@ func (h *MarketDataHandler) GetAll(c *gin.Context) {
 func (h *MarketDataHandler) GetByEmiten(c *gin.Context) {
 	emiten := c.Param("emiten")
 
-	marketDataList, err := h.useCase.GetByEmiten(emiten)
+	query, ok := parseMarketDataQuery(c)
+	if !ok {
+		return
+	}
+	adjusted, ok := parseAdjusted(c)
+	if !ok {
+		return
+	}
+	query.Adjusted = adjusted
+
+	marketDataList, err := h.useCase.GetByEmiten(emiten, query)
 	if err != nil {
 		c.JSON(http.StatusNotFound, dto.ErrorResponse{
 			Error:   "not_found",
@@ -55,7 +70,12 @@ func (h *MarketDataHandler) GetByEmiten(c *gin.Context) {
 func (h *MarketDataHandler) GetLatestByEmiten(c *gin.Context) {
 	emiten := c.Param("emiten")
 
-	marketData, err := h.useCase.GetLatestByEmiten(emiten)
+	query, ok := parseLatestQuery(c)
+	if !ok {
+		return
+	}
+
+	marketData, err := h.useCase.GetLatestByEmiten(emiten, query)
 	if err != nil {
 		c.JSON(http.StatusNotFound, dto.ErrorResponse{
 			Error:   "not_found",
@@ -70,7 +90,12 @@ func (h *MarketDataHandler) GetLatestByEmiten(c *gin.Context) {
 // GetLatestByAllEmiten handles GET /api/v1/market-data/latest
 // Retrieves the latest market data for all emitens
 func (h *MarketDataHandler) GetLatestByAllEmiten(c *gin.Context) {
-	marketDataList, err := h.useCase.GetLatestByAllEmiten()
+	query, ok := parseLatestQuery(c)
+	if !ok {
+		return
+	}
+
+	marketDataList, err := h.useCase.GetLatestByAllEmiten(query)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error:   "internal_error",
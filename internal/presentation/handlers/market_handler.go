@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/application/usecases"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MarketHandler struct {
+	useCase *usecases.MarketUseCase
+}
+
+func NewMarketHandler(useCase *usecases.MarketUseCase) *MarketHandler {
+	return &MarketHandler{
+		useCase: useCase,
+	}
+}
+
+// GetStatus handles GET /api/v1/market/status
+// Reports the current IDX trading phase and the next open and close
+func (h *MarketHandler) GetStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, h.useCase.GetStatus(time.Now()))
+}
+
+// GetSectors handles GET /api/v1/market/sectors
+// Aggregates the latest price moves per sector for the heatmap
+func (h *MarketHandler) GetSectors(c *gin.Context) {
+	sectors, err := h.useCase.GetSectors()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "internal_error",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, sectors)
+}
+
+// GetAutoRejections handles GET /api/v1/market/auto-rejections
+// Lists the emitens at their ARA or ARB limit in the latest session
+func (h *MarketHandler) GetAutoRejections(c *gin.Context) {
+	autoRejections, err := h.useCase.GetAutoRejections()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "internal_error",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, autoRejections)
+}
+
+// GetBreadth handles GET /api/v1/market/breadth
+// Computes a daily market breadth series with the advance/decline line
+func (h *MarketHandler) GetBreadth(c *gin.Context) {
+	from, to, err := parseTimeRange(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	breadth, err := h.useCase.GetBreadth(from, to)
+	if err != nil {
+		if errors.Is(err, usecases.ErrInvalidTimeRange) {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "invalid_request",
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "internal_error",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, breadth)
+}
+
+// RoundTick handles GET /api/v1/market/ticks/round
+// Rounds a price to the IDX tick grid (mode=nearest|down|up)
+func (h *MarketHandler) RoundTick(c *gin.Context) {
+	price, err := strconv.ParseFloat(c.Query("price"), 64)
+	if err != nil {
+		writeTickError(c, fmt.Errorf("%w: price must be a number", usecases.ErrInvalidTickRequest))
+		return
+	}
+
+	rounded, err := h.useCase.RoundTick(price, c.Query("mode"))
+	if err != nil {
+		writeTickError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rounded)
+}
+
+// ListTicks handles GET /api/v1/market/ticks
+// Lists the valid IDX prices between from and to
+func (h *MarketHandler) ListTicks(c *gin.Context) {
+	from, err := strconv.ParseFloat(c.Query("from"), 64)
+	if err != nil {
+		writeTickError(c, fmt.Errorf("%w: from must be a number", usecases.ErrInvalidTickRequest))
+		return
+	}
+	to, err := strconv.ParseFloat(c.Query("to"), 64)
+	if err != nil {
+		writeTickError(c, fmt.Errorf("%w: to must be a number", usecases.ErrInvalidTickRequest))
+		return
+	}
+
+	ticks, err := h.useCase.ListTicks(from, to)
+	if err != nil {
+		writeTickError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ticks)
+}
+
+func writeTickError(c *gin.Context, err error) {
+	if errors.Is(err, usecases.ErrInvalidTickRequest) {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+		Error:   "internal_error",
+		Message: err.Error(),
+	})
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/application/usecases"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OrderBookHandler struct {
+	useCase *usecases.OrderBookUseCase
+}
+
+func NewOrderBookHandler(useCase *usecases.OrderBookUseCase) *OrderBookHandler {
+	return &OrderBookHandler{
+		useCase: useCase,
+	}
+}
+
+// Latest handles GET /api/v1/market-data/emiten/:emiten/order-book
+// Retrieves the latest depth snapshot of an emiten (?as_of=, default now)
+// with its spread, mid price and the imbalance of the best ?depth= levels
+func (h *OrderBookHandler) Latest(c *gin.Context) {
+	asOf, err := parseTimeQuery(c.Query("as_of"), true)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "invalid as_of: " + err.Error(),
+		})
+		return
+	}
+
+	var depth int
+	if value := c.Query("depth"); value != "" {
+		depth, err = strconv.Atoi(value)
+		if err != nil || depth < 1 {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "invalid_request",
+				Message: "depth must be a positive integer",
+			})
+			return
+		}
+	}
+
+	book, err := h.useCase.Latest(c.Param("emiten"), asOf, depth)
+	if err != nil {
+		writeOrderBookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, book)
+}
+
+// Ingest handles POST /api/v1/order-books
+// Records a JSON batch of depth snapshots (admin only)
+func (h *OrderBookHandler) Ingest(c *gin.Context) {
+	var req dto.OrderBookBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	result, err := h.useCase.Ingest(req.Data)
+	if err != nil {
+		writeOrderBookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func writeOrderBookError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrInvalidOrderBook):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+	case errors.Is(err, usecases.ErrOrderBookNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "not_found",
+			Message: err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "internal_error",
+			Message: err.Error(),
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/domain/services"
+)
+
+const dateLayout = "2006-01-02"
+
+// parseTimeRange reads the optional from and to query parameters. Both accept
+// RFC3339 timestamps or plain dates in WIB; a plain to date covers the whole day.
+// Missing parameters are returned as zero times.
+func parseTimeRange(c *gin.Context) (time.Time, time.Time, error) {
+	from, err := parseTimeQuery(c.Query("from"), false)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid from: %w", err)
+	}
+
+	to, err := parseTimeQuery(c.Query("to"), true)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid to: %w", err)
+	}
+
+	return from, to, nil
+}
+
+func parseTimeQuery(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.ParseInLocation(dateLayout, value, services.WIB)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected YYYY-MM-DD or RFC3339, got %q", value)
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+	return t, nil
+}
+
+// parseIDParam reads a positive integer path parameter, writing a 400
+// response and returning false when it is malformed
+func parseIDParam(c *gin.Context, name string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param(name), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: name + " must be a positive integer",
+		})
+		return 0, false
+	}
+	return id, true
+}
+
+// parseMarketDataQuery reads the optional parameters shared by the market
+// data endpoints, e.g. ?include=company,limits&exclude=suspended, writing a
+// 400 response and returning false when exclude names an unknown status
+func parseMarketDataQuery(c *gin.Context) (dto.MarketDataQuery, bool) {
+	var query dto.MarketDataQuery
+	for _, include := range strings.Split(c.Query("include"), ",") {
+		switch strings.TrimSpace(include) {
+		case "company":
+			query.IncludeCompany = true
+		case "limits":
+			query.IncludeLimits = true
+		}
+	}
+	for _, exclude := range strings.Split(c.Query("exclude"), ",") {
+		switch exclude = strings.TrimSpace(exclude); exclude {
+		case "":
+		case "suspended":
+			query.ExcludeSuspended = true
+		case "delisted":
+			query.ExcludeDelisted = true
+		case "notations":
+			query.ExcludeNotations = true
+		case "watchlist":
+			query.ExcludeWatchlist = true
+		default:
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "invalid_request",
+				Message: fmt.Sprintf("unknown exclude %q, expected suspended, delisted, notations or watchlist", exclude),
+			})
+			return query, false
+		}
+	}
+	return query, true
+}
+
+// parseAdjusted reads the optional adjusted flag of the history endpoints,
+// writing a 400 response and returning false when it is not a boolean
+func parseAdjusted(c *gin.Context) (bool, bool) {
+	value := c.Query("adjusted")
+	if value == "" {
+		return false, true
+	}
+	adjusted, err := strconv.ParseBool(value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "adjusted must be true or false",
+		})
+		return false, false
+	}
+	return adjusted, true
+}
+
+// parseLatestQuery reads the market data query of the latest endpoints,
+// including as_of, writing a 400 response and returning false when as_of or
+// exclude is malformed. A date-only as_of means the end of that day in WIB.
+func parseLatestQuery(c *gin.Context) (dto.MarketDataQuery, bool) {
+	query, ok := parseMarketDataQuery(c)
+	if !ok {
+		return query, false
+	}
+
+	asOf, err := parseTimeQuery(c.Query("as_of"), true)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "invalid as_of: " + err.Error(),
+		})
+		return query, false
+	}
+	query.AsOf = asOf
+	return query, true
+}
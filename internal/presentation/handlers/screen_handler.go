@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/application/usecases"
+	"api-web-scrapping/internal/presentation/middleware"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ScreenHandler struct {
+	useCase *usecases.ScreenUseCase
+}
+
+func NewScreenHandler(useCase *usecases.ScreenUseCase) *ScreenHandler {
+	return &ScreenHandler{
+		useCase: useCase,
+	}
+}
+
+// Create handles POST /api/v1/screens
+// Saves a named screen for the authenticated user
+func (h *ScreenHandler) Create(c *gin.Context) {
+	var req dto.ScreenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	screen, err := h.useCase.Create(c.GetString(middleware.ContextUserID), req)
+	if err != nil {
+		writeScreenError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, screen)
+}
+
+// List handles GET /api/v1/screens
+// Retrieves the screens of the authenticated user
+func (h *ScreenHandler) List(c *gin.Context) {
+	screens, err := h.useCase.List(c.GetString(middleware.ContextUserID))
+	if err != nil {
+		writeScreenError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, screens)
+}
+
+// Get handles GET /api/v1/screens/:id
+// Retrieves a single screen
+func (h *ScreenHandler) Get(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	screen, err := h.useCase.Get(c.GetString(middleware.ContextUserID), id)
+	if err != nil {
+		writeScreenError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, screen)
+}
+
+// Update handles PUT /api/v1/screens/:id
+// Replaces the definition and schedule of a screen
+func (h *ScreenHandler) Update(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.ScreenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	screen, err := h.useCase.Update(c.GetString(middleware.ContextUserID), id, req)
+	if err != nil {
+		writeScreenError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, screen)
+}
+
+// Delete handles DELETE /api/v1/screens/:id
+// Removes a screen and its stored runs
+func (h *ScreenHandler) Delete(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.useCase.Delete(c.GetString(middleware.ContextUserID), id); err != nil {
+		writeScreenError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Run handles POST /api/v1/screens/:id/run
+// Evaluates a screen immediately and stores the run
+func (h *ScreenHandler) Run(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	run, err := h.useCase.RunNow(c.GetString(middleware.ContextUserID), id)
+	if err != nil {
+		writeScreenError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, run)
+}
+
+// ListRuns handles GET /api/v1/screens/:id/runs
+// Retrieves the most recent runs of a screen
+func (h *ScreenHandler) ListRuns(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	runs, err := h.useCase.ListRuns(c.GetString(middleware.ContextUserID), id, limit)
+	if err != nil {
+		writeScreenError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, runs)
+}
+
+// GetRun handles GET /api/v1/screens/:id/runs/:runId
+// Retrieves a single run of a screen
+func (h *ScreenHandler) GetRun(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	runID, ok := parseIDParam(c, "runId")
+	if !ok {
+		return
+	}
+
+	run, err := h.useCase.GetRun(c.GetString(middleware.ContextUserID), id, runID)
+	if err != nil {
+		writeScreenError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
+
+// Diff handles GET /api/v1/screens/:id/diff
+// Reports which emitens entered or left the result set between a run
+// (?run_id=, default latest) and the run before it
+func (h *ScreenHandler) Diff(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var runID int64
+	if value := c.Query("run_id"); value != "" {
+		var err error
+		if runID, err = strconv.ParseInt(value, 10, 64); err != nil || runID <= 0 {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "invalid_request",
+				Message: "run_id must be a positive integer",
+			})
+			return
+		}
+	}
+
+	diff, err := h.useCase.Diff(c.GetString(middleware.ContextUserID), id, runID)
+	if err != nil {
+		writeScreenError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+func writeScreenError(c *gin.Context, err error) {
+	if parseErr, ok := usecases.IsExpressionError(err); ok {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:    "invalid_expression",
+			Message:  parseErr.Message,
+			Position: parseErr.Position,
+		})
+		return
+	}
+
+	switch {
+	case errors.Is(err, usecases.ErrInvalidScreen):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+	case errors.Is(err, usecases.ErrScreenNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "not_found",
+			Message: err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "internal_error",
+			Message: err.Error(),
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/application/usecases"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ScreenerHandler struct {
+	useCase *usecases.ScreenerUseCase
+}
+
+func NewScreenerHandler(useCase *usecases.ScreenerUseCase) *ScreenerHandler {
+	return &ScreenerHandler{
+		useCase: useCase,
+	}
+}
+
+// Run handles POST /api/v1/screener
+// Evaluates a filter expression against the latest market data of every emiten
+func (h *ScreenerHandler) Run(c *gin.Context) {
+	var req dto.ScreenerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	response, err := h.useCase.Run(req)
+	if err != nil {
+		if parseErr, ok := usecases.IsExpressionError(err); ok {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:    "invalid_expression",
+				Message:  parseErr.Message,
+				Position: parseErr.Position,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "internal_error",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
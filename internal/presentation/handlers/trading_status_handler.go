@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/application/usecases"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TradingStatusHandler struct {
+	useCase *usecases.TradingStatusUseCase
+}
+
+func NewTradingStatusHandler(useCase *usecases.TradingStatusUseCase) *TradingStatusHandler {
+	return &TradingStatusHandler{
+		useCase: useCase,
+	}
+}
+
+// Current handles GET /api/v1/emitens/:ticker/status
+// Retrieves the trading status of an emiten on a date (?as_of=, default today)
+func (h *TradingStatusHandler) Current(c *gin.Context) {
+	asOf, err := parseTimeQuery(c.Query("as_of"), false)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "invalid as_of: " + err.Error(),
+		})
+		return
+	}
+
+	status, err := h.useCase.Current(c.Param("ticker"), asOf)
+	if err != nil {
+		writeTradingStatusError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// History handles GET /api/v1/emitens/:ticker/statuses
+// Retrieves the trading status history of an emiten
+func (h *TradingStatusHandler) History(c *gin.Context) {
+	statuses, err := h.useCase.History(c.Param("ticker"))
+	if err != nil {
+		writeTradingStatusError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statuses)
+}
+
+// List handles GET /api/v1/trading-statuses
+// Retrieves the status records covering a date (?as_of=, default today;
+// ?status=suspended to filter)
+func (h *TradingStatusHandler) List(c *gin.Context) {
+	asOf, err := parseTimeQuery(c.Query("as_of"), false)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "invalid as_of: " + err.Error(),
+		})
+		return
+	}
+
+	statuses, err := h.useCase.List(c.Query("status"), asOf)
+	if err != nil {
+		writeTradingStatusError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statuses)
+}
+
+// Create handles POST /api/v1/trading-statuses
+// Records a trading status (admin only)
+func (h *TradingStatusHandler) Create(c *gin.Context) {
+	var req dto.TradingStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	status, err := h.useCase.Create(req)
+	if err != nil {
+		writeTradingStatusError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, status)
+}
+
+// Update handles PUT /api/v1/trading-statuses/:id
+// Replaces the status, notations, watchlist flag, reason and date range of a
+// record (admin only)
+func (h *TradingStatusHandler) Update(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.TradingStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	status, err := h.useCase.Update(id, req)
+	if err != nil {
+		writeTradingStatusError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// Delete handles DELETE /api/v1/trading-statuses/:id
+// Removes a status record (admin only)
+func (h *TradingStatusHandler) Delete(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.useCase.Delete(id); err != nil {
+		writeTradingStatusError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Import handles POST /api/v1/trading-statuses/import
+// Records statuses from a CSV sent as a multipart "file" field or as a
+// text/csv body (admin only)
+func (h *TradingStatusHandler) Import(c *gin.Context) {
+	body, ok := csvBody(c)
+	if !ok {
+		return
+	}
+	defer body.Close()
+
+	result, err := h.useCase.Import(body)
+	if err != nil {
+		writeTradingStatusError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func writeTradingStatusError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrInvalidTradingStatus), errors.Is(err, usecases.ErrInvalidCSV):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+	case errors.Is(err, usecases.ErrTradingStatusNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "not_found",
+			Message: err.Error(),
+		})
+	case errors.Is(err, usecases.ErrTradingStatusOverlap):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error:   "conflict",
+			Message: err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "internal_error",
+			Message: err.Error(),
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"api-web-scrapping/internal/application/dto"
+)
+
+// RequireAdmin allows only users whose email is in adminEmails. It must run
+// after AuthMiddleware.
+func RequireAdmin(adminEmails []string) gin.HandlerFunc {
+	admins := make(map[string]bool, len(adminEmails))
+	for _, email := range adminEmails {
+		admins[strings.ToLower(email)] = true
+	}
+
+	return func(c *gin.Context) {
+		if !admins[strings.ToLower(c.GetString(ContextEmail))] {
+			c.AbortWithStatusJSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:   "forbidden",
+				Message: "admin access required",
+			})
+			return
+		}
+		c.Next()
+	}
+}
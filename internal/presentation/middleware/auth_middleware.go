@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/pkg/auth"
+)
+
+const (
+	// ContextUserID is the gin context key holding the authenticated user ID
+	ContextUserID = "user_id"
+	// ContextEmail is the gin context key holding the authenticated user email
+	ContextEmail = "email"
+)
+
+// AuthMiddleware requires a valid "Authorization: Bearer <token>" header and
+// stores the token's user ID and email in the request context
+func AuthMiddleware(jwtManager auth.JWTManager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		token := strings.TrimPrefix(header, "Bearer ")
+		if header == "" || token == header {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, dto.ErrorResponse{
+				Error:   "unauthorized",
+				Message: "missing bearer token",
+			})
+			return
+		}
+
+		claims, err := jwtManager.ValidateToken(token)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, dto.ErrorResponse{
+				Error:   "unauthorized",
+				Message: "invalid or expired token",
+			})
+			return
+		}
+
+		c.Set(ContextUserID, claims.UserID)
+		c.Set(ContextEmail, claims.Email)
+		c.Next()
+	}
+}
@@ -4,9 +4,35 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"api-web-scrapping/internal/presentation/handlers"
+	"api-web-scrapping/internal/presentation/middleware"
+	"api-web-scrapping/pkg/auth"
 )
 
-func SetupRoutes(r *gin.Engine, authHandler *handlers.AuthHandler, marketDataHandler *handlers.MarketDataHandler) {
+func SetupRoutes(
+	r *gin.Engine,
+	jwtManager auth.JWTManager,
+	adminEmails []string,
+	authHandler *handlers.AuthHandler,
+	marketDataHandler *handlers.MarketDataHandler,
+	candleHandler *handlers.CandleHandler,
+	indicatorHandler *handlers.IndicatorHandler,
+	screenerHandler *handlers.ScreenerHandler,
+	screenHandler *handlers.ScreenHandler,
+	emitenHandler *handlers.EmitenHandler,
+	marketHandler *handlers.MarketHandler,
+	indexHandler *handlers.IndexHandler,
+	indexMembershipHandler *handlers.IndexMembershipHandler,
+	analyticsHandler *handlers.AnalyticsHandler,
+	corporateActionHandler *handlers.CorporateActionHandler,
+	dividendHandler *handlers.DividendHandler,
+	tradingStatusHandler *handlers.TradingStatusHandler,
+	foreignFlowHandler *handlers.ForeignFlowHandler,
+	brokerSummaryHandler *handlers.BrokerSummaryHandler,
+	orderBookHandler *handlers.OrderBookHandler,
+) {
+	requireAuth := middleware.AuthMiddleware(jwtManager)
+	requireAdmin := middleware.RequireAdmin(adminEmails)
+
 	api := r.Group("/api/v1")
 	{
 		// Auth routes
@@ -29,6 +55,201 @@ func SetupRoutes(r *gin.Engine, authHandler *handlers.AuthHandler, marketDataHan
 
 			// Get latest market data by emiten
 			marketData.GET("/emiten/:emiten/latest", marketDataHandler.GetLatestByEmiten)
+
+			// Get OHLC candles rolled up from snapshots of an emiten
+			marketData.GET("/emiten/:emiten/candles", candleHandler.GetCandles)
+
+			// Get technical indicators computed over daily closes of an emiten
+			marketData.GET("/emiten/:emiten/indicators", indicatorHandler.GetIndicator)
+
+			// Get volatility, drawdown and beta of an emiten
+			marketData.GET("/emiten/:emiten/risk", analyticsHandler.GetRisk)
+
+			// Get 52-week, average and all-time levels of an emiten
+			marketData.GET("/emiten/:emiten/summary", analyticsHandler.GetSummary)
+
+			// Get cash dividends and the trailing-12-month yield of an emiten
+			marketData.GET("/emiten/:emiten/dividends", dividendHandler.History)
+
+			// Get daily foreign buys, sells and net flow of an emiten
+			marketData.GET("/emiten/:emiten/foreign-flow", foreignFlowHandler.History)
+
+			// Get the top net-buying and net-selling brokers of an emiten
+			marketData.GET("/emiten/:emiten/brokers", brokerSummaryHandler.TopBrokers)
+
+			// Get the latest bid/offer depth of an emiten with spread and imbalance
+			marketData.GET("/emiten/:emiten/order-book", orderBookHandler.Latest)
+
+			// Snapshots rejected on ingest for prices off the tick grid
+			marketData.GET("/quarantine", requireAuth, requireAdmin, candleHandler.ListQuarantine)
+		}
+
+		// Screener routes (filter expressions over latest market data)
+		api.POST("/screener", screenerHandler.Run)
+
+		// Saved screen routes (per user, scheduled in Asia/Jakarta time)
+		screens := api.Group("/screens", requireAuth)
+		{
+			screens.POST("", screenHandler.Create)
+			screens.GET("", screenHandler.List)
+			screens.GET("/:id", screenHandler.Get)
+			screens.PUT("/:id", screenHandler.Update)
+			screens.DELETE("/:id", screenHandler.Delete)
+
+			// Evaluate a screen now and store the run
+			screens.POST("/:id/run", screenHandler.Run)
+
+			// Stored runs and the change between consecutive runs
+			screens.GET("/:id/runs", screenHandler.ListRuns)
+			screens.GET("/:id/runs/:runId", screenHandler.GetRun)
+			screens.GET("/:id/diff", screenHandler.Diff)
+		}
+
+		// Emiten master data routes (writes restricted to admins)
+		emitens := api.Group("/emitens")
+		{
+			emitens.GET("", emitenHandler.List)
+			// Ticker and company name autocomplete
+			emitens.GET("/search", emitenHandler.Search)
+			emitens.GET("/:ticker", emitenHandler.Get)
+
+			// Effective-dated suspensions, notations and watchlist placement
+			emitens.GET("/:ticker/status", tradingStatusHandler.Current)
+			emitens.GET("/:ticker/statuses", tradingStatusHandler.History)
+
+			emitens.POST("", requireAuth, requireAdmin, emitenHandler.Create)
+			emitens.PUT("/:ticker", requireAuth, requireAdmin, emitenHandler.Update)
+			emitens.DELETE("/:ticker", requireAuth, requireAdmin, emitenHandler.Delete)
+
+			// Bulk upsert from CSV
+			emitens.POST("/import", requireAuth, requireAdmin, emitenHandler.Import)
+		}
+
+		// Market-wide aggregate routes
+		market := api.Group("/market")
+		{
+			// Trading phase and next open/close in WIB
+			market.GET("/status", marketHandler.GetStatus)
+
+			// Per-sector moves for the heatmap
+			market.GET("/sectors", marketHandler.GetSectors)
+
+			// Daily advancers/decliners, new highs/lows and A/D line
+			market.GET("/breadth", marketHandler.GetBreadth)
+
+			// Emitens at their auto-rejection limits (ARA/ARB) today
+			market.GET("/auto-rejections", marketHandler.GetAutoRejections)
+
+			// IDX tick grid helpers
+			market.GET("/ticks", marketHandler.ListTicks)
+			market.GET("/ticks/round", marketHandler.RoundTick)
+		}
+
+		// Composite index routes (definitions restricted to admins)
+		indices := api.Group("/indices")
+		{
+			indices.GET("", indexHandler.List)
+			indices.GET("/:code", indexHandler.Get)
+
+			indices.POST("", requireAuth, requireAdmin, indexHandler.Create)
+			indices.PUT("/:code", requireAuth, requireAdmin, indexHandler.Update)
+			indices.DELETE("/:code", requireAuth, requireAdmin, indexHandler.Delete)
+
+			// Membership changes with divisor adjustment
+			indices.PUT("/:code/constituents", requireAuth, requireAdmin, indexHandler.Rebalance)
+			indices.GET("/:code/divisors", indexHandler.ListDivisors)
+
+			// Computed OHLC levels in the market data shape
+			indices.GET("/:code/series", indexHandler.GetSeries)
+			indices.GET("/:code/latest", indexHandler.GetLatest)
+
+			// Effective-dated membership (any index code, e.g. LQ45)
+			indices.GET("/:code/members", indexMembershipHandler.Members)
+			indices.GET("/:code/memberships", indexMembershipHandler.List)
+		}
+
+		// Index membership record routes (admin only)
+		memberships := api.Group("/index-memberships", requireAuth, requireAdmin)
+		{
+			memberships.POST("", indexMembershipHandler.Create)
+			memberships.PUT("/:id", indexMembershipHandler.Update)
+			memberships.DELETE("/:id", indexMembershipHandler.Delete)
+
+			// Bulk import from CSV
+			memberships.POST("/import", indexMembershipHandler.Import)
+		}
+
+		// Trading status record routes (writes restricted to admins)
+		tradingStatuses := api.Group("/trading-statuses")
+		{
+			// Records covering a date, e.g. every suspension today
+			tradingStatuses.GET("", tradingStatusHandler.List)
+
+			tradingStatuses.POST("", requireAuth, requireAdmin, tradingStatusHandler.Create)
+			tradingStatuses.PUT("/:id", requireAuth, requireAdmin, tradingStatusHandler.Update)
+			tradingStatuses.DELETE("/:id", requireAuth, requireAdmin, tradingStatusHandler.Delete)
+
+			// Bulk import from CSV
+			tradingStatuses.POST("/import", requireAuth, requireAdmin, tradingStatusHandler.Import)
+		}
+
+		// Corporate action routes (writes restricted to admins)
+		corporateActions := api.Group("/corporate-actions")
+		{
+			corporateActions.GET("", corporateActionHandler.List)
+			corporateActions.GET("/:id", corporateActionHandler.Get)
+
+			corporateActions.POST("", requireAuth, requireAdmin, corporateActionHandler.Create)
+			corporateActions.PUT("/:id", requireAuth, requireAdmin, corporateActionHandler.Update)
+			corporateActions.DELETE("/:id", requireAuth, requireAdmin, corporateActionHandler.Delete)
+
+			// Bulk upsert from CSV
+			corporateActions.POST("/import", requireAuth, requireAdmin, corporateActionHandler.Import)
+		}
+
+		// Dividend routes (cash dividends recorded as corporate actions)
+		dividends := api.Group("/dividends")
+		{
+			// Cum, ex, recording and payment dates in a range
+			dividends.GET("/upcoming", dividendHandler.Upcoming)
+
+			// Emitens ranked by trailing-12-month yield
+			dividends.GET("/yields", dividendHandler.Yields)
+		}
+
+		// Foreign investor flow routes (ingest restricted to admins)
+		foreignFlows := api.Group("/foreign-flows")
+		{
+			// Top foreign net buys and net sells over a range
+			foreignFlows.GET("/rankings", foreignFlowHandler.Rankings)
+
+			// Bulk upsert from a JSON batch or CSV
+			foreignFlows.POST("", requireAuth, requireAdmin, foreignFlowHandler.Ingest)
+			foreignFlows.POST("/import", requireAuth, requireAdmin, foreignFlowHandler.Import)
+		}
+
+		// Broker summary routes (ingest restricted to admins)
+		brokerSummaries := api.Group("/broker-summaries", requireAuth, requireAdmin)
+		{
+			// Bulk upsert from a JSON batch or CSV
+			brokerSummaries.POST("", brokerSummaryHandler.Ingest)
+			brokerSummaries.POST("/import", brokerSummaryHandler.Import)
+		}
+
+		// Emitens a broker accumulated and distributed over a range
+		api.GET("/brokers/:code/accumulation", brokerSummaryHandler.Accumulation)
+
+		// Order book depth snapshots from the scrapers (admin only)
+		api.POST("/order-books", requireAuth, requireAdmin, orderBookHandler.Ingest)
+
+		// Cross-emiten analytics routes
+		analytics := api.Group("/analytics")
+		{
+			// Correlation and covariance of daily log returns
+			analytics.POST("/correlation", analyticsHandler.Correlation)
+
+			// Rebased performance of several emitens against a benchmark
+			analytics.GET("/compare", analyticsHandler.Compare)
 		}
 	}
 
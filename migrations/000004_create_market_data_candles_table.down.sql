@@ -0,0 +1,6 @@
+-- Rollback: Drop market_data_candles table
+-- Version: 000004
+-- Description: Drop market_data_candles table
+
+-- Drop table
+DROP TABLE IF EXISTS market_data_candles;
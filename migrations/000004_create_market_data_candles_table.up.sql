@@ -0,0 +1,19 @@
+-- Migration: Create market_data_candles table
+-- Version: 000004
+-- Description: Persisted OHLC rollups (1h/1d/1w/1M) built from market data snapshots
+
+-- Create market_data_candles table
+CREATE TABLE IF NOT EXISTS market_data_candles (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    emiten VARCHAR(16) NOT NULL COMMENT 'Stock ticker',
+    interval_code VARCHAR(4) NOT NULL COMMENT 'Candle interval (1h, 1d, 1w, 1M)',
+    bucket_start DATETIME NOT NULL COMMENT 'Bucket start time in UTC, aligned to WIB sessions',
+    open_price DECIMAL(18,4) NOT NULL COMMENT 'Open of the first snapshot in the bucket',
+    high_price DECIMAL(18,4) NOT NULL COMMENT 'Highest high in the bucket',
+    low_price DECIMAL(18,4) NOT NULL COMMENT 'Lowest low in the bucket',
+    close_price DECIMAL(18,4) NOT NULL COMMENT 'Close of the last snapshot in the bucket',
+    sample_count INT NOT NULL DEFAULT 0 COMMENT 'Number of snapshots rolled into the candle',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_market_data_candles_bucket (emiten, interval_code, bucket_start)
+) COMMENT = 'OHLC candle rollups of scraped market data';
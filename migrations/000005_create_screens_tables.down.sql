@@ -0,0 +1,7 @@
+-- Rollback: Drop screens and screen_runs tables
+-- Version: 000005
+-- Description: Drop screen_runs and screens tables
+
+-- Drop tables
+DROP TABLE IF EXISTS screen_runs;
+DROP TABLE IF EXISTS screens;
@@ -0,0 +1,31 @@
+-- Migration: Create screens and screen_runs tables
+-- Version: 000005
+-- Description: Saved screener filters with cron schedules and their stored runs
+
+-- Create screens table
+CREATE TABLE IF NOT EXISTS screens (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL COMMENT 'Owner (users.id)',
+    name VARCHAR(255) NOT NULL COMMENT 'Screen name, unique per user',
+    filter JSON NOT NULL COMMENT 'Price/change thresholds, emiten list and optional expression',
+    schedule VARCHAR(100) NOT NULL DEFAULT '' COMMENT 'Cron expression in Asia/Jakarta time, empty for manual only',
+    enabled BOOLEAN NOT NULL DEFAULT TRUE COMMENT 'Whether the schedule is active',
+    next_run_at DATETIME NULL COMMENT 'Next scheduled evaluation',
+    last_run_at DATETIME NULL COMMENT 'Last evaluation',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_screens_user_name (user_id, name),
+    KEY idx_screens_due (enabled, next_run_at)
+) COMMENT = 'Saved screener filters';
+
+-- Create screen_runs table
+CREATE TABLE IF NOT EXISTS screen_runs (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    screen_id BIGINT NOT NULL COMMENT 'Reference to screens',
+    trigger_type VARCHAR(16) NOT NULL COMMENT 'schedule or manual',
+    run_at DATETIME NOT NULL COMMENT 'Evaluation time',
+    result_count INT NOT NULL DEFAULT 0 COMMENT 'Number of matching emitens',
+    results JSON NOT NULL COMMENT 'Matching emitens with close price and change',
+    KEY idx_screen_runs_screen_run_at (screen_id, run_at),
+    CONSTRAINT fk_screen_runs_screen_id FOREIGN KEY (screen_id) REFERENCES screens(id) ON DELETE CASCADE
+) COMMENT = 'Stored results of screen evaluations';
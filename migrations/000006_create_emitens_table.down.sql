@@ -0,0 +1,6 @@
+-- Rollback: Drop emitens table
+-- Version: 000006
+-- Description: Drop emitens table
+
+-- Drop table
+DROP TABLE IF EXISTS emitens;
@@ -0,0 +1,20 @@
+-- Migration: Create emitens table
+-- Version: 000006
+-- Description: Master data of listed companies keyed by ticker
+
+-- Create emitens table
+CREATE TABLE IF NOT EXISTS emitens (
+    ticker VARCHAR(16) PRIMARY KEY COMMENT 'Stock ticker (matches market data emiten)',
+    company_name VARCHAR(255) NOT NULL COMMENT 'Registered company name',
+    sector VARCHAR(100) NOT NULL DEFAULT '' COMMENT 'IDX-IC sector',
+    sub_industry VARCHAR(150) NOT NULL DEFAULT '' COMMENT 'IDX-IC sub-industry',
+    board VARCHAR(20) NOT NULL DEFAULT 'main' COMMENT 'Listing board (main, development, acceleration, new_economy, watchlist)',
+    listing_date DATE NULL COMMENT 'Initial listing date',
+    shares_outstanding BIGINT NOT NULL DEFAULT 0 COMMENT 'Number of listed shares',
+    is_sharia BOOLEAN NOT NULL DEFAULT FALSE COMMENT 'Included in the sharia securities list',
+    status VARCHAR(20) NOT NULL DEFAULT 'active' COMMENT 'Listing status (active, suspended, delisted)',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    KEY idx_emitens_sector (sector),
+    KEY idx_emitens_company_name (company_name)
+) COMMENT = 'Emiten master data';
@@ -0,0 +1,7 @@
+-- Rollback: Drop indices and index_periods tables
+-- Version: 000007
+-- Description: Drop index_periods and indices tables
+
+-- Drop tables
+DROP TABLE IF EXISTS index_periods;
+DROP TABLE IF EXISTS indices;
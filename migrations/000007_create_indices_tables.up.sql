@@ -0,0 +1,27 @@
+-- Migration: Create indices and index_periods tables
+-- Version: 000007
+-- Description: Composite index definitions and their constituent/divisor history
+
+-- Create indices table
+CREATE TABLE IF NOT EXISTS indices (
+    code VARCHAR(32) PRIMARY KEY COMMENT 'Index code, e.g. BIGBANKS',
+    name VARCHAR(255) NOT NULL COMMENT 'Display name',
+    weighting VARCHAR(20) NOT NULL COMMENT 'market_cap, free_float, equal or price',
+    base_date DATE NOT NULL COMMENT 'Date on which the index equals base_value',
+    base_value DOUBLE NOT NULL COMMENT 'Index level on the base date',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) COMMENT = 'Composite index definitions';
+
+-- Create index_periods table
+CREATE TABLE IF NOT EXISTS index_periods (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    index_code VARCHAR(32) NOT NULL COMMENT 'Reference to indices',
+    effective_from DATE NOT NULL COMMENT 'First session of the period',
+    divisor DOUBLE NOT NULL COMMENT 'Divisor applied to the weighted sum of prices',
+    reason VARCHAR(20) NOT NULL COMMENT 'base or rebalance',
+    constituents JSON NOT NULL COMMENT 'Constituents with their fixed weights',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_index_periods_code_effective (index_code, effective_from),
+    CONSTRAINT fk_index_periods_index_code FOREIGN KEY (index_code) REFERENCES indices(code) ON DELETE CASCADE
+) COMMENT = 'Constituent lists and divisors of composite indices over time';
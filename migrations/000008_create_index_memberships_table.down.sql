@@ -0,0 +1,6 @@
+-- Rollback: Drop index_memberships table
+-- Version: 000008
+-- Description: Drop index_memberships table
+
+-- Drop table
+DROP TABLE IF EXISTS index_memberships;
@@ -0,0 +1,17 @@
+-- Migration: Create index_memberships table
+-- Version: 000008
+-- Description: Effective-dated membership of emitens in indices such as LQ45
+
+-- Create index_memberships table
+CREATE TABLE IF NOT EXISTS index_memberships (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    index_code VARCHAR(32) NOT NULL COMMENT 'Index code, e.g. LQ45',
+    emiten VARCHAR(16) NOT NULL COMMENT 'Member ticker',
+    effective_from DATE NOT NULL COMMENT 'First date of membership',
+    effective_to DATE NULL COMMENT 'Last date of membership, NULL while still a member',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_index_memberships_index_emiten_from (index_code, emiten, effective_from),
+    KEY idx_index_memberships_range (index_code, effective_from, effective_to),
+    KEY idx_index_memberships_emiten (emiten)
+) COMMENT = 'Historical index constituents';
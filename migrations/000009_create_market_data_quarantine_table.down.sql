@@ -0,0 +1,6 @@
+-- Rollback: Drop market_data_quarantine table
+-- Version: 000009
+-- Description: Drop market_data_quarantine table
+
+-- Drop table
+DROP TABLE IF EXISTS market_data_quarantine;
@@ -0,0 +1,20 @@
+-- Migration: Create market_data_quarantine table
+-- Version: 000009
+-- Description: Scraped snapshots rejected on ingest, e.g. for prices off the IDX tick grid
+
+-- Create market_data_quarantine table
+CREATE TABLE IF NOT EXISTS market_data_quarantine (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    market_data_id BIGINT NOT NULL COMMENT 'Snapshot ID in v_latest_market_data',
+    emiten VARCHAR(16) NOT NULL COMMENT 'Stock ticker',
+    open_price DECIMAL(18,4) NOT NULL COMMENT 'Scraped open',
+    high_price DECIMAL(18,4) NOT NULL COMMENT 'Scraped high',
+    low_price DECIMAL(18,4) NOT NULL COMMENT 'Scraped low',
+    last_price DECIMAL(18,4) NOT NULL COMMENT 'Scraped last price',
+    date_time_scraping DATETIME NOT NULL COMMENT 'Scrape time of the snapshot',
+    reason VARCHAR(500) NOT NULL COMMENT 'Validation failures, separated by semicolons',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_market_data_quarantine_snapshot (market_data_id),
+    KEY idx_market_data_quarantine_emiten_date (emiten, date_time_scraping),
+    KEY idx_market_data_quarantine_date (date_time_scraping)
+) COMMENT = 'Quarantined market data snapshots';
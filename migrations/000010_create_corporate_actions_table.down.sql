@@ -0,0 +1,6 @@
+-- Rollback: Drop corporate_actions table
+-- Version: 000010
+-- Description: Drop corporate_actions table
+
+-- Drop table
+DROP TABLE IF EXISTS corporate_actions;
@@ -0,0 +1,20 @@
+-- Migration: Create corporate_actions table
+-- Version: 000010
+-- Description: Splits, bonus and rights issues and cash dividends used to adjust price history
+
+-- Create corporate_actions table
+CREATE TABLE IF NOT EXISTS corporate_actions (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    emiten VARCHAR(16) NOT NULL COMMENT 'Stock ticker',
+    action_type VARCHAR(20) NOT NULL COMMENT 'split, reverse_split, bonus, rights or cash_dividend',
+    ex_date DATE NOT NULL COMMENT 'First session trading without the entitlement',
+    ratio_from DECIMAL(18,6) NOT NULL DEFAULT 0 COMMENT 'Old or held shares of the ratio',
+    ratio_to DECIMAL(18,6) NOT NULL DEFAULT 0 COMMENT 'New, bonus or rights shares of the ratio',
+    price DECIMAL(18,4) NOT NULL DEFAULT 0 COMMENT 'Exercise price of a rights issue',
+    amount DECIMAL(18,4) NOT NULL DEFAULT 0 COMMENT 'Cash dividend per share',
+    description VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'Free-form note, e.g. the announcement reference',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_corporate_actions_emiten_type_ex_date (emiten, action_type, ex_date),
+    KEY idx_corporate_actions_ex_date (ex_date)
+) COMMENT = 'Corporate actions affecting share prices';
@@ -0,0 +1,11 @@
+-- Rollback: Remove schedule dates from corporate_actions
+-- Version: 000011
+-- Description: Drop announcement, cum, recording and payment dates
+
+-- Drop schedule columns
+ALTER TABLE corporate_actions
+    DROP KEY idx_corporate_actions_payment_date,
+    DROP COLUMN payment_date,
+    DROP COLUMN recording_date,
+    DROP COLUMN cum_date,
+    DROP COLUMN announcement_date;
@@ -0,0 +1,11 @@
+-- Migration: Add schedule dates to corporate_actions
+-- Version: 000011
+-- Description: Announcement, cum, recording and payment dates for the dividend calendar
+
+-- Add schedule columns
+ALTER TABLE corporate_actions
+    ADD COLUMN announcement_date DATE NULL COMMENT 'Date the action was announced' AFTER ex_date,
+    ADD COLUMN cum_date DATE NULL COMMENT 'Last session trading with the entitlement' AFTER announcement_date,
+    ADD COLUMN recording_date DATE NULL COMMENT 'Date fixing the entitled shareholders' AFTER cum_date,
+    ADD COLUMN payment_date DATE NULL COMMENT 'Cash dividend payment date' AFTER recording_date,
+    ADD KEY idx_corporate_actions_payment_date (payment_date);
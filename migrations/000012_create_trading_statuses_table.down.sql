@@ -0,0 +1,6 @@
+-- Rollback: Drop trading_statuses table
+-- Version: 000012
+-- Description: Drop trading_statuses table
+
+-- Drop table
+DROP TABLE IF EXISTS trading_statuses;
@@ -0,0 +1,19 @@
+-- Migration: Create trading_statuses table
+-- Version: 000012
+-- Description: Effective-dated suspensions, delistings, special notations and watchlist placement of emitens
+
+-- Create trading_statuses table
+CREATE TABLE IF NOT EXISTS trading_statuses (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    emiten VARCHAR(16) NOT NULL COMMENT 'Ticker',
+    status VARCHAR(16) NOT NULL DEFAULT 'active' COMMENT 'active, suspended or delisted',
+    notations VARCHAR(64) NOT NULL DEFAULT '' COMMENT 'Comma-separated special notation letters, e.g. E,X',
+    watchlist BOOLEAN NOT NULL DEFAULT FALSE COMMENT 'Placed on the watchlist board',
+    reason VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'Exchange announcement or reason',
+    effective_from DATE NOT NULL COMMENT 'First date of the status',
+    effective_to DATE NULL COMMENT 'Last date of the status, NULL while it still applies',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_trading_statuses_emiten_from (emiten, effective_from),
+    KEY idx_trading_statuses_range (effective_from, effective_to)
+) COMMENT = 'Historical trading status of emitens';
@@ -0,0 +1,13 @@
+-- Rollback: Remove volume, value and frequency from market data
+-- Version: 000013
+-- Description: Drop the session totals and the candle volume
+
+-- Drop candle volume
+ALTER TABLE market_data_candles
+    DROP COLUMN volume;
+
+-- Drop session totals
+ALTER TABLE market_data
+    DROP COLUMN frequency,
+    DROP COLUMN value,
+    DROP COLUMN volume;
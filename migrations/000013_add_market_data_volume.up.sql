@@ -0,0 +1,13 @@
+-- Migration: Add volume, value and frequency to market data
+-- Version: 000013
+-- Description: Session volume, traded value and trade frequency of scraped snapshots
+
+-- Add session totals to the scraped snapshots; NULL for rows scraped before they were captured
+ALTER TABLE market_data
+    ADD COLUMN volume BIGINT NULL COMMENT 'Shares traded in the session as of the scrape' AFTER last_price,
+    ADD COLUMN value DECIMAL(24,2) NULL COMMENT 'Rupiah value traded in the session as of the scrape' AFTER volume,
+    ADD COLUMN frequency BIGINT NULL COMMENT 'Number of trades in the session as of the scrape' AFTER value;
+
+-- Add traded volume to the candle rollups
+ALTER TABLE market_data_candles
+    ADD COLUMN volume DECIMAL(24,0) NOT NULL DEFAULT 0 COMMENT 'Shares traded in the bucket' AFTER close_price;
@@ -0,0 +1,6 @@
+-- Rollback: Drop foreign_flows table
+-- Version: 000014
+-- Description: Drop foreign_flows table
+
+-- Drop table
+DROP TABLE IF EXISTS foreign_flows;
@@ -0,0 +1,18 @@
+-- Migration: Create foreign_flows table
+-- Version: 000014
+-- Description: Daily foreign investor buy and sell totals per emiten
+
+-- Create foreign_flows table
+CREATE TABLE IF NOT EXISTS foreign_flows (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    emiten VARCHAR(16) NOT NULL COMMENT 'Ticker',
+    trade_date DATE NOT NULL COMMENT 'Session date',
+    buy_volume BIGINT NOT NULL DEFAULT 0 COMMENT 'Shares bought by foreign investors',
+    sell_volume BIGINT NOT NULL DEFAULT 0 COMMENT 'Shares sold by foreign investors',
+    buy_value DECIMAL(20, 2) NOT NULL DEFAULT 0 COMMENT 'Rupiah value bought by foreign investors',
+    sell_value DECIMAL(20, 2) NOT NULL DEFAULT 0 COMMENT 'Rupiah value sold by foreign investors',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_foreign_flows_emiten_date (emiten, trade_date),
+    KEY idx_foreign_flows_date (trade_date)
+) COMMENT = 'Daily foreign investor flow per emiten';
@@ -0,0 +1,6 @@
+-- Rollback: Drop broker_summaries table
+-- Version: 000015
+-- Description: Drop broker_summaries table
+
+-- Drop table
+DROP TABLE IF EXISTS broker_summaries;
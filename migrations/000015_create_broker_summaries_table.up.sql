@@ -0,0 +1,20 @@
+-- Migration: Create broker_summaries table
+-- Version: 000015
+-- Description: Daily buy and sell totals of each exchange member per emiten
+
+-- Create broker_summaries table
+CREATE TABLE IF NOT EXISTS broker_summaries (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    emiten VARCHAR(16) NOT NULL COMMENT 'Ticker',
+    trade_date DATE NOT NULL COMMENT 'Session date',
+    broker_code CHAR(2) NOT NULL COMMENT 'Exchange member code, e.g. YP',
+    buy_lots BIGINT NOT NULL DEFAULT 0 COMMENT 'Lots bought',
+    sell_lots BIGINT NOT NULL DEFAULT 0 COMMENT 'Lots sold',
+    buy_value DECIMAL(20, 2) NOT NULL DEFAULT 0 COMMENT 'Rupiah value bought',
+    sell_value DECIMAL(20, 2) NOT NULL DEFAULT 0 COMMENT 'Rupiah value sold',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_broker_summaries_emiten_date_broker (emiten, trade_date, broker_code),
+    KEY idx_broker_summaries_broker_date (broker_code, trade_date),
+    KEY idx_broker_summaries_date (trade_date)
+) COMMENT = 'Daily broker activity per emiten';
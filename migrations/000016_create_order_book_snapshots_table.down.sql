@@ -0,0 +1,6 @@
+-- Rollback: Drop order_book_snapshots table
+-- Version: 000016
+-- Description: Drop order_book_snapshots table
+
+-- Drop table
+DROP TABLE IF EXISTS order_book_snapshots;
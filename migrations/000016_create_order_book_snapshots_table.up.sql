@@ -0,0 +1,15 @@
+-- Migration: Create order_book_snapshots table
+-- Version: 000016
+-- Description: Bid and offer depth captured by the scrapers, pruned after the retention period
+
+-- Create order_book_snapshots table
+CREATE TABLE IF NOT EXISTS order_book_snapshots (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    emiten VARCHAR(16) NOT NULL COMMENT 'Ticker',
+    captured_at DATETIME(3) NOT NULL COMMENT 'Capture time of the snapshot, to the millisecond',
+    bids JSON NOT NULL COMMENT 'Bid levels from the highest price: price, lots and orders',
+    offers JSON NOT NULL COMMENT 'Offer levels from the lowest price: price, lots and orders',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_order_book_snapshots_emiten_time (emiten, captured_at),
+    KEY idx_order_book_snapshots_time (captured_at)
+) COMMENT = 'Order book depth snapshots';
@@ -0,0 +1,6 @@
+-- Rollback: Remove the backfilled trading statuses
+-- Version: 000017
+-- Description: Remove the trading statuses backfilled from the emiten master data
+
+-- Delete backfilled statuses
+DELETE FROM trading_statuses WHERE reason = 'Backfilled from emiten master data';
@@ -0,0 +1,14 @@
+-- Migration: Backfill trading statuses from the emiten master data
+-- Version: 000017
+-- Description: Trading statuses are the only source of an emiten's status and watchlist placement
+
+-- Open a status for every emiten the master data lists as suspended, delisted
+-- or on the watchlist board and that has no open status yet
+INSERT INTO trading_statuses (emiten, status, watchlist, reason, effective_from)
+SELECT e.ticker, e.status, e.board = 'watchlist', 'Backfilled from emiten master data', CURRENT_DATE
+FROM emitens e
+WHERE (e.status <> 'active' OR e.board = 'watchlist')
+    AND NOT EXISTS (
+        SELECT 1 FROM trading_statuses s
+        WHERE s.emiten = e.ticker AND s.effective_to IS NULL
+    );
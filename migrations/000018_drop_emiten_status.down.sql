@@ -0,0 +1,7 @@
+-- Rollback: Restore the status of the emiten master data
+-- Version: 000018
+-- Description: Restore emitens.status; the statuses themselves stay in trading_statuses
+
+ALTER TABLE emitens
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'active' COMMENT 'Listing status (active, suspended, delisted)' AFTER is_sharia,
+    MODIFY COLUMN board VARCHAR(20) NOT NULL DEFAULT 'main' COMMENT 'Listing board (main, development, acceleration, new_economy, watchlist)';
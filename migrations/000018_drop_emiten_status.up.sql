@@ -0,0 +1,11 @@
+-- Migration: Drop the status of the emiten master data
+-- Version: 000018
+-- Description: Trading statuses are the only record of suspension, delisting and watchlist placement
+
+-- Watchlist placement was copied to trading_statuses by 000017; the listing
+-- board of those emitens is not known, so they return to the main board
+UPDATE emitens SET board = 'main' WHERE board = 'watchlist';
+
+ALTER TABLE emitens
+    DROP COLUMN status,
+    MODIFY COLUMN board VARCHAR(20) NOT NULL DEFAULT 'main' COMMENT 'Listing board (main, development, acceleration, new_economy)';
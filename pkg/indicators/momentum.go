@@ -0,0 +1,95 @@
+package indicators
+
+import "math"
+
+// RSI returns Wilder's relative strength index. The first average gain and
+// loss are simple averages of the first period changes; later values use
+// Wilder smoothing, so the first RSI is available at index period.
+func RSI(closes []float64, period int) []float64 {
+	out := nanSlice(len(closes))
+	if period <= 0 || len(closes) <= period {
+		return out
+	}
+
+	var avgGain, avgLoss float64
+	for i := 1; i <= period; i++ {
+		gain, loss := change(closes[i-1], closes[i])
+		avgGain += gain
+		avgLoss += loss
+	}
+	avgGain /= float64(period)
+	avgLoss /= float64(period)
+	out[period] = rsiValue(avgGain, avgLoss)
+
+	for i := period + 1; i < len(closes); i++ {
+		gain, loss := change(closes[i-1], closes[i])
+		avgGain = (avgGain*float64(period-1) + gain) / float64(period)
+		avgLoss = (avgLoss*float64(period-1) + loss) / float64(period)
+		out[i] = rsiValue(avgGain, avgLoss)
+	}
+	return out
+}
+
+// MACD returns the MACD line (fast EMA minus slow EMA), its signal line (EMA
+// of the MACD line) and the histogram (MACD minus signal)
+func MACD(closes []float64, fast, slow, signal int) ([]float64, []float64, []float64) {
+	fastEMA := EMA(closes, fast)
+	slowEMA := EMA(closes, slow)
+
+	macd := make([]float64, len(closes))
+	for i := range closes {
+		macd[i] = fastEMA[i] - slowEMA[i] // NaN while either EMA is warming up
+	}
+
+	signalLine := EMA(macd, signal)
+	histogram := make([]float64, len(closes))
+	for i := range closes {
+		histogram[i] = macd[i] - signalLine[i]
+	}
+	return macd, signalLine, histogram
+}
+
+// Stochastic returns the %K line, the position of the close within the
+// highest high and lowest low of the last kPeriod bars, and %D, its SMA over
+// dPeriod values
+func Stochastic(highs, lows, closes []float64, kPeriod, dPeriod int) ([]float64, []float64) {
+	k := nanSlice(len(closes))
+	if kPeriod > 0 && len(closes) >= kPeriod {
+		for i := kPeriod - 1; i < len(closes); i++ {
+			highest, lowest := highs[i], lows[i]
+			for j := i - kPeriod + 1; j < i; j++ {
+				highest = math.Max(highest, highs[j])
+				lowest = math.Min(lowest, lows[j])
+			}
+			if highest == lowest {
+				k[i] = 50 // flat range, the close sits in the middle
+				continue
+			}
+			k[i] = 100 * (closes[i] - lowest) / (highest - lowest)
+		}
+	}
+
+	d := nanSlice(len(closes))
+	start := WarmUp(k)
+	smoothedK := SMA(k[start:], dPeriod)
+	copy(d[start:], smoothedK)
+	return k, d
+}
+
+func change(previous, current float64) (float64, float64) {
+	diff := current - previous
+	if diff > 0 {
+		return diff, 0
+	}
+	return 0, -diff
+}
+
+func rsiValue(avgGain, avgLoss float64) float64 {
+	if avgLoss == 0 {
+		if avgGain == 0 {
+			return 50
+		}
+		return 100
+	}
+	return 100 - 100/(1+avgGain/avgLoss)
+}
@@ -0,0 +1,94 @@
+// Package indicators computes technical indicators over price series.
+//
+// Every function returns a slice aligned with its input. Positions that fall
+// inside the indicator's warm-up window, where there is not yet enough history
+// to produce a value, are set to NaN.
+package indicators
+
+import "math"
+
+// SMA returns the simple moving average over period values
+func SMA(values []float64, period int) []float64 {
+	out := nanSlice(len(values))
+	if period <= 0 || len(values) < period {
+		return out
+	}
+
+	sum := 0.0
+	for i, v := range values {
+		sum += v
+		if i >= period {
+			sum -= values[i-period]
+		}
+		if i >= period-1 {
+			out[i] = sum / float64(period)
+		}
+	}
+	return out
+}
+
+// EMA returns the exponential moving average with smoothing 2/(period+1),
+// seeded with the SMA of the first period values. Leading NaNs in the input
+// are skipped so EMA can be chained onto another indicator's output.
+func EMA(values []float64, period int) []float64 {
+	return smoothed(values, period, 2/float64(period+1))
+}
+
+// WMA returns the linearly weighted moving average, where the most recent
+// value has weight period and the oldest has weight 1
+func WMA(values []float64, period int) []float64 {
+	out := nanSlice(len(values))
+	if period <= 0 || len(values) < period {
+		return out
+	}
+
+	denominator := float64(period*(period+1)) / 2
+	for i := period - 1; i < len(values); i++ {
+		sum := 0.0
+		for j := 0; j < period; j++ {
+			sum += values[i-period+1+j] * float64(j+1)
+		}
+		out[i] = sum / denominator
+	}
+	return out
+}
+
+// WarmUp returns the index of the first non-NaN value, or len(values) if none
+func WarmUp(values []float64) int {
+	for i, v := range values {
+		if !math.IsNaN(v) {
+			return i
+		}
+	}
+	return len(values)
+}
+
+// smoothed applies exponential smoothing with factor alpha, seeded with the
+// average of the first period values after any leading NaNs
+func smoothed(values []float64, period int, alpha float64) []float64 {
+	out := nanSlice(len(values))
+	start := WarmUp(values)
+	if period <= 0 || len(values)-start < period {
+		return out
+	}
+
+	seedEnd := start + period - 1
+	sum := 0.0
+	for i := start; i <= seedEnd; i++ {
+		sum += values[i]
+	}
+	out[seedEnd] = sum / float64(period)
+
+	for i := seedEnd + 1; i < len(values); i++ {
+		out[i] = alpha*values[i] + (1-alpha)*out[i-1]
+	}
+	return out
+}
+
+func nanSlice(n int) []float64 {
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = math.NaN()
+	}
+	return out
+}
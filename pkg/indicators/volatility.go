@@ -0,0 +1,46 @@
+package indicators
+
+import "math"
+
+// BollingerBands returns the middle band (SMA over period), and the upper and
+// lower bands at multiplier population standard deviations from it
+func BollingerBands(closes []float64, period int, multiplier float64) ([]float64, []float64, []float64) {
+	middle := SMA(closes, period)
+	upper := nanSlice(len(closes))
+	lower := nanSlice(len(closes))
+
+	for i := range closes {
+		if math.IsNaN(middle[i]) {
+			continue
+		}
+		variance := 0.0
+		for j := i - period + 1; j <= i; j++ {
+			d := closes[j] - middle[i]
+			variance += d * d
+		}
+		deviation := math.Sqrt(variance / float64(period))
+		upper[i] = middle[i] + multiplier*deviation
+		lower[i] = middle[i] - multiplier*deviation
+	}
+	return middle, upper, lower
+}
+
+// TrueRange returns the greatest of high-low, |high-previous close| and
+// |low-previous close|. The first bar has no previous close and uses high-low.
+func TrueRange(highs, lows, closes []float64) []float64 {
+	out := make([]float64, len(closes))
+	for i := range closes {
+		out[i] = highs[i] - lows[i]
+		if i > 0 {
+			out[i] = math.Max(out[i], math.Abs(highs[i]-closes[i-1]))
+			out[i] = math.Max(out[i], math.Abs(lows[i]-closes[i-1]))
+		}
+	}
+	return out
+}
+
+// ATR returns Wilder's average true range: the simple average of the first
+// period true ranges, then smoothed with factor 1/period
+func ATR(highs, lows, closes []float64, period int) []float64 {
+	return smoothed(TrueRange(highs, lows, closes), period, 1/float64(period))
+}
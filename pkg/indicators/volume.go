@@ -0,0 +1,17 @@
+package indicators
+
+// OBV returns on-balance volume: a running total that adds the bar's volume
+// when the close rises and subtracts it when the close falls
+func OBV(closes, volumes []float64) []float64 {
+	out := make([]float64, len(closes))
+	for i := 1; i < len(closes); i++ {
+		out[i] = out[i-1]
+		switch {
+		case closes[i] > closes[i-1]:
+			out[i] += volumes[i]
+		case closes[i] < closes[i-1]:
+			out[i] -= volumes[i]
+		}
+	}
+	return out
+}
@@ -0,0 +1,90 @@
+package screener
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Type is the static type of an expression node
+type Type int
+
+const (
+	TypeNumber Type = iota
+	TypeBool
+)
+
+func (t Type) String() string {
+	if t == TypeBool {
+		return "boolean"
+	}
+	return "number"
+}
+
+// Node is an expression in the parsed AST
+type Node interface {
+	// Pos returns the 1-based column where the node starts
+	Pos() int
+	// String returns the canonical source form of the node
+	String() string
+}
+
+// NumberLit is a numeric literal
+type NumberLit struct {
+	Value  float64
+	Column int
+}
+
+// FieldRef refers to a per-emiten value declared in the Schema, e.g. close
+type FieldRef struct {
+	Name   string
+	Column int
+}
+
+// Call invokes a Schema function with constant numeric arguments, e.g. sma(50)
+type Call struct {
+	Name   string
+	Args   []float64
+	Column int
+}
+
+// Unary is a prefix operation: numeric negation or logical not
+type Unary struct {
+	Op      string
+	Operand Node
+	Column  int
+}
+
+// Binary is an arithmetic, comparison or logical operation
+type Binary struct {
+	Op          string
+	Left, Right Node
+	Column      int
+}
+
+func (n *NumberLit) Pos() int { return n.Column }
+func (n *FieldRef) Pos() int  { return n.Column }
+func (n *Call) Pos() int      { return n.Column }
+func (n *Unary) Pos() int     { return n.Column }
+func (n *Binary) Pos() int    { return n.Column }
+
+func (n *NumberLit) String() string { return strconv.FormatFloat(n.Value, 'g', -1, 64) }
+func (n *FieldRef) String() string  { return n.Name }
+
+func (n *Call) String() string {
+	args := make([]string, len(n.Args))
+	for i, a := range n.Args {
+		args[i] = strconv.FormatFloat(a, 'g', -1, 64)
+	}
+	return n.Name + "(" + strings.Join(args, ",") + ")"
+}
+
+func (n *Unary) String() string {
+	if n.Op == "not" {
+		return "not " + n.Operand.String()
+	}
+	return n.Op + n.Operand.String()
+}
+
+func (n *Binary) String() string {
+	return "(" + n.Left.String() + " " + n.Op + " " + n.Right.String() + ")"
+}
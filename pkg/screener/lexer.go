@@ -0,0 +1,146 @@
+// Package screener implements the filter expression language used to screen
+// emitens, e.g. "close > sma(50) and rsi(14) < 30 and change_pct > 2".
+//
+// Expressions are parsed into an AST, type-checked against a Schema that
+// declares the available fields and functions, and evaluated against an Env
+// without any reflection or dynamic code execution.
+package screener
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenIdent
+	tokenLParen
+	tokenRParen
+	tokenComma
+	tokenOperator
+	tokenAnd
+	tokenOr
+	tokenNot
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	pos   int // 1-based column of the first character
+	value float64
+}
+
+// ParseError reports a syntax or type error together with the 1-based
+// column in the source expression where it was detected
+type ParseError struct {
+	Position int
+	Message  string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("position %d: %s", e.Position, e.Message)
+}
+
+func errorAt(pos int, format string, args ...interface{}) *ParseError {
+	return &ParseError{Position: pos, Message: fmt.Sprintf(format, args...)}
+}
+
+// twoCharOperators are matched before their single-character prefixes
+var twoCharOperators = []string{"<=", ">=", "==", "!=", "&&", "||"}
+
+func tokenize(src string) ([]token, error) {
+	var tokens []token
+	runes := []rune(src)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		pos := i + 1
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			text := string(runes[start:i])
+			value, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, errorAt(pos, "invalid number %q", text)
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: text, pos: pos, value: value})
+
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			text := string(runes[start:i])
+			switch strings.ToLower(text) {
+			case "and":
+				tokens = append(tokens, token{kind: tokenAnd, text: text, pos: pos})
+			case "or":
+				tokens = append(tokens, token{kind: tokenOr, text: text, pos: pos})
+			case "not":
+				tokens = append(tokens, token{kind: tokenNot, text: text, pos: pos})
+			default:
+				tokens = append(tokens, token{kind: tokenIdent, text: strings.ToLower(text), pos: pos})
+			}
+
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: pos})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: pos})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", pos: pos})
+			i++
+
+		default:
+			matched := false
+			for _, op := range twoCharOperators {
+				if i+1 < len(runes) && string(runes[i:i+2]) == op {
+					tokens = append(tokens, operatorToken(op, pos))
+					i += 2
+					matched = true
+					break
+				}
+			}
+			if matched {
+				continue
+			}
+			if strings.ContainsRune("+-*/<>!=", r) {
+				op := string(r)
+				if op == "=" {
+					op = "==" // a single "=" is accepted as equality
+				}
+				tokens = append(tokens, operatorToken(op, pos))
+				i++
+				continue
+			}
+			return nil, errorAt(pos, "unexpected character %q", r)
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, text: "end of expression", pos: len(runes) + 1}), nil
+}
+
+func operatorToken(op string, pos int) token {
+	switch op {
+	case "&&":
+		return token{kind: tokenAnd, text: op, pos: pos}
+	case "||":
+		return token{kind: tokenOr, text: op, pos: pos}
+	case "!":
+		return token{kind: tokenNot, text: op, pos: pos}
+	}
+	return token{kind: tokenOperator, text: op, pos: pos}
+}
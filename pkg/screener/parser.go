@@ -0,0 +1,252 @@
+package screener
+
+const (
+	// MaxExpressionLength bounds the size of a screen so parsing and
+	// evaluation across every emiten stays cheap
+	MaxExpressionLength = 1000
+	maxNestingDepth     = 64
+)
+
+type parser struct {
+	tokens []token
+	pos    int
+	depth  int
+}
+
+// Parse turns an expression into an untyped AST. Most callers want Compile,
+// which also type-checks the result against a Schema.
+func Parse(src string) (Node, error) {
+	if len([]rune(src)) > MaxExpressionLength {
+		return nil, errorAt(MaxExpressionLength+1, "expression is longer than %d characters", MaxExpressionLength)
+	}
+
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokenEOF {
+		return nil, errorAt(1, "expression is empty")
+	}
+
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, errorAt(tok.pos, "unexpected %q", tok.text)
+	}
+	return node, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) enter(pos int) error {
+	p.depth++
+	if p.depth > maxNestingDepth {
+		return errorAt(pos, "expression is nested deeper than %d levels", maxNestingDepth)
+	}
+	return nil
+}
+
+func (p *parser) leave() {
+	p.depth--
+}
+
+func (p *parser) parseOr() (Node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenOr {
+		op := p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: "or", Left: left, Right: right, Column: op.pos}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenAnd {
+		op := p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: "and", Left: left, Right: right, Column: op.pos}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (Node, error) {
+	if tok := p.peek(); tok.kind == tokenNot {
+		p.next()
+		if err := p.enter(tok.pos); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &Unary{Op: "not", Operand: operand, Column: tok.pos}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (Node, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind == tokenOperator && isComparison(tok.text) {
+		p.next()
+		right, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: tok.text, Left: left, Right: right, Column: tok.pos}
+
+		if next := p.peek(); next.kind == tokenOperator && isComparison(next.text) {
+			return nil, errorAt(next.pos, "comparisons cannot be chained, combine them with \"and\"")
+		}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAdditive() (Node, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for tok := p.peek(); tok.kind == tokenOperator && (tok.text == "+" || tok.text == "-"); tok = p.peek() {
+		p.next()
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: tok.text, Left: left, Right: right, Column: tok.pos}
+	}
+	return left, nil
+}
+
+func (p *parser) parseMultiplicative() (Node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for tok := p.peek(); tok.kind == tokenOperator && (tok.text == "*" || tok.text == "/"); tok = p.peek() {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: tok.text, Left: left, Right: right, Column: tok.pos}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	if tok := p.peek(); tok.kind == tokenOperator && tok.text == "-" {
+		p.next()
+		if err := p.enter(tok.pos); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &Unary{Op: "-", Operand: operand, Column: tok.pos}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Node, error) {
+	tok := p.next()
+
+	switch tok.kind {
+	case tokenNumber:
+		return &NumberLit{Value: tok.value, Column: tok.pos}, nil
+
+	case tokenIdent:
+		if p.peek().kind != tokenLParen {
+			return &FieldRef{Name: tok.text, Column: tok.pos}, nil
+		}
+		return p.parseCall(tok)
+
+	case tokenLParen:
+		if err := p.enter(tok.pos); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			return nil, errorAt(closing.pos, "expected \")\" to close \"(\" at position %d", tok.pos)
+		}
+		return inner, nil
+	}
+
+	return nil, errorAt(tok.pos, "unexpected %q, expected a number, field or \"(\"", tok.text)
+}
+
+// parseCall parses the argument list of a function; arguments must be
+// numeric constants such as the 50 in sma(50)
+func (p *parser) parseCall(name token) (Node, error) {
+	p.next() // "("
+	call := &Call{Name: name.text, Column: name.pos}
+
+	if p.peek().kind == tokenRParen {
+		p.next()
+		return call, nil
+	}
+
+	for {
+		arg := p.next()
+		if arg.kind != tokenNumber {
+			return nil, errorAt(arg.pos, "arguments of %s must be numeric constants", name.text)
+		}
+		call.Args = append(call.Args, arg.value)
+
+		switch sep := p.next(); sep.kind {
+		case tokenComma:
+			continue
+		case tokenRParen:
+			return call, nil
+		default:
+			return nil, errorAt(sep.pos, "expected \",\" or \")\" in call to %s", name.text)
+		}
+	}
+}
+
+func isComparison(op string) bool {
+	switch op {
+	case "<", "<=", ">", ">=", "==", "!=":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,310 @@
+package screener
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Function declares a callable metric such as sma(period)
+type Function struct {
+	Name  string
+	Arity int
+	// Validate optionally rejects out-of-range arguments
+	Validate func(args []float64) error
+	// Lookback returns how many trading sessions of history a call needs
+	Lookback func(args []float64) int
+}
+
+// Schema declares the fields and functions an expression may reference
+type Schema struct {
+	Fields    []string
+	Functions []Function
+}
+
+// Env supplies the values of fields and function calls for one emiten.
+// Unavailable values are reported as NaN.
+type Env interface {
+	Field(name string) float64
+	Call(name string, args []float64) float64
+}
+
+// Program is a parsed and type-checked expression
+type Program struct {
+	root     Node
+	refs     []Node
+	lookback int
+}
+
+// Compile parses src and type-checks it against schema. The expression must
+// evaluate to a boolean.
+func Compile(src string, schema *Schema) (*Program, error) {
+	root, err := Parse(src)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &checker{schema: schema, seen: make(map[string]bool)}
+	typ, err := c.check(root)
+	if err != nil {
+		return nil, err
+	}
+	if typ != TypeBool {
+		return nil, errorAt(root.Pos(), "expression must be a condition, got a %s", typ)
+	}
+
+	return &Program{root: root, refs: c.refs, lookback: c.lookback}, nil
+}
+
+// Refs returns the canonical names of the fields and calls referenced by the
+// expression, in order of first appearance, e.g. ["close", "sma(50)"]
+func (p *Program) Refs() []string {
+	names := make([]string, len(p.refs))
+	for i, ref := range p.refs {
+		names[i] = ref.String()
+	}
+	return names
+}
+
+// Lookback returns the number of sessions of history needed by the calls in
+// the expression; zero when only fields are referenced
+func (p *Program) Lookback() int {
+	return p.lookback
+}
+
+// Values evaluates every referenced field and call against env
+func (p *Program) Values(env Env) map[string]float64 {
+	values := make(map[string]float64, len(p.refs))
+	for _, ref := range p.refs {
+		values[ref.String()] = evalNumber(ref, env)
+	}
+	return values
+}
+
+// Match evaluates the expression against env. A condition whose outcome is
+// unknown because a value is unavailable does not match.
+func (p *Program) Match(env Env) bool {
+	return evalBool(p.root, env) == truthTrue
+}
+
+type checker struct {
+	schema   *Schema
+	refs     []Node
+	seen     map[string]bool
+	lookback int
+}
+
+func (c *checker) addRef(n Node) {
+	if key := n.String(); !c.seen[key] {
+		c.seen[key] = true
+		c.refs = append(c.refs, n)
+	}
+}
+
+func (c *checker) check(n Node) (Type, error) {
+	switch n := n.(type) {
+	case *NumberLit:
+		return TypeNumber, nil
+
+	case *FieldRef:
+		for _, f := range c.schema.Fields {
+			if f == n.Name {
+				c.addRef(n)
+				return TypeNumber, nil
+			}
+		}
+		return 0, errorAt(n.Column, "unknown field %q, expected one of %s", n.Name, strings.Join(c.schema.fieldNames(), ", "))
+
+	case *Call:
+		fn, ok := c.schema.function(n.Name)
+		if !ok {
+			return 0, errorAt(n.Column, "unknown function %q, expected one of %s", n.Name, strings.Join(c.schema.functionNames(), ", "))
+		}
+		if len(n.Args) != fn.Arity {
+			return 0, errorAt(n.Column, "%s takes %d argument(s), got %d", n.Name, fn.Arity, len(n.Args))
+		}
+		if fn.Validate != nil {
+			if err := fn.Validate(n.Args); err != nil {
+				return 0, errorAt(n.Column, "%s: %v", n.Name, err)
+			}
+		}
+		if fn.Lookback != nil {
+			if lb := fn.Lookback(n.Args); lb > c.lookback {
+				c.lookback = lb
+			}
+		}
+		c.addRef(n)
+		return TypeNumber, nil
+
+	case *Unary:
+		typ, err := c.check(n.Operand)
+		if err != nil {
+			return 0, err
+		}
+		want := TypeNumber
+		if n.Op == "not" {
+			want = TypeBool
+		}
+		if typ != want {
+			return 0, errorAt(n.Column, "operator %q expects a %s, got a %s", n.Op, want, typ)
+		}
+		return want, nil
+
+	case *Binary:
+		left, err := c.check(n.Left)
+		if err != nil {
+			return 0, err
+		}
+		right, err := c.check(n.Right)
+		if err != nil {
+			return 0, err
+		}
+
+		operand, result := TypeNumber, TypeNumber
+		switch {
+		case n.Op == "and" || n.Op == "or":
+			operand, result = TypeBool, TypeBool
+		case isComparison(n.Op):
+			result = TypeBool
+		}
+		if left != operand {
+			return 0, errorAt(n.Left.Pos(), "left side of %q must be a %s, got a %s", n.Op, operand, left)
+		}
+		if right != operand {
+			return 0, errorAt(n.Right.Pos(), "right side of %q must be a %s, got a %s", n.Op, operand, right)
+		}
+		return result, nil
+	}
+
+	return 0, fmt.Errorf("unsupported node %T", n)
+}
+
+func (s *Schema) function(name string) (Function, bool) {
+	for _, fn := range s.Functions {
+		if fn.Name == name {
+			return fn, true
+		}
+	}
+	return Function{}, false
+}
+
+func (s *Schema) fieldNames() []string {
+	names := append([]string(nil), s.Fields...)
+	sort.Strings(names)
+	return names
+}
+
+func (s *Schema) functionNames() []string {
+	names := make([]string, len(s.Functions))
+	for i, fn := range s.Functions {
+		names[i] = fn.Name
+	}
+	sort.Strings(names)
+	return names
+}
+
+func evalNumber(n Node, env Env) float64 {
+	switch n := n.(type) {
+	case *NumberLit:
+		return n.Value
+	case *FieldRef:
+		return env.Field(n.Name)
+	case *Call:
+		return env.Call(n.Name, n.Args)
+	case *Unary:
+		return -evalNumber(n.Operand, env)
+	case *Binary:
+		left, right := evalNumber(n.Left, env), evalNumber(n.Right, env)
+		switch n.Op {
+		case "+":
+			return left + right
+		case "-":
+			return left - right
+		case "*":
+			return left * right
+		case "/":
+			if right == 0 {
+				return math.NaN()
+			}
+			return left / right
+		}
+	}
+	return math.NaN()
+}
+
+// truth is the outcome of a condition in three-valued logic
+type truth int
+
+const (
+	truthFalse truth = iota
+	truthTrue
+	// truthUnknown is the outcome of a comparison involving NaN
+	truthUnknown
+)
+
+func truthOf(b bool) truth {
+	if b {
+		return truthTrue
+	}
+	return truthFalse
+}
+
+// evalBool treats comparisons involving NaN as unknown rather than false, so
+// that an emiten whose metric is unavailable matches neither a condition on
+// it nor its negation. "and" is false when either side is false and "or" is
+// true when either side is true, whatever the other side.
+func evalBool(n Node, env Env) truth {
+	switch n := n.(type) {
+	case *Unary:
+		switch operand := evalBool(n.Operand, env); operand {
+		case truthTrue:
+			return truthFalse
+		case truthFalse:
+			return truthTrue
+		default:
+			return operand
+		}
+	case *Binary:
+		switch n.Op {
+		case "and":
+			left, right := evalBool(n.Left, env), evalBool(n.Right, env)
+			if left == truthFalse || right == truthFalse {
+				return truthFalse
+			}
+			if left == truthUnknown || right == truthUnknown {
+				return truthUnknown
+			}
+			return truthTrue
+		case "or":
+			left, right := evalBool(n.Left, env), evalBool(n.Right, env)
+			if left == truthTrue || right == truthTrue {
+				return truthTrue
+			}
+			if left == truthUnknown || right == truthUnknown {
+				return truthUnknown
+			}
+			return truthFalse
+		}
+
+		left, right := evalNumber(n.Left, env), evalNumber(n.Right, env)
+		if math.IsNaN(left) || math.IsNaN(right) {
+			return truthUnknown
+		}
+		switch n.Op {
+		case "<":
+			return truthOf(left < right)
+		case "<=":
+			return truthOf(left <= right)
+		case ">":
+			return truthOf(left > right)
+		case ">=":
+			return truthOf(left >= right)
+		case "==":
+			return truthOf(left == right)
+		case "!=":
+			return truthOf(left != right)
+		}
+	}
+	return truthFalse
+}
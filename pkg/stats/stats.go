@@ -0,0 +1,130 @@
+// Package stats computes descriptive statistics and dependence measures over
+// float64 samples.
+//
+// Functions return NaN when the sample is too small or degenerate for the
+// statistic to be defined, e.g. a correlation of a constant series.
+package stats
+
+import (
+	"math"
+	"sort"
+)
+
+// Mean returns the arithmetic mean
+func Mean(x []float64) float64 {
+	if len(x) == 0 {
+		return math.NaN()
+	}
+	sum := 0.0
+	for _, v := range x {
+		sum += v
+	}
+	return sum / float64(len(x))
+}
+
+// Variance returns the sample variance (n-1 denominator)
+func Variance(x []float64) float64 {
+	return Covariance(x, x)
+}
+
+// StdDev returns the sample standard deviation
+func StdDev(x []float64) float64 {
+	return math.Sqrt(Variance(x))
+}
+
+// Covariance returns the sample covariance (n-1 denominator) of two equally
+// long samples
+func Covariance(x, y []float64) float64 {
+	n := len(x)
+	if n < 2 || n != len(y) {
+		return math.NaN()
+	}
+	mx, my := Mean(x), Mean(y)
+	sum := 0.0
+	for i := range x {
+		sum += (x[i] - mx) * (y[i] - my)
+	}
+	return sum / float64(n-1)
+}
+
+// Pearson returns the Pearson product-moment correlation of two equally long
+// samples
+func Pearson(x, y []float64) float64 {
+	n := len(x)
+	if n < 2 || n != len(y) {
+		return math.NaN()
+	}
+	mx, my := Mean(x), Mean(y)
+	var sxy, sxx, syy float64
+	for i := range x {
+		dx, dy := x[i]-mx, y[i]-my
+		sxy += dx * dy
+		sxx += dx * dx
+		syy += dy * dy
+	}
+	if sxx == 0 || syy == 0 {
+		return math.NaN()
+	}
+	// Rounding can push a perfect correlation just past ±1
+	return math.Max(-1, math.Min(1, sxy/math.Sqrt(sxx*syy)))
+}
+
+// Spearman returns the Spearman rank correlation of two equally long
+// samples: the Pearson correlation of their ranks, with ties ranked by their
+// average position
+func Spearman(x, y []float64) float64 {
+	if len(x) != len(y) {
+		return math.NaN()
+	}
+	return Pearson(Ranks(x), Ranks(y))
+}
+
+// Ranks returns the 1-based rank of each value, giving tied values the mean
+// of the ranks they span
+func Ranks(x []float64) []float64 {
+	order := make([]int, len(x))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return x[order[a]] < x[order[b]] })
+
+	ranks := make([]float64, len(x))
+	for i := 0; i < len(order); {
+		j := i + 1
+		for j < len(order) && x[order[j]] == x[order[i]] {
+			j++
+		}
+		// Positions i..j-1 are tied; their 1-based ranks average to (i+1+j)/2
+		rank := float64(i+1+j) / 2
+		for k := i; k < j; k++ {
+			ranks[order[k]] = rank
+		}
+		i = j
+	}
+	return ranks
+}
+
+// DownsideDeviation returns the root mean square of the shortfalls of x below
+// target, counting observations above target as zero shortfall
+func DownsideDeviation(x []float64, target float64) float64 {
+	if len(x) == 0 {
+		return math.NaN()
+	}
+	sum := 0.0
+	for _, v := range x {
+		if v < target {
+			sum += (v - target) * (v - target)
+		}
+	}
+	return math.Sqrt(sum / float64(len(x)))
+}
+
+// Beta returns the sensitivity of x to benchmark: their covariance over the
+// variance of benchmark
+func Beta(x, benchmark []float64) float64 {
+	variance := Variance(benchmark)
+	if variance == 0 {
+		return math.NaN()
+	}
+	return Covariance(x, benchmark) / variance
+}
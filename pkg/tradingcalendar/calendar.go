@@ -0,0 +1,231 @@
+// Package tradingcalendar models the trading days and intraday sessions of
+// the Indonesia Stock Exchange (IDX). Every time is evaluated in WIB
+// (UTC+7); weekends and the configured exchange holidays are non-trading
+// days.
+package tradingcalendar
+
+import (
+	"time"
+)
+
+// WIB is Western Indonesia Time, the exchange time zone of IDX. Indonesia does
+// not observe daylight saving, so a fixed offset is exact.
+var WIB = time.FixedZone("WIB", 7*60*60)
+
+// Phase is a part of the IDX trading day
+type Phase string
+
+const (
+	PhaseClosed      Phase = "closed"
+	PhasePreOpening  Phase = "pre_opening"
+	PhaseSession1    Phase = "session_1"
+	PhaseLunchBreak  Phase = "lunch_break"
+	PhaseSession2    Phase = "session_2"
+	PhasePreClosing  Phase = "pre_closing"
+	PhasePostTrading Phase = "post_trading"
+)
+
+// AcceptsOrders reports whether orders are entered or matched during the
+// phase, either in continuous trading or in the opening and closing call
+// auctions
+func (p Phase) AcceptsOrders() bool {
+	switch p {
+	case PhasePreOpening, PhaseSession1, PhaseSession2, PhasePreClosing:
+		return true
+	}
+	return false
+}
+
+// Period is a phase from Start until End, both offsets from midnight WIB
+type Period struct {
+	Phase Phase
+	Start time.Duration
+	End   time.Duration
+}
+
+func clock(hour, minute int) time.Duration {
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute
+}
+
+// The regular market schedule, rounded to the minute so the periods are
+// contiguous: the pre-opening auction matches until 08:59 and post-trading
+// officially starts at 16:01, after the closing auction. Fridays have a
+// longer midday break for Friday prayers.
+var (
+	mondayToThursday = []Period{
+		{PhasePreOpening, clock(8, 45), clock(9, 0)},
+		{PhaseSession1, clock(9, 0), clock(12, 0)},
+		{PhaseLunchBreak, clock(12, 0), clock(13, 30)},
+		{PhaseSession2, clock(13, 30), clock(15, 50)},
+		{PhasePreClosing, clock(15, 50), clock(16, 0)},
+		{PhasePostTrading, clock(16, 0), clock(16, 15)},
+	}
+	friday = []Period{
+		{PhasePreOpening, clock(8, 45), clock(9, 0)},
+		{PhaseSession1, clock(9, 0), clock(11, 30)},
+		{PhaseLunchBreak, clock(11, 30), clock(14, 0)},
+		{PhaseSession2, clock(14, 0), clock(15, 50)},
+		{PhasePreClosing, clock(15, 50), clock(16, 0)},
+		{PhasePostTrading, clock(16, 0), clock(16, 15)},
+	}
+)
+
+// maxNonTradingDays bounds the searches for the next or previous trading
+// day, which are far longer than any run of IDX holidays
+const maxNonTradingDays = 60
+
+// Calendar answers trading-day and session questions for IDX
+type Calendar struct {
+	holidays map[string]string
+	years    map[int]bool
+}
+
+// Holiday is a weekday on which the exchange is closed. Date is formatted
+// as YYYY-MM-DD.
+type Holiday struct {
+	Date string `json:"date"`
+	Name string `json:"name"`
+}
+
+// New creates a calendar closed on weekends and the given holidays. The
+// years of the holidays are the years the calendar covers.
+func New(holidays []Holiday) *Calendar {
+	c := &Calendar{holidays: make(map[string]string, len(holidays)), years: make(map[int]bool)}
+	for _, h := range holidays {
+		c.holidays[h.Date] = h.Name
+		if date, err := time.Parse("2006-01-02", h.Date); err == nil {
+			c.years[date.Year()] = true
+		}
+	}
+	return c
+}
+
+// Covers reports whether the holidays of the year of t are known. In other
+// years only weekends are closed.
+func (c *Calendar) Covers(t time.Time) bool {
+	return c.years[t.In(WIB).Year()]
+}
+
+// Day returns midnight WIB of the day of t
+func Day(t time.Time) time.Time {
+	year, month, day := t.In(WIB).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, WIB)
+}
+
+// Holiday returns the name of the holiday on the day of t
+func (c *Calendar) Holiday(t time.Time) (string, bool) {
+	name, ok := c.holidays[t.In(WIB).Format("2006-01-02")]
+	return name, ok
+}
+
+// IsTradingDay reports whether the exchange trades on the day of t
+func (c *Calendar) IsTradingDay(t time.Time) bool {
+	switch t.In(WIB).Weekday() {
+	case time.Saturday, time.Sunday:
+		return false
+	}
+	_, holiday := c.Holiday(t)
+	return !holiday
+}
+
+// PreviousTradingDay returns midnight WIB of the last trading day before the
+// day of t
+func (c *Calendar) PreviousTradingDay(t time.Time) time.Time {
+	day := Day(t)
+	for i := 0; i < maxNonTradingDays; i++ {
+		day = day.AddDate(0, 0, -1)
+		if c.IsTradingDay(day) {
+			break
+		}
+	}
+	return day
+}
+
+// NextTradingDay returns midnight WIB of the first trading day after the day
+// of t
+func (c *Calendar) NextTradingDay(t time.Time) time.Time {
+	day := Day(t)
+	for i := 0; i < maxNonTradingDays; i++ {
+		day = day.AddDate(0, 0, 1)
+		if c.IsTradingDay(day) {
+			break
+		}
+	}
+	return day
+}
+
+// Schedule returns the periods of the day of t, or nil on a non-trading day
+func (c *Calendar) Schedule(t time.Time) []Period {
+	if !c.IsTradingDay(t) {
+		return nil
+	}
+	if t.In(WIB).Weekday() == time.Friday {
+		return friday
+	}
+	return mondayToThursday
+}
+
+// PhaseAt returns the phase of the trading day at t
+func (c *Calendar) PhaseAt(t time.Time) Phase {
+	offset := t.Sub(Day(t))
+	for _, p := range c.Schedule(t) {
+		if offset >= p.Start && offset < p.End {
+			return p.Phase
+		}
+	}
+	return PhaseClosed
+}
+
+// NextOpen returns the first start of session 1 after t
+func (c *Calendar) NextOpen(t time.Time) time.Time {
+	return c.next(t, PhaseSession1, func(p Period) time.Duration { return p.Start })
+}
+
+// NextClose returns the first end of the closing auction after t, when the
+// closing price of the day is set
+func (c *Calendar) NextClose(t time.Time) time.Time {
+	return c.next(t, PhasePreClosing, func(p Period) time.Duration { return p.End })
+}
+
+// next returns the first boundary of a phase after t, searching from the
+// day of t
+func (c *Calendar) next(t time.Time, phase Phase, boundary func(Period) time.Duration) time.Time {
+	day := Day(t)
+	for i := 0; i <= maxNonTradingDays; i++ {
+		for _, p := range c.Schedule(day) {
+			if p.Phase != phase {
+				continue
+			}
+			if at := day.Add(boundary(p)); at.After(t) {
+				return at
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return time.Time{}
+}
+
+// Status describes the market at an instant
+type Status struct {
+	Time               time.Time
+	Phase              Phase
+	TradingDay         bool
+	Holiday            string
+	NextOpen           time.Time
+	NextClose          time.Time
+	PreviousTradingDay time.Time
+}
+
+// StatusAt returns the market status at t
+func (c *Calendar) StatusAt(t time.Time) Status {
+	holiday, _ := c.Holiday(t)
+	return Status{
+		Time:               t.In(WIB),
+		Phase:              c.PhaseAt(t),
+		TradingDay:         c.IsTradingDay(t),
+		Holiday:            holiday,
+		NextOpen:           c.NextOpen(t),
+		NextClose:          c.NextClose(t),
+		PreviousTradingDay: c.PreviousTradingDay(t),
+	}
+}
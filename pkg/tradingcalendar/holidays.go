@@ -0,0 +1,41 @@
+package tradingcalendar
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// holidayFile is the layout of a holiday config file
+type holidayFile struct {
+	Holidays []Holiday `json:"holidays"`
+}
+
+// ParseHolidays reads holidays from a JSON document of the form
+// {"holidays": [{"date": "2026-01-01", "name": "New Year's Day"}]}
+func ParseHolidays(data []byte) ([]Holiday, error) {
+	var file holidayFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid holiday file: %w", err)
+	}
+	for _, h := range file.Holidays {
+		if _, err := time.Parse("2006-01-02", h.Date); err != nil {
+			return nil, fmt.Errorf("holiday %q: date must be YYYY-MM-DD, got %q", h.Name, h.Date)
+		}
+	}
+	return file.Holidays, nil
+}
+
+// LoadFile creates a calendar with the holidays of a JSON config file
+func LoadFile(path string) (*Calendar, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	holidays, err := ParseHolidays(data)
+	if err != nil {
+		return nil, err
+	}
+	return New(holidays), nil
+}
@@ -0,0 +1,68 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"api-web-scrapping/pkg/cron"
+)
+
+var wib = time.FixedZone("WIB", 7*60*60)
+
+func TestSchedule_Next_TableDriven(t *testing.T) {
+	// Wednesday 2026-01-21 08:30 WIB
+	from := time.Date(2026, time.January, 21, 8, 30, 0, 0, wib)
+
+	tests := []struct {
+		name string
+		expr string
+		want time.Time
+	}{
+		{"every minute", "* * * * *", time.Date(2026, time.January, 21, 8, 31, 0, 0, wib)},
+		{"weekday morning later today", "45 8 * * 1-5", time.Date(2026, time.January, 21, 8, 45, 0, 0, wib)},
+		{"weekday morning already passed", "0 8 * * mon-fri", time.Date(2026, time.January, 22, 8, 0, 0, 0, wib)},
+		{"step minutes", "*/20 9 * * *", time.Date(2026, time.January, 21, 9, 0, 0, 0, wib)},
+		{"list of hours", "0 12,16 * * *", time.Date(2026, time.January, 21, 12, 0, 0, 0, wib)},
+		{"friday only", "0 9 * * fri", time.Date(2026, time.January, 23, 9, 0, 0, 0, wib)},
+		{"sunday as 7", "0 9 * * 7", time.Date(2026, time.January, 25, 9, 0, 0, 0, wib)},
+		{"first of next month", "0 0 1 * *", time.Date(2026, time.February, 1, 0, 0, 0, 0, wib)},
+		{"named month", "30 10 15 mar *", time.Date(2026, time.March, 15, 10, 30, 0, 0, wib)},
+		{"day of month or day of week", "0 9 25 * sat", time.Date(2026, time.January, 24, 9, 0, 0, 0, wib)},
+		{"stepped day of month and day of week", "0 9 */2 * 1", time.Date(2026, time.February, 9, 9, 0, 0, 0, wib)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := cron.Parse(tt.expr, wib)
+
+			assert.NoError(t, err)
+			assert.True(t, tt.want.Equal(schedule.Next(from)), "got %s", schedule.Next(from))
+		})
+	}
+}
+
+func TestSchedule_Next_EvaluatesInLocation(t *testing.T) {
+	schedule, err := cron.Parse("0 9 * * *", wib)
+	assert.NoError(t, err)
+
+	// 03:00 UTC is 10:00 WIB, so the next 09:00 WIB is tomorrow at 02:00 UTC
+	next := schedule.Next(time.Date(2026, time.January, 21, 3, 0, 0, 0, time.UTC))
+
+	assert.True(t, time.Date(2026, time.January, 22, 2, 0, 0, 0, time.UTC).Equal(next))
+}
+
+func TestSchedule_Next_Impossible(t *testing.T) {
+	schedule, err := cron.Parse("0 0 31 2 *", wib)
+	assert.NoError(t, err)
+
+	assert.True(t, schedule.Next(time.Now()).IsZero())
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "*/0 * * * *", "5-1 * * * *", "* * * * funday"} {
+		_, err := cron.Parse(expr, wib)
+		assert.Error(t, err, expr)
+	}
+}
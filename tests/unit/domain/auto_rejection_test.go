@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/services"
+)
+
+func TestAutoRejectionLimits_Tiers(t *testing.T) {
+	rules := services.DefaultAutoRejectionRules()
+
+	tests := []struct {
+		name      string
+		board     string
+		prevClose float64
+		upper     float64
+		lower     float64
+	}{
+		{"up to 200", entities.BoardMain, 100, 135, 65},
+		{"tier boundary is inclusive", entities.BoardMain, 200, 270, 130},
+		{"up to 5000", entities.BoardMain, 1000, 1250, 750},
+		{"above 5000", entities.BoardMain, 10000, 12000, 8000},
+		{"rounds inside the band", entities.BoardMain, 333, 416, 250},
+		{"snaps to ticks", entities.BoardMain, 1234, 1540, 930},
+		{"floor price", entities.BoardMain, 60, 81, 50},
+		{"acceleration board", entities.BoardAcceleration, 1000, 1100, 900},
+		{"one rupiah minimum", entities.BoardAcceleration, 5, 6, 4},
+		{"unknown board uses main", "", 1000, 1250, 750},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits, ok := rules.Limits(tt.board, tt.prevClose)
+			require.True(t, ok)
+			assert.Equal(t, tt.upper, limits.Upper)
+			assert.Equal(t, tt.lower, limits.Lower)
+		})
+	}
+
+	_, ok := rules.Limits(entities.BoardMain, 0)
+	assert.False(t, ok)
+}
+
+func TestAutoRejectionLimits_Hits(t *testing.T) {
+	rules := services.DefaultAutoRejectionRules()
+
+	limits, _ := rules.Limits(entities.BoardMain, 1000)
+	assert.True(t, limits.HitUpper(1250))
+	assert.False(t, limits.HitUpper(1245))
+	assert.True(t, limits.HitLower(750))
+	assert.False(t, limits.HitLower(755))
+
+	// A stock resting at the floor price is not at ARB
+	atFloor, _ := rules.Limits(entities.BoardMain, 50)
+	assert.Equal(t, 50.0, atFloor.Lower)
+	assert.False(t, atFloor.HitLower(50))
+}
+
+func TestParseAutoRejectionRules(t *testing.T) {
+	rules, err := services.ParseAutoRejectionRules([]byte(`{"boards": {"watchlist": {"floor_price": 1, "tiers": [{"upper_pct": 15, "lower_pct": 15}]}}}`))
+	require.NoError(t, err)
+
+	limits, _ := rules.Limits(entities.BoardWatchlist, 100)
+	assert.Equal(t, 115.0, limits.Upper)
+
+	// Boards left out keep their defaults
+	limits, _ = rules.Limits(entities.BoardMain, 100)
+	assert.Equal(t, 135.0, limits.Upper)
+
+	invalid := []string{
+		`not json`,
+		`{"boards": {"main": {"tiers": []}}}`,
+		`{"boards": {"main": {"tiers": [{"upper_pct": 0, "lower_pct": 10}]}}}`,
+		`{"boards": {"main": {"tiers": [{"upper_pct": 10, "lower_pct": 100}]}}}`,
+		`{"boards": {"main": {"tiers": [{"up_to": 200, "upper_pct": 35, "lower_pct": 35}]}}}`,
+		`{"boards": {"main": {"tiers": [{"up_to": 500, "upper_pct": 35, "lower_pct": 35}, {"up_to": 200, "upper_pct": 25, "lower_pct": 25}, {"upper_pct": 20, "lower_pct": 20}]}}}`,
+	}
+	for _, doc := range invalid {
+		_, err := services.ParseAutoRejectionRules([]byte(doc))
+		assert.Error(t, err, doc)
+	}
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/services"
+)
+
+func dailyCandle(day time.Time, high, low, close float64) entities.Candle {
+	return entities.Candle{Interval: entities.CandleInterval1d, Time: day, Open: close, High: high, Low: low, Close: close}
+}
+
+func TestComputeBreadth(t *testing.T) {
+	d1 := wib(2026, time.January, 19, 0, 0)
+	d2 := d1.AddDate(0, 0, 1)
+	d3 := d1.AddDate(0, 0, 2)
+
+	candles := map[string][]entities.Candle{
+		"AAAA": {
+			dailyCandle(d1, 105, 95, 100),
+			dailyCandle(d2, 110, 100, 108), // advance, new high
+			dailyCandle(d3, 109, 101, 102), // decline
+		},
+		"BBBB": {
+			dailyCandle(d1, 55, 45, 50),
+			dailyCandle(d2, 50, 40, 42), // decline, new low
+			dailyCandle(d3, 44, 41, 42), // unchanged
+		},
+		"CCCC": {
+			// Listed on d3: no previous session, so not counted
+			dailyCandle(d3, 20, 10, 15),
+		},
+	}
+
+	series := services.ComputeBreadth(candles, d2, d3.Add(24*time.Hour-time.Nanosecond))
+
+	if assert.Len(t, series, 2) {
+		assert.Equal(t, services.BreadthDay{Date: d2, Advancers: 1, Decliners: 1, NewHighs: 1, NewLows: 1, NetAdvances: 0, ADLine: 0}, series[0])
+		assert.Equal(t, services.BreadthDay{Date: d3, Decliners: 1, Unchanged: 1, NetAdvances: -1, ADLine: -1}, series[1])
+	}
+}
+
+func TestComputeBreadth_HighsExpireAfter52Weeks(t *testing.T) {
+	start := wib(2025, time.January, 6, 0, 0)
+	candles := []entities.Candle{dailyCandle(start, 200, 90, 100)}
+	// A year of flat sessions below the first high
+	for day := start.AddDate(0, 0, 7); day.Before(start.Add(services.BreadthHighLowWindow)); day = day.AddDate(0, 0, 7) {
+		candles = append(candles, dailyCandle(day, 150, 100, 120))
+	}
+	last := start.Add(services.BreadthHighLowWindow).AddDate(0, 0, 1)
+	candles = append(candles, dailyCandle(last, 160, 110, 125))
+
+	series := services.ComputeBreadth(map[string][]entities.Candle{"AAAA": candles}, last, last)
+
+	if assert.Len(t, series, 1) {
+		assert.Equal(t, 1, series[0].NewHighs, "the 200 high fell out of the window")
+		assert.Equal(t, 0, series[0].NewLows)
+	}
+}
@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/services"
+)
+
+func wib(year int, month time.Month, day, hour, min int) time.Time {
+	return time.Date(year, month, day, hour, min, 0, 0, services.WIB)
+}
+
+func snapshot(at time.Time, open, high, low, close float64) entities.MarketData {
+	return entities.MarketData{
+		Emiten:     "BBCA",
+		OpenPrice:  open,
+		HighPrice:  high,
+		LowPrice:   low,
+		ClosePrice: close,
+		Date:       at,
+	}
+}
+
+func TestBucketStart_TableDriven(t *testing.T) {
+	// Wednesday 2026-01-21 10:37 WIB
+	at := wib(2026, time.January, 21, 10, 37)
+
+	tests := []struct {
+		name     string
+		interval entities.CandleInterval
+		want     time.Time
+	}{
+		{"1m", entities.CandleInterval1m, wib(2026, time.January, 21, 10, 37)},
+		{"5m", entities.CandleInterval5m, wib(2026, time.January, 21, 10, 35)},
+		{"15m", entities.CandleInterval15m, wib(2026, time.January, 21, 10, 30)},
+		{"1h anchored to session open", entities.CandleInterval1h, wib(2026, time.January, 21, 10, 0)},
+		{"1d", entities.CandleInterval1d, wib(2026, time.January, 21, 0, 0)},
+		{"1w starts on monday", entities.CandleInterval1w, wib(2026, time.January, 19, 0, 0)},
+		{"1M", entities.CandleInterval1M, wib(2026, time.January, 1, 0, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.True(t, tt.want.Equal(services.BucketStart(at, tt.interval)))
+		})
+	}
+}
+
+func TestBucketStart_ConvertsToWIB(t *testing.T) {
+	// 02:05 UTC is 09:05 WIB
+	at := time.Date(2026, time.January, 21, 2, 5, 0, 0, time.UTC)
+
+	got := services.BucketStart(at, entities.CandleInterval15m)
+
+	assert.True(t, wib(2026, time.January, 21, 9, 0).Equal(got))
+	assert.Equal(t, services.WIB, got.Location())
+}
+
+func TestBucketStart_PreOpeningFloorsBeforeSession(t *testing.T) {
+	got := services.BucketStart(wib(2026, time.January, 21, 8, 50), entities.CandleInterval15m)
+
+	assert.True(t, wib(2026, time.January, 21, 8, 45).Equal(got))
+}
+
+func TestAggregateCandles_RollsSnapshotsIntoBuckets(t *testing.T) {
+	data := []entities.MarketData{
+		snapshot(wib(2026, time.January, 21, 9, 14), 9200, 9260, 9180, 9240),
+		snapshot(wib(2026, time.January, 21, 9, 1), 9200, 9220, 9190, 9210),
+		snapshot(wib(2026, time.January, 21, 9, 7), 9200, 9300, 9150, 9250),
+		snapshot(wib(2026, time.January, 21, 9, 20), 9200, 9310, 9150, 9300),
+	}
+
+	candles := services.AggregateCandles(data, entities.CandleInterval15m)
+
+	assert.Len(t, candles, 2)
+
+	first := candles[0]
+	assert.True(t, wib(2026, time.January, 21, 9, 0).Equal(first.Time))
+	assert.Equal(t, 9200.0, first.Open)
+	assert.Equal(t, 9300.0, first.High)
+	assert.Equal(t, 9150.0, first.Low)
+	assert.Equal(t, 9240.0, first.Close)
+	assert.Equal(t, 3, first.SampleCount)
+	assert.Equal(t, "BBCA", first.Emiten)
+	assert.Equal(t, entities.CandleInterval15m, first.Interval)
+
+	second := candles[1]
+	assert.True(t, wib(2026, time.January, 21, 9, 15).Equal(second.Time))
+	assert.Equal(t, 9300.0, second.Close)
+	assert.Equal(t, 1, second.SampleCount)
+}
+
+func TestAggregateCandles_Volume(t *testing.T) {
+	withVolume := func(md entities.MarketData, volume int64) entities.MarketData {
+		md.Volume = &volume
+		return md
+	}
+	data := []entities.MarketData{
+		withVolume(snapshot(wib(2026, time.January, 21, 9, 5), 9200, 9220, 9190, 9210), 1000),
+		withVolume(snapshot(wib(2026, time.January, 21, 9, 20), 9200, 9260, 9180, 9240), 4000),
+		// A corrected total below the previous one adds nothing
+		withVolume(snapshot(wib(2026, time.January, 21, 9, 25), 9200, 9260, 9180, 9250), 3500),
+		withVolume(snapshot(wib(2026, time.January, 21, 15, 50), 9200, 9300, 9150, 9300), 9000),
+		// The next session starts a new running total
+		withVolume(snapshot(wib(2026, time.January, 22, 9, 5), 9300, 9325, 9300, 9325), 2500),
+		snapshot(wib(2026, time.January, 22, 9, 10), 9300, 9325, 9300, 9300),
+	}
+
+	intraday := services.AggregateCandles(data, entities.CandleInterval1h)
+	assert.Len(t, intraday, 3)
+	assert.Equal(t, 4000.0, intraday[0].Volume)
+	assert.Equal(t, 5000.0, intraday[1].Volume)
+	assert.Equal(t, 2500.0, intraday[2].Volume)
+
+	weekly := services.AggregateCandles(data, entities.CandleInterval1w)
+	assert.Len(t, weekly, 1)
+	assert.Equal(t, 11500.0, weekly[0].Volume)
+}
+
+func TestMarketData_VWAP(t *testing.T) {
+	volume, value := int64(250000), 2.3e9
+	md := entities.MarketData{Volume: &volume, Value: &value}
+
+	assert.InDelta(t, 9200.0, *md.VWAP(), 1e-9)
+	assert.Equal(t, int64(2500), *md.Lots())
+	assert.Nil(t, (&entities.MarketData{Volume: &volume}).VWAP())
+	assert.Nil(t, (&entities.MarketData{}).Lots())
+}
+
+func TestAggregateCandles_Empty(t *testing.T) {
+	assert.Empty(t, services.AggregateCandles(nil, entities.CandleInterval1d))
+}
+
+func TestParseCandleInterval_Invalid(t *testing.T) {
+	_, err := entities.ParseCandleInterval("2h")
+
+	assert.Error(t, err)
+}
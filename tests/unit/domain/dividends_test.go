@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/services"
+)
+
+func TestTrailingDividends(t *testing.T) {
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+	}
+	actions := []entities.CorporateAction{
+		{Type: entities.CorporateActionCashDividend, ExDate: day(2025, time.June, 1), Amount: 500},
+		{Type: entities.CorporateActionCashDividend, ExDate: day(2025, time.November, 10), Amount: 100},
+		{Type: entities.CorporateActionSplit, ExDate: day(2026, time.January, 5), RatioFrom: 1, RatioTo: 5},
+		{Type: entities.CorporateActionCashDividend, ExDate: day(2026, time.May, 20), Amount: 30},
+		{Type: entities.CorporateActionCashDividend, ExDate: day(2026, time.July, 1), Amount: 40},
+	}
+
+	total, dividends := services.TrailingDividends(actions, day(2026, time.June, 1))
+
+	// The June 2025 dividend falls on the window start and is excluded; the
+	// November one is restated for the 1:5 split
+	assert.Len(t, dividends, 2)
+	assert.InDelta(t, 100.0/5+30, total, 1e-9)
+}
+
+func TestSharesPerShare(t *testing.T) {
+	exDate := time.Date(2026, time.March, 3, 0, 0, 0, 0, time.UTC)
+	actions := []entities.CorporateAction{
+		{Type: entities.CorporateActionSplit, ExDate: exDate, RatioFrom: 1, RatioTo: 2},
+		{Type: entities.CorporateActionBonus, ExDate: exDate.AddDate(0, 1, 0), RatioFrom: 4, RatioTo: 1},
+		{Type: entities.CorporateActionRights, ExDate: exDate.AddDate(0, 2, 0), RatioFrom: 4, RatioTo: 1, Price: 500},
+	}
+
+	assert.InDelta(t, 2.5, services.SharesPerShare(actions, exDate.AddDate(0, 0, -1), exDate.AddDate(1, 0, 0)), 1e-12)
+	assert.InDelta(t, 1.25, services.SharesPerShare(actions, exDate, exDate.AddDate(1, 0, 0)), 1e-12)
+	assert.InDelta(t, 1.0, services.SharesPerShare(actions, exDate.AddDate(0, 0, -1), exDate.AddDate(0, 0, -1)), 1e-12)
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/services"
+)
+
+func searchIndex() *services.EmitenIndex {
+	index := services.NewEmitenIndex()
+	index.Rebuild([]entities.Emiten{
+		{Ticker: "BBCA", CompanyName: "Bank Central Asia Tbk."},
+		{Ticker: "BBRI", CompanyName: "Bank Rakyat Indonesia (Persero) Tbk."},
+		{Ticker: "BRIS", CompanyName: "Bank Syariah Indonesia Tbk."},
+		{Ticker: "BANK", CompanyName: "Bank Aladin Syariah Tbk."},
+		{Ticker: "TLKM", CompanyName: "Telkom Indonesia (Persero) Tbk."},
+		{Ticker: "ASII", CompanyName: "Astra International Tbk."},
+	}, index.Generation())
+	return index
+}
+
+func tickers(results []services.EmitenSearchResult) []string {
+	out := make([]string, len(results))
+	for i, r := range results {
+		out[i] = r.Emiten.Ticker
+	}
+	return out
+}
+
+func TestEmitenIndex_TickerPrefixRanksFirst(t *testing.T) {
+	results := searchIndex().Search("bank", 10)
+
+	if assert.NotEmpty(t, results) {
+		assert.Equal(t, "BANK", results[0].Emiten.Ticker)
+		assert.Equal(t, services.MatchTicker, results[0].MatchedOn)
+	}
+	// Every other bank matches on a name prefix
+	assert.ElementsMatch(t, []string{"BANK", "BBCA", "BBRI", "BRIS"}, tickers(results))
+}
+
+func TestEmitenIndex_TickerPrefix(t *testing.T) {
+	assert.Equal(t, []string{"BBCA", "BBRI"}, tickers(searchIndex().Search("bb", 10)))
+}
+
+func TestEmitenIndex_NameMatchesCaseInsensitive(t *testing.T) {
+	assert.Equal(t, []string{"BBCA"}, tickers(searchIndex().Search("CENTRAL ASIA", 10)))
+	// Earlier words rank higher: "persero" is the third word of Telkom's name
+	assert.Equal(t, []string{"TLKM", "BBRI"}, tickers(searchIndex().Search("persero", 10)))
+}
+
+func TestEmitenIndex_ToleratesTypos(t *testing.T) {
+	results := searchIndex().Search("astar internasional", 10)
+
+	assert.Equal(t, []string{"ASII"}, tickers(results))
+	assert.Equal(t, []string{"BBCA"}, tickers(searchIndex().Search("bnak centrl", 10)))
+}
+
+func TestEmitenIndex_LimitAndEmptyQuery(t *testing.T) {
+	index := searchIndex()
+
+	assert.Len(t, index.Search("bank", 2), 2)
+	assert.Empty(t, index.Search("  ", 10))
+	assert.Empty(t, index.Search("zzzz", 10))
+}
+
+func TestEmitenIndex_Invalidate(t *testing.T) {
+	index := searchIndex()
+	assert.True(t, index.Built())
+
+	index.Invalidate()
+	assert.False(t, index.Built())
+}
+
+func TestEmitenIndex_RebuildAfterInvalidate(t *testing.T) {
+	index := services.NewEmitenIndex()
+	generation := index.Generation()
+
+	// The list changes while the stale one is being loaded
+	index.Invalidate()
+	index.Rebuild([]entities.Emiten{{Ticker: "BBCA", CompanyName: "Bank Central Asia Tbk."}}, generation)
+
+	assert.False(t, index.Built())
+	assert.Equal(t, []string{"BBCA"}, tickers(index.Search("bbca", 10)))
+
+	index.Rebuild([]entities.Emiten{{Ticker: "BBCA", CompanyName: "Bank Central Asia Tbk."}}, index.Generation())
+	assert.True(t, index.Built())
+}
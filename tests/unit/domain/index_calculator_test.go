@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/services"
+)
+
+func TestIndexWeights(t *testing.T) {
+	constituents := []entities.IndexConstituent{{Emiten: "AAAA", FreeFloat: 0.5}, {Emiten: "BBBB"}}
+	prices := map[string]float64{"AAAA": 100, "BBBB": 50}
+	shares := map[string]int64{"AAAA": 1000, "BBBB": 4000}
+
+	weights := func(weighting string) []float64 {
+		weighted, err := services.IndexWeights(weighting, constituents, prices, shares)
+		require.NoError(t, err)
+		return []float64{weighted[0].Weight, weighted[1].Weight}
+	}
+
+	assert.Equal(t, []float64{1000, 4000}, weights(entities.IndexWeightingMarketCap))
+	assert.Equal(t, []float64{500, 4000}, weights(entities.IndexWeightingFreeFloat))
+	assert.Equal(t, []float64{1, 1}, weights(entities.IndexWeightingPrice))
+	assert.Equal(t, []float64{0.01, 0.02}, weights(entities.IndexWeightingEqual))
+
+	_, err := services.IndexWeights(entities.IndexWeightingMarketCap, constituents, prices, map[string]int64{"AAAA": 1})
+	assert.Error(t, err, "shares of BBBB are unknown")
+}
+
+func TestComputeIndexCandles_RebalanceKeepsLevelContinuous(t *testing.T) {
+	d1 := wib(2026, time.January, 19, 0, 0)
+	d2 := d1.AddDate(0, 0, 1)
+
+	snap := func(emiten string, at time.Time, open, close float64) entities.MarketData {
+		md := snapshot(at, open, close, close, close)
+		md.Emiten = emiten
+		return md
+	}
+	snapshots := map[string][]entities.MarketData{
+		"AAAA": {
+			snap("AAAA", d1.Add(10*time.Hour), 100, 100),
+			snap("AAAA", d2.Add(10*time.Hour), 100, 110),
+			snap("AAAA", d2.Add(15*time.Hour), 100, 105),
+		},
+		"BBBB": {
+			snap("BBBB", d1.Add(10*time.Hour), 50, 50),
+			snap("BBBB", d2.Add(11*time.Hour), 50, 40),
+		},
+		"CCCC": {
+			snap("CCCC", d1.Add(10*time.Hour), 200, 200),
+			snap("CCCC", d2.Add(10*time.Hour), 200, 220),
+		},
+	}
+
+	price := func(emitens ...string) []entities.IndexConstituent {
+		constituents := make([]entities.IndexConstituent, len(emitens))
+		for i, e := range emitens {
+			constituents[i] = entities.IndexConstituent{Emiten: e, Weight: 1}
+		}
+		return constituents
+	}
+
+	// Base on d1: (100 + 50) / 1.5 = 100. From d2, BBBB is replaced by CCCC:
+	// at d1 closes the aggregate goes from 150 to 300, so the divisor doubles.
+	periods := []entities.IndexPeriod{
+		{EffectiveFrom: time.Date(2026, time.January, 19, 0, 0, 0, 0, time.UTC), Divisor: 1.5, Constituents: price("AAAA", "BBBB")},
+		{EffectiveFrom: time.Date(2026, time.January, 20, 0, 0, 0, 0, time.UTC), Divisor: 3, Constituents: price("AAAA", "CCCC")},
+	}
+
+	candles := services.ComputeIndexCandles("TEST", periods, snapshots, d1, d2.Add(24*time.Hour-time.Nanosecond))
+
+	require.Len(t, candles, 2)
+	assert.Equal(t, "TEST", candles[0].Emiten)
+	assert.Equal(t, d1, candles[0].Time)
+	assert.InDelta(t, 100, candles[0].Close, 1e-9)
+
+	// d2 opens at (100 + 200) / 3 = 100 and peaks at (110 + 220) / 3 = 110
+	// before AAAA closes at 105: (105 + 220) / 3
+	second := candles[1]
+	assert.InDelta(t, 100, second.Open, 1e-9)
+	assert.InDelta(t, 110, second.High, 1e-9)
+	assert.InDelta(t, 100, second.Low, 1e-9)
+	assert.InDelta(t, 325.0/3, second.Close, 1e-9)
+}
+
+func TestComputeIndexCandles_CarriesLastCloseForIdleConstituents(t *testing.T) {
+	d1 := wib(2026, time.January, 19, 0, 0)
+	d2 := d1.AddDate(0, 0, 1)
+
+	a1 := snapshot(d1.Add(10*time.Hour), 100, 100, 100, 100)
+	a2 := snapshot(d2.Add(10*time.Hour), 100, 120, 120, 120)
+	b1 := snapshot(d1.Add(10*time.Hour), 50, 50, 50, 50)
+	b1.Emiten = "BBBB"
+
+	periods := []entities.IndexPeriod{{
+		EffectiveFrom: time.Date(2026, time.January, 19, 0, 0, 0, 0, time.UTC),
+		Divisor:       1.5,
+		Constituents:  []entities.IndexConstituent{{Emiten: "BBCA", Weight: 1}, {Emiten: "BBBB", Weight: 1}},
+	}}
+
+	candles := services.ComputeIndexCandles("TEST", periods, map[string][]entities.MarketData{
+		"BBCA": {a1, a2},
+		"BBBB": {b1},
+	}, d2, d2.Add(24*time.Hour-time.Nanosecond))
+
+	require.Len(t, candles, 1)
+	assert.InDelta(t, (120.0+50)/1.5, candles[0].Close, 1e-9)
+}
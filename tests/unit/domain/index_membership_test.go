@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"api-web-scrapping/internal/domain/entities"
+)
+
+func date(value string) time.Time {
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func membership(from, to string) *entities.IndexMembership {
+	m := &entities.IndexMembership{IndexCode: "LQ45", Emiten: "BBCA", EffectiveFrom: date(from)}
+	if to != "" {
+		end := date(to)
+		m.EffectiveTo = &end
+	}
+	return m
+}
+
+func TestIndexMembership_ActiveOn(t *testing.T) {
+	m := membership("2025-02-01", "2025-07-31")
+
+	assert.False(t, m.ActiveOn(date("2025-01-31")))
+	assert.True(t, m.ActiveOn(date("2025-02-01")))
+	assert.True(t, m.ActiveOn(date("2025-07-31")), "to is inclusive")
+	assert.False(t, m.ActiveOn(date("2025-08-01")))
+	assert.True(t, membership("2025-02-01", "").ActiveOn(date("2030-01-01")))
+}
+
+func TestIndexMembership_Overlaps(t *testing.T) {
+	first := membership("2025-02-01", "2025-07-31")
+
+	assert.False(t, first.Overlaps(membership("2025-08-01", "")), "consecutive periods")
+	assert.True(t, first.Overlaps(membership("2025-07-31", "")), "shared last day")
+	assert.True(t, membership("2024-01-01", "").Overlaps(first))
+
+	other := membership("2025-03-01", "")
+	other.Emiten = "BBRI"
+	assert.False(t, first.Overlaps(other))
+}
+
+func TestIndexMembership_Validate(t *testing.T) {
+	m := &entities.IndexMembership{IndexCode: " lq45 ", Emiten: "bbca", EffectiveFrom: date("2025-02-01")}
+	assert.NoError(t, m.Validate())
+	assert.Equal(t, "LQ45", m.IndexCode)
+	assert.Equal(t, "BBCA", m.Emiten)
+
+	assert.Error(t, membership("2025-02-01", "2025-01-31").Validate())
+}
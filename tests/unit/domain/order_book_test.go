@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/services"
+)
+
+func TestOrderBookSnapshot_Validate_SortsLevels(t *testing.T) {
+	book := entities.OrderBookSnapshot{
+		Emiten: "bbca",
+		Time:   time.Date(2026, time.March, 6, 10, 0, 0, 0, services.WIB),
+		Bids:   []entities.OrderBookLevel{{Price: 9200, Lots: 50}, {Price: 9225, Lots: 120, Orders: 8}},
+		Offers: []entities.OrderBookLevel{{Price: 9275, Lots: 40}, {Price: 9250, Lots: 30, Orders: 3}},
+	}
+
+	require.NoError(t, book.Validate())
+	assert.Equal(t, "BBCA", book.Emiten)
+	assert.Equal(t, 9225.0, book.BestBid().Price)
+	assert.Equal(t, 9250.0, book.BestOffer().Price)
+}
+
+func TestOrderBookSnapshot_Validate_Invalid(t *testing.T) {
+	at := time.Date(2026, time.March, 6, 10, 0, 0, 0, services.WIB)
+	cases := map[string]entities.OrderBookSnapshot{
+		"no levels":       {Emiten: "BBCA", Time: at},
+		"no time":         {Emiten: "BBCA", Bids: []entities.OrderBookLevel{{Price: 9225, Lots: 1}}},
+		"zero lots":       {Emiten: "BBCA", Time: at, Bids: []entities.OrderBookLevel{{Price: 9225}}},
+		"duplicate price": {Emiten: "BBCA", Time: at, Offers: []entities.OrderBookLevel{{Price: 9250, Lots: 1}, {Price: 9250, Lots: 2}}},
+	}
+	for name, book := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, book.Validate())
+		})
+	}
+}
+
+func TestAnalyzeOrderBook(t *testing.T) {
+	book := &entities.OrderBookSnapshot{
+		Bids:   []entities.OrderBookLevel{{Price: 1995, Lots: 300}, {Price: 1990, Lots: 500}},
+		Offers: []entities.OrderBookLevel{{Price: 2010, Lots: 100}, {Price: 2020, Lots: 900}},
+	}
+
+	metrics := services.AnalyzeOrderBook(book, 1)
+
+	require.NotNil(t, metrics.Spread)
+	assert.InDelta(t, 15, *metrics.Spread, 1e-9)
+	assert.InDelta(t, 2002.5, *metrics.MidPrice, 1e-9)
+	// 1995 -> 2000 in the 5 band, then 2000 -> 2010 in the 10 band
+	require.NotNil(t, metrics.SpreadTicks)
+	assert.Equal(t, 2, *metrics.SpreadTicks)
+	assert.InDelta(t, 0.5, *metrics.Imbalance, 1e-9)
+
+	all := services.AnalyzeOrderBook(book, 0)
+	assert.Equal(t, 2, all.Depth)
+	assert.Equal(t, int64(800), all.BidLots)
+	assert.Equal(t, int64(1000), all.OfferLots)
+	assert.InDelta(t, -200.0/1800, *all.Imbalance, 1e-9)
+}
+
+func TestAnalyzeOrderBook_OneSided(t *testing.T) {
+	book := &entities.OrderBookSnapshot{
+		Bids:   []entities.OrderBookLevel{{Price: 500, Lots: 1000}},
+		Offers: []entities.OrderBookLevel{},
+	}
+
+	metrics := services.AnalyzeOrderBook(book, 0)
+
+	assert.Nil(t, metrics.Spread)
+	assert.Nil(t, metrics.MidPrice)
+	assert.Nil(t, metrics.SpreadTicks)
+	require.NotNil(t, metrics.Imbalance)
+	assert.InDelta(t, 1, *metrics.Imbalance, 1e-9)
+}
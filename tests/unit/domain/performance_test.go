@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/services"
+)
+
+func TestRebase_StartsTogetherAndCarriesLastClose(t *testing.T) {
+	d1 := wib(2026, time.January, 5, 0, 0)
+	d2, d3, d4 := d1.AddDate(0, 0, 1), d1.AddDate(0, 0, 2), d1.AddDate(0, 0, 3)
+
+	rebased, ok := services.Rebase([][]entities.Candle{
+		{dailyCandle(d1, 0, 0, 80), dailyCandle(d2, 0, 0, 100), dailyCandle(d3, 0, 0, 110), dailyCandle(d4, 0, 0, 120)},
+		// Listed on d2 and idle on d3
+		{dailyCandle(d2, 0, 0, 50), dailyCandle(d4, 0, 0, 45)},
+	}, 100)
+
+	require.True(t, ok)
+	assert.Equal(t, []time.Time{d2, d3, d4}, rebased.Dates)
+	assert.InDeltaSlice(t, []float64{100, 110, 120}, rebased.Values[0], 1e-9)
+	assert.InDeltaSlice(t, []float64{100, 100, 90}, rebased.Values[1], 1e-9)
+	assert.Equal(t, []float64{50, 50, 45}, rebased.Closes[1])
+
+	_, ok = services.Rebase([][]entities.Candle{
+		{dailyCandle(d1, 0, 0, 80)},
+		{dailyCandle(d2, 0, 0, 50)},
+	}, 100)
+	assert.False(t, ok, "the series never trade on the same session")
+}
+
+func TestCAGR(t *testing.T) {
+	from := wib(2024, time.January, 1, 0, 0)
+	to := from.Add(2 * 365.25 * 24 * time.Hour)
+
+	assert.InDelta(t, 0.2, services.CAGR(100, 144, from, to), 1e-9)
+	assert.True(t, math.IsNaN(services.CAGR(100, 144, from, from)))
+}
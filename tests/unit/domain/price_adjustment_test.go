@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/services"
+)
+
+func TestAdjustmentFactor(t *testing.T) {
+	tests := []struct {
+		name     string
+		action   entities.CorporateAction
+		cumClose float64
+		factor   float64
+		ok       bool
+	}{
+		{"split", entities.CorporateAction{Type: entities.CorporateActionSplit, RatioFrom: 1, RatioTo: 5}, 0, 0.2, true},
+		{"reverse split", entities.CorporateAction{Type: entities.CorporateActionReverseSplit, RatioFrom: 10, RatioTo: 1}, 0, 10, true},
+		{"bonus", entities.CorporateAction{Type: entities.CorporateActionBonus, RatioFrom: 4, RatioTo: 1}, 0, 0.8, true},
+		{"rights", entities.CorporateAction{Type: entities.CorporateActionRights, RatioFrom: 4, RatioTo: 1, Price: 500}, 1000, 0.9, true},
+		{"worthless rights", entities.CorporateAction{Type: entities.CorporateActionRights, RatioFrom: 4, RatioTo: 1, Price: 1200}, 1000, 0, false},
+		{"cash dividend", entities.CorporateAction{Type: entities.CorporateActionCashDividend, Amount: 50}, 1000, 0.95, true},
+		{"dividend without cum price", entities.CorporateAction{Type: entities.CorporateActionCashDividend, Amount: 50}, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factor, ok := services.AdjustmentFactor(tt.action, tt.cumClose)
+			assert.Equal(t, tt.ok, ok)
+			assert.InDelta(t, tt.factor, factor, 1e-12)
+		})
+	}
+}
+
+func TestPriceAdjustments_FactorAt(t *testing.T) {
+	adjustments := services.PriceAdjustments{
+		{ExDate: time.Date(2026, time.March, 3, 0, 0, 0, 0, time.UTC), Factor: 0.2},
+		{ExDate: time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC), Factor: 0.95},
+	}
+
+	// The session before the first ex-date takes both factors, the ex-date
+	// session only the later one and sessions from the last ex-date none.
+	assert.InDelta(t, 0.19, adjustments.FactorAt(time.Date(2026, time.March, 2, 15, 0, 0, 0, services.WIB)), 1e-12)
+	assert.InDelta(t, 0.95, adjustments.FactorAt(time.Date(2026, time.March, 3, 9, 0, 0, 0, services.WIB)), 1e-12)
+	assert.Equal(t, 1.0, adjustments.FactorAt(time.Date(2026, time.June, 1, 9, 0, 0, 0, services.WIB)))
+
+	candles := services.AdjustCandles([]entities.Candle{
+		{Time: time.Date(2026, time.March, 2, 0, 0, 0, 0, services.WIB), Open: 1000, High: 1000, Low: 1000, Close: 1000},
+	}, adjustments)
+	assert.InDelta(t, 190, candles[0].Close, 1e-9)
+}
+
+func TestAdjustMarketData_ScalesVolume(t *testing.T) {
+	exDate := time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC)
+	adjustments := services.PriceAdjustments{{ExDate: exDate, Factor: 0.2}}
+	volume := int64(1000000)
+
+	adjusted := services.AdjustMarketData([]entities.MarketData{
+		{Emiten: "BBCA", ClosePrice: 9250, Volume: &volume, Date: time.Date(2026, time.February, 27, 15, 0, 0, 0, services.WIB)},
+		{Emiten: "BBCA", ClosePrice: 1850, Date: time.Date(2026, time.March, 2, 15, 0, 0, 0, services.WIB)},
+	}, adjustments)
+
+	assert.InDelta(t, 1850, adjusted[0].ClosePrice, 1e-9)
+	if assert.NotNil(t, adjusted[0].Volume) {
+		assert.Equal(t, int64(5000000), *adjusted[0].Volume)
+	}
+	assert.Equal(t, int64(1000000), volume)
+	assert.Nil(t, adjusted[1].Volume)
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/services"
+)
+
+func TestComputePriceSummary(t *testing.T) {
+	yearEnd := wib(2025, time.December, 31, 0, 0)
+	old := yearEnd.AddDate(-1, 0, 0) // outside the 52-week window
+	d1 := wib(2026, time.March, 2, 0, 0)
+	d2 := d1.AddDate(0, 0, 1)
+
+	candles := []entities.Candle{
+		dailyCandle(old, 300, 250, 280),
+		dailyCandle(yearEnd, 210, 190, 200),
+		dailyCandle(d1, 240, 180, 230),
+		dailyCandle(d2, 225, 215, 220),
+	}
+	earlierHigh := services.PriceLevel{Price: 500, Date: wib(2015, time.June, 1, 0, 0)}
+
+	summary, ok := services.ComputePriceSummary(candles, earlierHigh)
+
+	require.True(t, ok)
+	assert.Equal(t, d2, summary.Date)
+	assert.Equal(t, services.PriceLevel{Price: 240, Date: d1}, summary.High52w)
+	assert.Equal(t, services.PriceLevel{Price: 180, Date: d1}, summary.Low52w)
+	assert.Equal(t, earlierHigh, summary.AllTimeHigh)
+	assert.Equal(t, 200.0, summary.YTDBase)
+	assert.True(t, math.IsNaN(summary.Averages[20]), "four sessions cannot average twenty")
+
+	summary, _ = services.ComputePriceSummary(candles, services.PriceLevel{})
+	assert.Equal(t, services.PriceLevel{Price: 300, Date: old}, summary.AllTimeHigh)
+}
+
+func TestComputePriceSummary_Averages(t *testing.T) {
+	d1 := wib(2026, time.January, 5, 0, 0)
+	closes := make([]float64, 60)
+	for i := range closes {
+		closes[i] = float64(i + 1)
+	}
+
+	summary, ok := services.ComputePriceSummary(closeSeries(d1, closes...), services.PriceLevel{})
+
+	require.True(t, ok)
+	// Mean of 41..60 and of 11..60
+	assert.InDelta(t, 50.5, summary.Averages[20], 1e-9)
+	assert.InDelta(t, 35.5, summary.Averages[50], 1e-9)
+	assert.True(t, math.IsNaN(summary.Averages[200]))
+	assert.True(t, math.IsNaN(summary.YTDBase), "history starts this year")
+}
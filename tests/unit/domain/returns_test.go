@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/services"
+)
+
+func TestAlignLogReturns_DropsReturnsAcrossMissingSessions(t *testing.T) {
+	d1 := wib(2026, time.January, 19, 0, 0)
+	d2 := d1.AddDate(0, 0, 1)
+	d3 := d1.AddDate(0, 0, 2)
+	d4 := d1.AddDate(0, 0, 3)
+
+	aligned := services.AlignLogReturns([][]entities.Candle{
+		{dailyCandle(d1, 0, 0, 100), dailyCandle(d2, 0, 0, 110), dailyCandle(d3, 0, 0, 99), dailyCandle(d4, 0, 0, 99)},
+		// Did not trade on d3
+		{dailyCandle(d1, 0, 0, 50), dailyCandle(d2, 0, 0, 55), dailyCandle(d4, 0, 0, 60)},
+	})
+
+	require.Equal(t, []time.Time{d2, d3, d4}, aligned.Dates)
+	assert.InDelta(t, math.Log(1.1), aligned.Returns[0][0], 1e-12)
+	assert.InDelta(t, math.Log(0.9), aligned.Returns[0][1], 1e-12)
+	assert.InDelta(t, math.Log(1.1), aligned.Returns[1][0], 1e-12)
+	assert.True(t, math.IsNaN(aligned.Returns[1][1]))
+	assert.True(t, math.IsNaN(aligned.Returns[1][2]), "the d2-d4 move spans a missing session")
+
+	x, y := aligned.PairwiseComplete(0, 1)
+	assert.Len(t, x, 1)
+	assert.Len(t, y, 1)
+
+	tail := aligned.Tail(2)
+	assert.Equal(t, []time.Time{d3, d4}, tail.Dates)
+	assert.Len(t, tail.Returns[1], 2)
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/services"
+)
+
+func closeSeries(start time.Time, closes ...float64) []entities.Candle {
+	candles := make([]entities.Candle, len(closes))
+	for i, c := range closes {
+		candles[i] = dailyCandle(start.AddDate(0, 0, i), c, c, c)
+	}
+	return candles
+}
+
+func TestMaxDrawdown_FindsDeepestFallFromRunningPeak(t *testing.T) {
+	d1 := wib(2026, time.January, 5, 0, 0)
+	// Peak 120 on day 2 falls to 90 on day 4 (25%); the later 130 -> 110 is shallower
+	candles := closeSeries(d1, 100, 120, 105, 90, 130, 110)
+
+	drawdown, ok := services.MaxDrawdown(candles)
+
+	require.True(t, ok)
+	assert.InDelta(t, 0.25, drawdown.Depth, 1e-12)
+	assert.Equal(t, d1.AddDate(0, 0, 1), drawdown.Peak)
+	assert.Equal(t, d1.AddDate(0, 0, 3), drawdown.Trough)
+	assert.Equal(t, 90.0, drawdown.TroughClose)
+
+	_, ok = services.MaxDrawdown(closeSeries(d1, 100, 101, 102))
+	assert.False(t, ok, "a rising series has no drawdown")
+}
+
+func TestVolatility(t *testing.T) {
+	d1 := wib(2026, time.January, 5, 0, 0)
+	// Alternating +/-x log returns have a sample standard deviation of x*sqrt(n/(n-1))
+	x := 0.01
+	closes := []float64{100}
+	for i := 0; i < 4; i++ {
+		closes = append(closes, closes[len(closes)-1]*math.Exp(x*math.Pow(-1, float64(i))))
+	}
+
+	vol := services.CloseToCloseVolatility(closeSeries(d1, closes...))
+	assert.InDelta(t, x*math.Sqrt(4.0/3)*math.Sqrt(services.TradingSessionsPerYear), vol, 1e-9)
+
+	// A constant high/low ratio of e^r gives a Parkinson variance of r^2/(4 ln 2)
+	r := 0.02
+	candles := []entities.Candle{
+		dailyCandle(d1, 100*math.Exp(r), 100, 100),
+		dailyCandle(d1.AddDate(0, 0, 1), 200*math.Exp(r), 200, 200),
+	}
+	expected := math.Sqrt(r*r/(4*math.Ln2)) * math.Sqrt(services.TradingSessionsPerYear)
+	assert.InDelta(t, expected, services.ParkinsonVolatility(candles), 1e-9)
+}
+
+func TestBeta_UsesSharedSessions(t *testing.T) {
+	d1 := wib(2026, time.January, 5, 0, 0)
+	benchmark := closeSeries(d1, 100, 101, 99, 102, 100, 103)
+	// The asset moves twice as much in log terms on every session
+	asset := make([]entities.Candle, len(benchmark))
+	for i, c := range benchmark {
+		asset[i] = dailyCandle(c.Time, 0, 0, math.Pow(c.Close, 2)/100)
+	}
+
+	beta, observations := services.Beta(asset, benchmark, 252)
+
+	assert.InDelta(t, 2.0, beta, 1e-9)
+	assert.Equal(t, 5, observations)
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"api-web-scrapping/internal/domain/services"
+)
+
+func TestAggregateSectors(t *testing.T) {
+	quotes := []services.SectorQuote{
+		// Previous caps 1000 and 3000: weighted change 0.25*10 + 0.75*(-2) = 1
+		{Emiten: "AAAA", Sector: "Financials", Close: 110, PrevClose: 100, SharesOutstanding: 10},
+		{Emiten: "BBBB", Sector: "Financials", Close: 49, PrevClose: 50, SharesOutstanding: 60},
+		{Emiten: "CCCC", Sector: "Financials", Close: 20, PrevClose: 20, SharesOutstanding: 0},
+		{Emiten: "DDDD", Sector: "Energy", Close: 10, PrevClose: math.NaN(), SharesOutstanding: 5},
+		{Emiten: "EEEE", Sector: "", Close: 5, PrevClose: 4},
+	}
+
+	summaries := services.AggregateSectors(quotes)
+
+	if !assert.Len(t, summaries, 3) {
+		return
+	}
+
+	fin := summaries[0]
+	assert.Equal(t, "Financials", fin.Sector)
+	assert.Equal(t, 3, fin.EmitenCount)
+	assert.Equal(t, 1, fin.Advancers)
+	assert.Equal(t, 1, fin.Decliners)
+	assert.Equal(t, 1, fin.Unchanged)
+	assert.InDelta(t, 110*10+49*60, fin.MarketCap, 1e-9)
+	assert.InDelta(t, 1.0, fin.CapWeightedChangePct, 1e-9)
+	assert.InDelta(t, (10.0-2.0+0.0)/3, fin.EqualWeightedChangePct, 1e-9)
+	if assert.NotNil(t, fin.TopContributor) {
+		assert.Equal(t, "AAAA", fin.TopContributor.Emiten)
+		assert.InDelta(t, 2.5, fin.TopContributor.Contribution, 1e-9)
+	}
+
+	energy := summaries[1]
+	assert.Equal(t, "Energy", energy.Sector)
+	assert.Equal(t, 1, energy.EmitenCount)
+	assert.Zero(t, energy.Advancers+energy.Decliners+energy.Unchanged)
+	assert.True(t, math.IsNaN(energy.CapWeightedChangePct))
+	assert.Nil(t, energy.TopContributor)
+
+	other := summaries[2]
+	assert.Equal(t, services.UnclassifiedSector, other.Sector)
+	assert.InDelta(t, 25.0, other.EqualWeightedChangePct, 1e-9)
+	assert.True(t, math.IsNaN(other.CapWeightedChangePct))
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/services"
+)
+
+func TestTickSize(t *testing.T) {
+	tests := []struct {
+		price float64
+		tick  float64
+	}{
+		{50, 1},
+		{199, 1},
+		{200, 2},
+		{498, 2},
+		{500, 5},
+		{1995, 5},
+		{2000, 10},
+		{4990, 10},
+		{5000, 25},
+		{9250, 25},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.tick, services.TickSize(tt.price), "price %g", tt.price)
+	}
+}
+
+func TestIsValidTick(t *testing.T) {
+	assert.True(t, services.IsValidTick(9250))
+	assert.False(t, services.IsValidTick(9251))
+	assert.True(t, services.IsValidTick(498))
+	assert.False(t, services.IsValidTick(499))
+	assert.True(t, services.IsValidTick(500))
+	assert.False(t, services.IsValidTick(100.5))
+	assert.False(t, services.IsValidTick(0))
+}
+
+func TestRoundToTick(t *testing.T) {
+	tests := []struct {
+		name  string
+		price float64
+		mode  services.TickRounding
+		want  float64
+	}{
+		{"nearest down", 9260, services.TickRoundNearest, 9250},
+		{"nearest up", 9265, services.TickRoundNearest, 9275},
+		{"nearest tie rounds up", 9262.5, services.TickRoundNearest, 9275},
+		{"down", 9274, services.TickRoundDown, 9250},
+		{"up", 9251, services.TickRoundUp, 9275},
+		{"up across a band", 499, services.TickRoundUp, 500},
+		{"down below a band", 499, services.TickRoundDown, 498},
+		{"valid price is kept", 1995, services.TickRoundUp, 1995},
+		{"below the lowest tick", 0.4, services.TickRoundDown, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, services.RoundToTick(tt.price, tt.mode))
+		})
+	}
+}
+
+func TestTicksBetween(t *testing.T) {
+	ticks, complete := services.TicksBetween(495, 515, 100)
+	assert.True(t, complete)
+	assert.Equal(t, []float64{496, 498, 500, 505, 510, 515}, ticks)
+
+	ticks, complete = services.TicksBetween(100, 200, 10)
+	assert.False(t, complete)
+	assert.Len(t, ticks, 10)
+}
+
+func TestValidateTicks(t *testing.T) {
+	md := entities.MarketData{Emiten: "BBCA", OpenPrice: 9200, HighPrice: 9300, LowPrice: 9150, ClosePrice: 9251}
+
+	violations := services.ValidateTicks(md)
+	if assert.Len(t, violations, 1) {
+		assert.Equal(t, "close_price", violations[0].Field)
+		assert.Equal(t, 25.0, violations[0].Tick)
+	}
+
+	md.ClosePrice = 9250
+	assert.Empty(t, services.ValidateTicks(md))
+
+	untraded := entities.MarketData{Emiten: "SRIL", ClosePrice: 146}
+	assert.Empty(t, services.ValidateTicks(untraded))
+}
@@ -0,0 +1,130 @@
+package indicators
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"api-web-scrapping/pkg/indicators"
+)
+
+// Closing prices from the StockCharts RSI worked example, rounded to cents.
+// StockCharts computes from unrounded prices and shows 70.53 for the first
+// RSI; with these inputs the reference values are 70.46, 66.25, ...
+var wilderCloses = []float64{
+	44.34, 44.09, 44.15, 43.61, 44.33, 44.83, 45.10, 45.42, 45.84, 46.08,
+	45.89, 46.03, 45.61, 46.28, 46.28, 46.00, 46.03, 46.41, 46.22, 45.64,
+}
+
+func assertWarmUp(t *testing.T, values []float64, n int) {
+	t.Helper()
+	assert.Equal(t, n, indicators.WarmUp(values))
+	for i := 0; i < n; i++ {
+		assert.True(t, math.IsNaN(values[i]), "index %d should be NaN", i)
+	}
+}
+
+func TestSMA(t *testing.T) {
+	sma := indicators.SMA([]float64{1, 2, 3, 4, 5}, 3)
+
+	assertWarmUp(t, sma, 2)
+	assert.InDeltaSlice(t, []float64{2, 3, 4}, sma[2:], 1e-9)
+}
+
+func TestEMA(t *testing.T) {
+	// Seeded with SMA(2,4,6)=4, then k=0.5
+	ema := indicators.EMA([]float64{2, 4, 6, 8, 10}, 3)
+
+	assertWarmUp(t, ema, 2)
+	assert.InDeltaSlice(t, []float64{4, 6, 8}, ema[2:], 1e-9)
+}
+
+func TestEMA_SkipsLeadingNaN(t *testing.T) {
+	ema := indicators.EMA([]float64{math.NaN(), 2, 4, 6, 8}, 3)
+
+	assertWarmUp(t, ema, 3)
+	assert.InDeltaSlice(t, []float64{4, 6}, ema[3:], 1e-9)
+}
+
+func TestWMA(t *testing.T) {
+	wma := indicators.WMA([]float64{1, 2, 3, 4}, 3)
+
+	assertWarmUp(t, wma, 2)
+	assert.InDelta(t, 14.0/6, wma[2], 1e-9)
+	assert.InDelta(t, 20.0/6, wma[3], 1e-9)
+}
+
+func TestRSI_WilderReference(t *testing.T) {
+	rsi := indicators.RSI(wilderCloses, 14)
+
+	assertWarmUp(t, rsi, 14)
+	assert.InDeltaSlice(t, []float64{70.46, 66.25, 66.48, 69.35, 66.29, 57.92}, rsi[14:], 0.01)
+}
+
+func TestRSI_AllGains(t *testing.T) {
+	rsi := indicators.RSI([]float64{1, 2, 3, 4}, 3)
+
+	assert.Equal(t, 100.0, rsi[3])
+}
+
+func TestMACD(t *testing.T) {
+	macd, signal, histogram := indicators.MACD(wilderCloses, 3, 6, 4)
+
+	assertWarmUp(t, macd, 5)
+	assertWarmUp(t, signal, 8)
+	assertWarmUp(t, histogram, 8)
+
+	fast := indicators.EMA(wilderCloses, 3)
+	slow := indicators.EMA(wilderCloses, 6)
+	for i := 5; i < len(wilderCloses); i++ {
+		assert.InDelta(t, fast[i]-slow[i], macd[i], 1e-9)
+	}
+	for i := 8; i < len(wilderCloses); i++ {
+		assert.InDelta(t, macd[i]-signal[i], histogram[i], 1e-9)
+	}
+}
+
+func TestBollingerBands(t *testing.T) {
+	// Mean 5, population standard deviation 2
+	middle, upper, lower := indicators.BollingerBands([]float64{2, 4, 4, 4, 5, 5, 7, 9}, 8, 2)
+
+	assertWarmUp(t, middle, 7)
+	assert.InDelta(t, 5.0, middle[7], 1e-9)
+	assert.InDelta(t, 9.0, upper[7], 1e-9)
+	assert.InDelta(t, 1.0, lower[7], 1e-9)
+}
+
+func TestStochastic(t *testing.T) {
+	highs := []float64{10, 12, 14, 13}
+	lows := []float64{8, 9, 10, 11}
+	closes := []float64{9, 11, 13, 12}
+
+	k, d := indicators.Stochastic(highs, lows, closes, 3, 2)
+
+	assertWarmUp(t, k, 2)
+	assert.InDelta(t, 100*(13.0-8)/(14-8), k[2], 1e-9)
+	assert.InDelta(t, 100*(12.0-9)/(14-9), k[3], 1e-9)
+
+	assertWarmUp(t, d, 3)
+	assert.InDelta(t, (k[2]+k[3])/2, d[3], 1e-9)
+}
+
+func TestATR(t *testing.T) {
+	highs := []float64{10, 11, 12, 15}
+	lows := []float64{8, 9, 11, 12}
+	closes := []float64{9, 10, 11.5, 14}
+
+	// True ranges: 2, 2, 2, 3.5
+	atr := indicators.ATR(highs, lows, closes, 3)
+
+	assertWarmUp(t, atr, 2)
+	assert.InDelta(t, 2.0, atr[2], 1e-9)
+	assert.InDelta(t, (2.0*2+3.5)/3, atr[3], 1e-9)
+}
+
+func TestOBV(t *testing.T) {
+	obv := indicators.OBV([]float64{10, 11, 11, 9, 12}, []float64{100, 200, 300, 400, 500})
+
+	assert.InDeltaSlice(t, []float64{0, 200, 200, -200, 300}, obv, 1e-9)
+}
@@ -0,0 +1,127 @@
+package screener
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"api-web-scrapping/pkg/screener"
+)
+
+var testSchema = &screener.Schema{
+	Fields: []string{"close", "change_pct"},
+	Functions: []screener.Function{
+		{Name: "sma", Arity: 1, Lookback: func(args []float64) int { return int(args[0]) }},
+		{Name: "rsi", Arity: 1, Lookback: func(args []float64) int { return int(args[0]) + 1 }},
+	},
+}
+
+type mapEnv map[string]float64
+
+func (e mapEnv) Field(name string) float64 {
+	if v, ok := e[name]; ok {
+		return v
+	}
+	return math.NaN()
+}
+
+func (e mapEnv) Call(name string, args []float64) float64 {
+	return e.Field((&screener.Call{Name: name, Args: args}).String())
+}
+
+func TestCompile_MatchesExpression(t *testing.T) {
+	program, err := screener.Compile("close > sma(50) and rsi(14) < 30 and change_pct > 2", testSchema)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"close", "sma(50)", "rsi(14)", "change_pct"}, program.Refs())
+	assert.Equal(t, 50, program.Lookback())
+
+	assert.True(t, program.Match(mapEnv{"close": 110, "sma(50)": 100, "rsi(14)": 25, "change_pct": 3}))
+	assert.False(t, program.Match(mapEnv{"close": 110, "sma(50)": 100, "rsi(14)": 35, "change_pct": 3}))
+}
+
+func TestCompile_Precedence(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		env  mapEnv
+		want bool
+	}{
+		{"multiplication before addition", "close == 2 + 3 * 4", mapEnv{"close": 14}, true},
+		{"parentheses", "close == (2 + 3) * 4", mapEnv{"close": 20}, true},
+		{"and before or", "close > 100 or close < 10 and close > 5", mapEnv{"close": 200}, true},
+		{"not", "not close > 100", mapEnv{"close": 50}, true},
+		{"unary minus", "change_pct < -2", mapEnv{"change_pct": -3}, true},
+		{"symbolic operators", "close >= 10 && !(close != 10) || close < 0", mapEnv{"close": 10}, true},
+		{"case insensitive keywords", "close > 1 AND close < 3", mapEnv{"close": 2}, true},
+		{"missing value never matches", "close > sma(50)", mapEnv{"close": 10}, false},
+		{"division by zero never matches", "close / 0 > 1", mapEnv{"close": 10}, false},
+		{"negated missing value never matches", "not close > sma(50)", mapEnv{"close": 10}, false},
+		{"negated division by zero never matches", "not (close / 0 > 1)", mapEnv{"close": 10}, false},
+		{"or with a missing value", "close > 5 or close > sma(50)", mapEnv{"close": 10}, true},
+		{"negated and with a false side", "not (close > 50 and close > sma(50))", mapEnv{"close": 10}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program, err := screener.Compile(tt.expr, testSchema)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, program.Match(tt.env))
+		})
+	}
+}
+
+func TestCompile_ErrorPositions(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		position int
+	}{
+		{"unknown field", "close > volume", 9},
+		{"unknown function", "close > foo(3)", 9},
+		{"wrong arity", "sma(1, 2) > 3", 1},
+		{"non constant argument", "sma(close) > 3", 5},
+		{"unclosed parenthesis", "(close > 3", 11},
+		{"trailing token", "close > 3 4", 11},
+		{"unexpected character", "close > 3 $", 11},
+		{"chained comparison", "1 < close < 3", 11},
+		{"number used as condition", "close + 1", 7},
+		{"and on numbers", "close and close > 1", 1},
+		{"not on number", "not close", 1},
+		{"empty", "   ", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := screener.Compile(tt.expr, testSchema)
+
+			var parseErr *screener.ParseError
+			if assert.ErrorAs(t, err, &parseErr) {
+				assert.Equal(t, tt.position, parseErr.Position, parseErr.Message)
+			}
+		})
+	}
+}
+
+func TestCompile_RejectsLongExpression(t *testing.T) {
+	long := make([]byte, screener.MaxExpressionLength+1)
+	for i := range long {
+		long[i] = ' '
+	}
+
+	_, err := screener.Compile(string(long), testSchema)
+
+	assert.Error(t, err)
+}
+
+func TestProgram_Values(t *testing.T) {
+	program, err := screener.Compile("close > sma(20) * 1.05", testSchema)
+	assert.NoError(t, err)
+
+	values := program.Values(mapEnv{"close": 110, "sma(20)": 100})
+
+	assert.Equal(t, map[string]float64{"close": 110, "sma(20)": 100}, values)
+	assert.True(t, program.Match(mapEnv{"close": 110, "sma(20)": 100}))
+}
@@ -0,0 +1,67 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"api-web-scrapping/pkg/stats"
+)
+
+func TestMeanVarianceStdDev(t *testing.T) {
+	x := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+
+	assert.InDelta(t, 5.0, stats.Mean(x), 1e-12)
+	// Sample variance: sum of squared deviations 32 over n-1 = 7
+	assert.InDelta(t, 32.0/7, stats.Variance(x), 1e-12)
+	assert.InDelta(t, math.Sqrt(32.0/7), stats.StdDev(x), 1e-12)
+}
+
+func TestCovariance(t *testing.T) {
+	x := []float64{1, 2, 3, 4}
+	y := []float64{2, 4, 6, 8}
+
+	assert.InDelta(t, 10.0/3, stats.Covariance(x, y), 1e-12)
+	assert.True(t, math.IsNaN(stats.Covariance(x, y[:3])), "lengths must match")
+	assert.True(t, math.IsNaN(stats.Covariance([]float64{1}, []float64{1})), "needs two observations")
+}
+
+func TestPearson(t *testing.T) {
+	x := []float64{1, 2, 3, 4, 5}
+
+	assert.InDelta(t, 1.0, stats.Pearson(x, []float64{3, 5, 7, 9, 11}), 1e-12)
+	assert.InDelta(t, -1.0, stats.Pearson(x, []float64{5, 4, 3, 2, 1}), 1e-12)
+	assert.InDelta(t, 0.0, stats.Pearson(x, []float64{2, 1, 0, 1, 2}), 1e-12)
+	assert.True(t, math.IsNaN(stats.Pearson(x, []float64{7, 7, 7, 7, 7})), "constant series has no correlation")
+}
+
+func TestRanks_AveragesTies(t *testing.T) {
+	assert.Equal(t, []float64{4, 1, 2.5, 2.5, 5}, stats.Ranks([]float64{30, 10, 20, 20, 40}))
+}
+
+func TestSpearman_MonotonicIsPerfect(t *testing.T) {
+	x := []float64{1, 2, 3, 4, 5}
+	// Non-linear but monotonic: Pearson falls short of 1, Spearman does not
+	y := []float64{1, 8, 27, 64, 125}
+
+	assert.InDelta(t, 1.0, stats.Spearman(x, y), 1e-12)
+	assert.Less(t, stats.Pearson(x, y), 1.0)
+}
+
+func TestDownsideDeviation(t *testing.T) {
+	// Shortfalls below zero are -2 and -1 over four observations
+	assert.InDelta(t, math.Sqrt(5.0/4), stats.DownsideDeviation([]float64{1, -2, 3, -1}, 0), 1e-12)
+	assert.Equal(t, 0.0, stats.DownsideDeviation([]float64{1, 2}, 0))
+}
+
+func TestBeta(t *testing.T) {
+	market := []float64{0.01, -0.02, 0.015, 0.005}
+	asset := make([]float64, len(market))
+	for i, m := range market {
+		asset[i] = 1.5*m + 0.001
+	}
+
+	assert.InDelta(t, 1.5, stats.Beta(asset, market), 1e-12)
+	assert.True(t, math.IsNaN(stats.Beta(asset, []float64{0, 0, 0, 0})))
+}
@@ -0,0 +1,100 @@
+package tradingcalendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"api-web-scrapping/pkg/tradingcalendar"
+)
+
+func wib(year int, month time.Month, day, hour, min int) time.Time {
+	return time.Date(year, month, day, hour, min, 0, 0, tradingcalendar.WIB)
+}
+
+func calendar() *tradingcalendar.Calendar {
+	return tradingcalendar.New([]tradingcalendar.Holiday{
+		{Date: "2026-03-19", Name: "Nyepi"},
+		{Date: "2026-03-20", Name: "Eid al-Fitr"},
+	})
+}
+
+func TestPhaseAt_MondayToThursday(t *testing.T) {
+	cal := calendar()
+	tests := []struct {
+		at    time.Time
+		phase tradingcalendar.Phase
+	}{
+		{wib(2026, time.March, 17, 8, 44), tradingcalendar.PhaseClosed},
+		{wib(2026, time.March, 17, 8, 45), tradingcalendar.PhasePreOpening},
+		{wib(2026, time.March, 17, 9, 0), tradingcalendar.PhaseSession1},
+		{wib(2026, time.March, 17, 12, 0), tradingcalendar.PhaseLunchBreak},
+		{wib(2026, time.March, 17, 13, 30), tradingcalendar.PhaseSession2},
+		{wib(2026, time.March, 17, 15, 55), tradingcalendar.PhasePreClosing},
+		{wib(2026, time.March, 17, 16, 10), tradingcalendar.PhasePostTrading},
+		{wib(2026, time.March, 17, 16, 15), tradingcalendar.PhaseClosed},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.phase, cal.PhaseAt(tt.at), tt.at.Format(time.Kitchen))
+	}
+}
+
+func TestPhaseAt_FridayHasLongerBreak(t *testing.T) {
+	cal := calendar()
+
+	assert.Equal(t, tradingcalendar.PhaseLunchBreak, cal.PhaseAt(wib(2026, time.March, 13, 11, 45)))
+	assert.Equal(t, tradingcalendar.PhaseLunchBreak, cal.PhaseAt(wib(2026, time.March, 13, 13, 45)))
+	assert.Equal(t, tradingcalendar.PhaseSession2, cal.PhaseAt(wib(2026, time.March, 13, 14, 0)))
+}
+
+func TestPhaseAt_ConvertsToWIB(t *testing.T) {
+	// 02:30 UTC is 09:30 WIB
+	at := time.Date(2026, time.March, 17, 2, 30, 0, 0, time.UTC)
+	assert.Equal(t, tradingcalendar.PhaseSession1, calendar().PhaseAt(at))
+}
+
+func TestTradingDays_SkipWeekendsAndHolidays(t *testing.T) {
+	cal := calendar()
+
+	assert.False(t, cal.IsTradingDay(wib(2026, time.March, 19, 10, 0)))
+	assert.Equal(t, tradingcalendar.PhaseClosed, cal.PhaseAt(wib(2026, time.March, 19, 10, 0)))
+	// Holidays on Thursday and Friday, then the weekend
+	assert.Equal(t, wib(2026, time.March, 23, 0, 0), cal.NextTradingDay(wib(2026, time.March, 18, 15, 0)))
+	assert.Equal(t, wib(2026, time.March, 18, 0, 0), cal.PreviousTradingDay(wib(2026, time.March, 23, 9, 0)))
+}
+
+func TestStatusAt(t *testing.T) {
+	cal := calendar()
+
+	// During session 2 the next close is today and the next open tomorrow
+	status := cal.StatusAt(wib(2026, time.March, 17, 14, 0))
+	assert.Equal(t, tradingcalendar.PhaseSession2, status.Phase)
+	assert.True(t, status.Phase.AcceptsOrders())
+	assert.Equal(t, wib(2026, time.March, 17, 16, 0), status.NextClose)
+	assert.Equal(t, wib(2026, time.March, 18, 9, 0), status.NextOpen)
+
+	status = cal.StatusAt(wib(2026, time.March, 19, 10, 0))
+	assert.False(t, status.TradingDay)
+	assert.Equal(t, "Nyepi", status.Holiday)
+	assert.Equal(t, wib(2026, time.March, 23, 9, 0), status.NextOpen)
+}
+
+func TestParseHolidays(t *testing.T) {
+	holidays, err := tradingcalendar.ParseHolidays([]byte(`{"holidays":[{"date":"2026-01-01","name":"New Year's Day"}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, []tradingcalendar.Holiday{{Date: "2026-01-01", Name: "New Year's Day"}}, holidays)
+
+	_, err = tradingcalendar.ParseHolidays([]byte(`{"holidays":[{"date":"01/01/2026","name":"New Year's Day"}]}`))
+	assert.Error(t, err)
+}
+
+func TestCovers_YearsOfHolidays(t *testing.T) {
+	c := tradingcalendar.New([]tradingcalendar.Holiday{{Date: "2026-12-31", Name: "Exchange holiday"}})
+
+	assert.True(t, c.Covers(wib(2026, time.June, 1, 12, 0)))
+	// 31 December 2026 18:00 UTC is already 2027 in WIB
+	assert.False(t, c.Covers(time.Date(2026, time.December, 31, 18, 0, 0, 0, time.UTC)))
+	assert.False(t, tradingcalendar.New(nil).Covers(wib(2026, time.June, 1, 12, 0)))
+}
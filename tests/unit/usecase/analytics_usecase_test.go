@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/application/usecases"
+	"api-web-scrapping/internal/domain/entities"
+)
+
+func TestAnalyticsUseCase_GetSummary_UnknownEmiten(t *testing.T) {
+	repo := new(MockMarketDataRepository)
+	emitens := new(MockEmitenRepository)
+	uc := usecases.NewAnalyticsUseCase(repo, emitens)
+
+	emitens.On("FindByTicker", "XXXX").Return(nil, nil)
+
+	_, err := uc.GetSummary(" xxxx ")
+
+	assert.ErrorIs(t, err, usecases.ErrEmitenNotFound)
+	repo.AssertNotCalled(t, "GetDailyHistoryByEmiten", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAnalyticsUseCase_GetSummary_LoadsHistoryOncePerDay(t *testing.T) {
+	repo := new(MockMarketDataRepository)
+	emitens := new(MockEmitenRepository)
+	uc := usecases.NewAnalyticsUseCase(repo, emitens)
+
+	yesterday := time.Now().AddDate(0, 0, -1)
+	emitens.On("FindByTicker", "BBCA").Return(&entities.Emiten{Ticker: "BBCA"}, nil)
+	repo.On("GetDailyHistoryByEmiten", "BBCA", mock.Anything, mock.Anything).Return([]entities.MarketData{
+		{Emiten: "BBCA", OpenPrice: 9200, HighPrice: 9300, LowPrice: 9150, ClosePrice: 9250, Date: yesterday},
+	}, nil)
+	repo.On("GetHighestByEmiten", "BBCA", mock.Anything, 10).Return([]entities.MarketData{
+		{Emiten: "BBCA", HighPrice: 9301, Date: yesterday.AddDate(-2, 0, 0)},
+		{Emiten: "BBCA", HighPrice: 9275, Date: yesterday.AddDate(-3, 0, 0)},
+	}, nil)
+
+	for i := 0; i < 2; i++ {
+		response, err := uc.GetSummary("BBCA")
+		require.NoError(t, err)
+		assert.Equal(t, 9300.0, response.AllTimeHigh.Price)
+	}
+
+	repo.AssertNumberOfCalls(t, "GetHighestByEmiten", 1)
+}
+
+func TestAnalyticsUseCase_Compare_RangeTooLong(t *testing.T) {
+	repo := new(MockMarketDataRepository)
+	uc := usecases.NewAnalyticsUseCase(repo, new(MockEmitenRepository))
+
+	to := time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC)
+
+	_, err := uc.Compare(dto.CompareRequest{Emiten: "BBCA,BBRI"}, to.AddDate(-5, 0, 0), to)
+
+	assert.ErrorIs(t, err, usecases.ErrInvalidTimeRange)
+	repo.AssertNotCalled(t, "GetDailyHistoryByEmiten", mock.Anything, mock.Anything, mock.Anything)
+}
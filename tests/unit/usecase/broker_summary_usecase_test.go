@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/application/usecases"
+	"api-web-scrapping/internal/domain/entities"
+)
+
+type MockBrokerSummaryRepository struct {
+	mock.Mock
+}
+
+func (m *MockBrokerSummaryRepository) list(args mock.Arguments) ([]entities.BrokerSummary, error) {
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.BrokerSummary), args.Error(1)
+}
+
+func (m *MockBrokerSummaryRepository) Upsert(summaries []entities.BrokerSummary) error {
+	return m.Called(summaries).Error(0)
+}
+
+func (m *MockBrokerSummaryRepository) FindByEmiten(emiten string, from, to time.Time) ([]entities.BrokerSummary, error) {
+	return m.list(m.Called(emiten, from, to))
+}
+
+func (m *MockBrokerSummaryRepository) FindByBroker(brokerCode string, from, to time.Time) ([]entities.BrokerSummary, error) {
+	return m.list(m.Called(brokerCode, from, to))
+}
+
+func (m *MockBrokerSummaryRepository) LatestDate() (*time.Time, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*time.Time), args.Error(1)
+}
+
+func (m *MockBrokerSummaryRepository) LatestDateByEmiten(emiten string) (*time.Time, error) {
+	args := m.Called(emiten)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*time.Time), args.Error(1)
+}
+
+func TestBrokerSummaryUseCase_TopBrokers(t *testing.T) {
+	repo := new(MockBrokerSummaryRepository)
+	uc := usecases.NewBrokerSummaryUseCase(repo)
+
+	monday := time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC)
+	tuesday := monday.AddDate(0, 0, 1)
+	repo.On("FindByEmiten", "BBCA", monday, tuesday).Return([]entities.BrokerSummary{
+		{Emiten: "BBCA", Date: monday, BrokerCode: "YP", BuyLots: 1000, SellLots: 200, BuyValue: 925e6, SellValue: 185e6},
+		{Emiten: "BBCA", Date: monday, BrokerCode: "CC", BuyLots: 100, SellLots: 900, BuyValue: 92.5e6, SellValue: 832.5e6},
+		{Emiten: "BBCA", Date: tuesday, BrokerCode: "YP", BuyLots: 1000, BuyValue: 935e6},
+		{Emiten: "BBCA", Date: tuesday, BrokerCode: "ZP", BuyLots: 10, BuyValue: 9.3e6},
+	}, nil)
+
+	ranking, err := uc.TopBrokers("bbca", monday, tuesday, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, "2026-03-02", ranking.From)
+	require.Len(t, ranking.NetBuyers, 2)
+	yp := ranking.NetBuyers[0]
+	assert.Equal(t, "YP", yp.Broker)
+	assert.Empty(t, yp.Emiten)
+	assert.Equal(t, 2, yp.Days)
+	assert.Equal(t, int64(1800), yp.NetLots)
+	require.NotNil(t, yp.BuyAvgPrice)
+	assert.InDelta(t, 9300, *yp.BuyAvgPrice, 1e-9)
+	assert.Equal(t, "ZP", ranking.NetBuyers[1].Broker)
+	require.Len(t, ranking.NetSellers, 1)
+	assert.Equal(t, "CC", ranking.NetSellers[0].Broker)
+	assert.InDelta(t, -740e6, ranking.NetSellers[0].NetValue, 1e-6)
+}
+
+func TestBrokerSummaryUseCase_TopBrokers_DefaultsToEmitenLatestSession(t *testing.T) {
+	repo := new(MockBrokerSummaryRepository)
+	uc := usecases.NewBrokerSummaryUseCase(repo)
+
+	// The emiten was suspended after its last session
+	last := time.Date(2026, time.February, 20, 0, 0, 0, 0, time.UTC)
+	repo.On("LatestDateByEmiten", "SRIL").Return(&last, nil)
+	repo.On("FindByEmiten", "SRIL", last, last).Return([]entities.BrokerSummary{
+		{Emiten: "SRIL", Date: last, BrokerCode: "YP", BuyLots: 100, BuyValue: 1.46e6},
+	}, nil)
+
+	ranking, err := uc.TopBrokers("sril", time.Time{}, time.Time{}, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, "2026-02-20", ranking.From)
+	assert.Equal(t, "2026-02-20", ranking.To)
+	require.Len(t, ranking.NetBuyers, 1)
+	repo.AssertNotCalled(t, "LatestDate")
+}
+
+func TestBrokerSummaryUseCase_Accumulation_NoData(t *testing.T) {
+	repo := new(MockBrokerSummaryRepository)
+	uc := usecases.NewBrokerSummaryUseCase(repo)
+
+	repo.On("LatestDate").Return(nil, nil)
+
+	accumulation, err := uc.Accumulation("yp", time.Time{}, time.Time{}, 5)
+
+	require.NoError(t, err)
+	assert.Equal(t, "YP", accumulation.Broker)
+	assert.Empty(t, accumulation.Accumulated)
+	assert.NotNil(t, accumulation.Distributed)
+	repo.AssertNotCalled(t, "FindByBroker", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBrokerSummaryUseCase_Accumulation_InvalidRange(t *testing.T) {
+	uc := usecases.NewBrokerSummaryUseCase(new(MockBrokerSummaryRepository))
+
+	_, err := uc.Accumulation("YP", time.Date(2026, time.March, 6, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC), 0)
+
+	assert.ErrorIs(t, err, usecases.ErrInvalidTimeRange)
+}
+
+func TestBrokerSummaryUseCase_Ingest_InvalidBroker(t *testing.T) {
+	repo := new(MockBrokerSummaryRepository)
+	uc := usecases.NewBrokerSummaryUseCase(repo)
+
+	repo.On("Upsert", mock.Anything).Return(nil)
+
+	result, err := uc.Ingest([]dto.BrokerSummaryRequest{
+		{Emiten: "BBCA", Date: "2026-03-02", Broker: "yp", BuyLots: 10, BuyValue: 9.25e6},
+		{Emiten: "BBCA", Date: "2026-03-02", Broker: "YPX"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Imported)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, 2, result.Errors[0].Line)
+	summaries := repo.Calls[0].Arguments.Get(0).([]entities.BrokerSummary)
+	assert.Equal(t, "YP", summaries[0].BrokerCode)
+}
+
+func TestBrokerSummaryUseCase_Import_ReportsLines(t *testing.T) {
+	repo := new(MockBrokerSummaryRepository)
+	uc := usecases.NewBrokerSummaryUseCase(repo)
+
+	repo.On("Upsert", mock.Anything).Return(nil)
+
+	csv := "emiten,date,broker,buy_lots,sell_lots,buy_value,sell_value\n" +
+		"BBCA,2026-03-02,YP,10,0,9250000,0\n" +
+		"BBCA,2026-03-02,YPX,1,0,925000,0\n" +
+		"BBCA,2026-03-02,CC,ten,0,0,0\n"
+	result, err := uc.Import(strings.NewReader(csv))
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Imported)
+	require.Len(t, result.Errors, 2)
+	assert.Equal(t, 3, result.Errors[0].Line)
+	assert.Equal(t, 4, result.Errors[1].Line)
+	assert.Contains(t, result.Errors[1].Message, "buy_lots must be a whole number")
+}
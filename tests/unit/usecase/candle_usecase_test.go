@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"api-web-scrapping/internal/application/usecases"
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/services"
+)
+
+type MockCandleRepository struct {
+	mock.Mock
+}
+
+func (m *MockCandleRepository) SaveCandles(candles []entities.Candle) error {
+	return m.Called(candles).Error(0)
+}
+
+func (m *MockCandleRepository) GetCandles(emiten string, interval entities.CandleInterval, from, to time.Time) ([]entities.Candle, error) {
+	args := m.Called(emiten, interval, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.Candle), args.Error(1)
+}
+
+type MockQuarantineRepository struct {
+	mock.Mock
+}
+
+func (m *MockQuarantineRepository) Save(records []entities.QuarantinedMarketData) error {
+	return m.Called(records).Error(0)
+}
+
+func (m *MockQuarantineRepository) FindByRange(emiten string, from, to time.Time) ([]entities.QuarantinedMarketData, error) {
+	args := m.Called(emiten, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.QuarantinedMarketData), args.Error(1)
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func TestCandleUseCase_GetCandles_IntradayVolumeBaseline(t *testing.T) {
+	repo := new(MockMarketDataRepository)
+	uc := usecases.NewCandleUseCase(repo, new(MockCandleRepository), new(MockQuarantineRepository), new(MockCorporateActionRepository), false)
+
+	open := time.Date(2026, time.March, 2, 9, 0, 0, 0, services.WIB)
+	from := open.Add(time.Hour)
+	to := from.Add(30 * time.Minute)
+	day := time.Date(2026, time.March, 2, 0, 0, 0, 0, services.WIB)
+	repo.On("GetHistoryByEmiten", "BBCA", day, to).Return([]entities.MarketData{
+		{Emiten: "BBCA", OpenPrice: 9200, HighPrice: 9200, LowPrice: 9200, ClosePrice: 9200, Volume: int64Ptr(500000), Date: open.Add(5 * time.Minute)},
+		{Emiten: "BBCA", OpenPrice: 9200, HighPrice: 9225, LowPrice: 9200, ClosePrice: 9225, Volume: int64Ptr(900000), Date: from.Add(5 * time.Minute)},
+		{Emiten: "BBCA", OpenPrice: 9200, HighPrice: 9250, LowPrice: 9200, ClosePrice: 9250, Volume: int64Ptr(1000000), Date: from.Add(20 * time.Minute)},
+	}, nil)
+
+	response, err := uc.GetCandles("BBCA", "15m", from, to, false)
+
+	require.NoError(t, err)
+	require.Len(t, response.Data, 2)
+	assert.Equal(t, from, response.Data[0].Time)
+	assert.InDelta(t, 400000, response.Data[0].Volume, 1e-9)
+	assert.InDelta(t, 100000, response.Data[1].Volume, 1e-9)
+}
+
+func TestCandleUseCase_GetCandles_FillsBeforeRollups(t *testing.T) {
+	repo := new(MockMarketDataRepository)
+	candleRepo := new(MockCandleRepository)
+	uc := usecases.NewCandleUseCase(repo, candleRepo, new(MockQuarantineRepository), new(MockCorporateActionRepository), true)
+
+	from := time.Date(2026, time.March, 2, 0, 0, 0, 0, services.WIB)
+	rolledUp := from.AddDate(0, 0, 1)
+	to := from.AddDate(0, 0, 2)
+	candleRepo.On("GetCandles", "BBCA", entities.CandleInterval1d, from, to).Return([]entities.Candle{
+		{Emiten: "BBCA", Interval: entities.CandleInterval1d, Time: rolledUp, Open: 9250, High: 9300, Low: 9225, Close: 9275},
+	}, nil)
+	repo.On("GetHistoryByEmiten", "BBCA", from, rolledUp.Add(-time.Nanosecond)).Return([]entities.MarketData{
+		{Emiten: "BBCA", OpenPrice: 9200, HighPrice: 9250, LowPrice: 9175, ClosePrice: 9225, Date: from.Add(10 * time.Hour)},
+	}, nil)
+
+	response, err := uc.GetCandles("BBCA", "1d", from, to, false)
+
+	require.NoError(t, err)
+	require.Len(t, response.Data, 2)
+	assert.Equal(t, from, response.Data[0].Time)
+	assert.Equal(t, 9225.0, response.Data[0].Close)
+	assert.Equal(t, rolledUp, response.Data[1].Time)
+}
+
+func TestCandleUseCase_RefreshRollups_SkipsPartialMonth(t *testing.T) {
+	repo := new(MockMarketDataRepository)
+	candleRepo := new(MockCandleRepository)
+	uc := usecases.NewCandleUseCase(repo, candleRepo, new(MockQuarantineRepository), new(MockCorporateActionRepository), true)
+
+	now := time.Date(2026, time.March, 18, 12, 0, 0, 0, services.WIB)
+	from := time.Date(2026, time.January, 26, 0, 0, 0, 0, services.WIB)
+	repo.On("GetHistory", from, now).Return([]entities.MarketData{
+		{Emiten: "BBCA", OpenPrice: 9200, HighPrice: 9250, LowPrice: 9175, ClosePrice: 9225, Date: from.Add(34 * time.Hour)},
+		{Emiten: "BBCA", OpenPrice: 9225, HighPrice: 9275, LowPrice: 9200, ClosePrice: 9250, Date: now},
+	}, nil)
+	var saved []entities.Candle
+	candleRepo.On("SaveCandles", mock.Anything).Run(func(args mock.Arguments) {
+		saved = append(saved, args.Get(0).([]entities.Candle)...)
+	}).Return(nil)
+
+	require.NoError(t, uc.RefreshRollups(now))
+
+	var months []time.Time
+	for _, c := range saved {
+		assert.False(t, c.Time.Before(from), "%s candle at %s", c.Interval, c.Time)
+		if c.Interval == entities.CandleInterval1M {
+			months = append(months, c.Time)
+		}
+	}
+	assert.Equal(t, []time.Time{time.Date(2026, time.March, 1, 0, 0, 0, 0, services.WIB)}, months)
+}
+
+func TestCandleUseCase_GetCandles_LoadsCumClosesOnce(t *testing.T) {
+	repo := new(MockMarketDataRepository)
+	actionRepo := new(MockCorporateActionRepository)
+	uc := usecases.NewCandleUseCase(repo, new(MockCandleRepository), new(MockQuarantineRepository), actionRepo, false)
+
+	from := time.Date(2026, time.March, 2, 0, 0, 0, 0, services.WIB)
+	to := from.AddDate(0, 0, 3).Add(-time.Nanosecond)
+	firstEx := time.Date(2026, time.March, 3, 0, 0, 0, 0, time.UTC)
+	secondEx := time.Date(2026, time.March, 4, 0, 0, 0, 0, time.UTC)
+	actionRepo.On("FindByEmiten", "BBCA").Return([]entities.CorporateAction{
+		{Emiten: "BBCA", Type: entities.CorporateActionCashDividend, ExDate: firstEx, Amount: 100},
+		{Emiten: "BBCA", Type: entities.CorporateActionCashDividend, ExDate: secondEx, Amount: 90},
+	}, nil)
+	repo.On("GetHistoryByEmiten", "BBCA", from, to).Return([]entities.MarketData{
+		{Emiten: "BBCA", OpenPrice: 10000, HighPrice: 10000, LowPrice: 10000, ClosePrice: 10000, Date: from.Add(10 * time.Hour)},
+		{Emiten: "BBCA", OpenPrice: 9000, HighPrice: 9000, LowPrice: 9000, ClosePrice: 9000, Date: from.AddDate(0, 0, 1).Add(10 * time.Hour)},
+		{Emiten: "BBCA", OpenPrice: 8900, HighPrice: 8900, LowPrice: 8900, ClosePrice: 8900, Date: from.AddDate(0, 0, 2).Add(10 * time.Hour)},
+	}, nil)
+	repo.On("GetDailyHistoryByEmiten", "BBCA", mock.Anything, mock.Anything).Return([]entities.MarketData{
+		{Emiten: "BBCA", OpenPrice: 10000, HighPrice: 10000, LowPrice: 10000, ClosePrice: 10000, Date: from.Add(16 * time.Hour)},
+		{Emiten: "BBCA", OpenPrice: 9000, HighPrice: 9000, LowPrice: 9000, ClosePrice: 9000, Date: from.AddDate(0, 0, 1).Add(16 * time.Hour)},
+	}, nil).Once()
+
+	response, err := uc.GetCandles("BBCA", "1d", from, to, true)
+
+	require.NoError(t, err)
+	require.Len(t, response.Data, 3)
+	assert.InDelta(t, 10000*0.99*0.99, response.Data[0].Close, 1e-6)
+	assert.InDelta(t, 9000*0.99, response.Data[1].Close, 1e-6)
+	assert.InDelta(t, 8900, response.Data[2].Close, 1e-6)
+	repo.AssertNumberOfCalls(t, "GetDailyHistoryByEmiten", 1)
+}
@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/application/usecases"
+	"api-web-scrapping/internal/domain/entities"
+)
+
+type MockCorporateActionRepository struct {
+	mock.Mock
+}
+
+func (m *MockCorporateActionRepository) list(args mock.Arguments) ([]entities.CorporateAction, error) {
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.CorporateAction), args.Error(1)
+}
+
+func (m *MockCorporateActionRepository) Create(action *entities.CorporateAction) error {
+	return m.Called(action).Error(0)
+}
+
+func (m *MockCorporateActionRepository) Update(action *entities.CorporateAction) error {
+	return m.Called(action).Error(0)
+}
+
+func (m *MockCorporateActionRepository) Upsert(actions []entities.CorporateAction) error {
+	return m.Called(actions).Error(0)
+}
+
+func (m *MockCorporateActionRepository) Delete(id int64) error {
+	return m.Called(id).Error(0)
+}
+
+func (m *MockCorporateActionRepository) FindByID(id int64) (*entities.CorporateAction, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.CorporateAction), args.Error(1)
+}
+
+func (m *MockCorporateActionRepository) FindByEmiten(emiten string) ([]entities.CorporateAction, error) {
+	return m.list(m.Called(emiten))
+}
+
+func (m *MockCorporateActionRepository) FindByRange(emiten string, from, to time.Time) ([]entities.CorporateAction, error) {
+	return m.list(m.Called(emiten, from, to))
+}
+
+func (m *MockCorporateActionRepository) FindDividendsByDate(from, to time.Time) ([]entities.CorporateAction, error) {
+	return m.list(m.Called(from, to))
+}
+
+func TestCorporateActionUseCase_Create_Conflict(t *testing.T) {
+	repo := new(MockCorporateActionRepository)
+	uc := usecases.NewCorporateActionUseCase(repo)
+
+	repo.On("FindByEmiten", "BBCA").Return([]entities.CorporateAction{{
+		ID:     3,
+		Emiten: "BBCA",
+		Type:   entities.CorporateActionSplit,
+		ExDate: time.Date(2026, time.March, 3, 0, 0, 0, 0, time.UTC),
+	}}, nil)
+
+	_, err := uc.Create(dto.CorporateActionRequest{Emiten: "bbca", Type: "split", ExDate: "2026-03-03", Ratio: "1:5"})
+
+	assert.ErrorIs(t, err, usecases.ErrCorporateActionExists)
+	repo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestCorporateActionUseCase_Create_Invalid(t *testing.T) {
+	uc := usecases.NewCorporateActionUseCase(new(MockCorporateActionRepository))
+
+	requests := []dto.CorporateActionRequest{
+		{Emiten: "BBCA", Type: "merger", ExDate: "2026-03-03"},
+		{Emiten: "BBCA", Type: "split", ExDate: "03/03/2026", Ratio: "1:5"},
+		{Emiten: "BBCA", Type: "split", ExDate: "2026-03-03", Ratio: "5:1"},
+		{Emiten: "BBCA", Type: "reverse_split", ExDate: "2026-03-03", Ratio: "1:5"},
+		{Emiten: "BBCA", Type: "rights", ExDate: "2026-03-03", Ratio: "5:1"},
+		{Emiten: "BBCA", Type: "cash_dividend", ExDate: "2026-03-03"},
+		{Emiten: "BBCA", Type: "bonus", ExDate: "2026-03-03", Ratio: "ten to one"},
+		{Emiten: "TLKM", Type: "cash_dividend", ExDate: "2026-05-20", Amount: 178.5, CumDate: "2026-05-20"},
+		{Emiten: "TLKM", Type: "cash_dividend", ExDate: "2026-05-20", Amount: 178.5, PaymentDate: "2026-05-19"},
+		{Emiten: "BBCA", Type: "split", ExDate: "2026-03-03", Ratio: "1:5", PaymentDate: "2026-03-20"},
+	}
+	for _, req := range requests {
+		_, err := uc.Create(req)
+		assert.ErrorIs(t, err, usecases.ErrInvalidCorporateAction, "%+v", req)
+	}
+}
+
+func TestCorporateActionUseCase_Import(t *testing.T) {
+	repo := new(MockCorporateActionRepository)
+	uc := usecases.NewCorporateActionUseCase(repo)
+
+	var saved []entities.CorporateAction
+	repo.On("Upsert", mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(0).([]entities.CorporateAction)
+	}).Return(nil)
+
+	csv := "emiten,type,ex_date,ratio,price,amount,description\n" +
+		"BBCA,split,2026-03-03,1:5,,,Stock split\n" +
+		"TLKM,cash_dividend,2026-05-20,,,178.5,Final dividend\n" +
+		"BBRI,rights,2026-06-01,10:1,abc,,\n"
+
+	result, err := uc.Import(strings.NewReader(csv))
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Imported)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, 4, result.Errors[0].Line)
+	require.Len(t, saved, 2)
+	assert.Equal(t, 5.0, saved[0].RatioTo)
+	assert.Equal(t, 178.5, saved[1].Amount)
+	assert.Zero(t, saved[1].RatioFrom)
+}
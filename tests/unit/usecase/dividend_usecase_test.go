@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"api-web-scrapping/internal/application/usecases"
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/services"
+)
+
+func TestDividendUseCase_Yields_OneRowPerEmiten(t *testing.T) {
+	actions := new(MockCorporateActionRepository)
+	repo := new(MockMarketDataRepository)
+	uc := usecases.NewDividendUseCase(actions, repo)
+
+	exDate := services.CalendarDate(time.Now()).AddDate(0, -1, 0)
+	actions.On("FindByRange", "", mock.Anything, mock.Anything).Return([]entities.CorporateAction{
+		{Emiten: "BBCA", Type: entities.CorporateActionCashDividend, ExDate: exDate, Amount: 185},
+	}, nil)
+	// Two snapshots of BBCA share the latest scrape time
+	scraped := time.Now().Add(-time.Hour)
+	repo.On("GetLatestByAllEmiten").Return([]entities.MarketData{
+		{ID: 1, Emiten: "BBCA", OpenPrice: 9200, HighPrice: 9200, LowPrice: 9200, ClosePrice: 9200, Date: scraped},
+		{ID: 2, Emiten: "BBCA", OpenPrice: 9250, HighPrice: 9250, LowPrice: 9250, ClosePrice: 9250, Date: scraped},
+	}, nil)
+
+	response, err := uc.Yields()
+
+	require.NoError(t, err)
+	require.Len(t, response.Data, 1)
+	assert.Equal(t, 1, response.Count)
+	assert.Equal(t, 9250.0, response.Data[0].Close)
+	assert.InDelta(t, 2, response.Data[0].TTMYieldPct, 1e-9)
+}
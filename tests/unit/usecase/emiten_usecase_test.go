@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/application/usecases"
+	"api-web-scrapping/internal/domain/entities"
+)
+
+type MockEmitenRepository struct {
+	mock.Mock
+}
+
+func (m *MockEmitenRepository) Create(emiten *entities.Emiten) error {
+	return m.Called(emiten).Error(0)
+}
+
+func (m *MockEmitenRepository) Update(emiten *entities.Emiten) error {
+	return m.Called(emiten).Error(0)
+}
+
+func (m *MockEmitenRepository) Upsert(emitens []entities.Emiten) error {
+	return m.Called(emitens).Error(0)
+}
+
+func (m *MockEmitenRepository) Delete(ticker string) error {
+	return m.Called(ticker).Error(0)
+}
+
+func (m *MockEmitenRepository) FindByTicker(ticker string) (*entities.Emiten, error) {
+	args := m.Called(ticker)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Emiten), args.Error(1)
+}
+
+func (m *MockEmitenRepository) FindAll() ([]entities.Emiten, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.Emiten), args.Error(1)
+}
+
+func TestEmitenUseCase_Create_Conflict(t *testing.T) {
+	repo := new(MockEmitenRepository)
+	uc := usecases.NewEmitenUseCase(repo)
+
+	repo.On("FindByTicker", "BBCA").Return(&entities.Emiten{Ticker: "BBCA"}, nil)
+
+	_, err := uc.Create(dto.EmitenRequest{Ticker: " bbca ", CompanyName: "Bank Central Asia Tbk."})
+
+	assert.ErrorIs(t, err, usecases.ErrEmitenExists)
+	repo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestEmitenUseCase_Create_RejectsWatchlistBoard(t *testing.T) {
+	repo := new(MockEmitenRepository)
+	uc := usecases.NewEmitenUseCase(repo)
+
+	_, err := uc.Create(dto.EmitenRequest{Ticker: "SRIL", CompanyName: "Sri Rejeki Isman Tbk.", Board: "watchlist"})
+
+	assert.ErrorIs(t, err, usecases.ErrInvalidEmiten)
+	assert.ErrorContains(t, err, "trading status")
+	repo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestEmitenUseCase_Import_SkipsInvalidRows(t *testing.T) {
+	repo := new(MockEmitenRepository)
+	uc := usecases.NewEmitenUseCase(repo)
+
+	csv := "\ufeffTicker,Company_Name,Board,Listing_Date,Shares_Outstanding,Is_Sharia\n" +
+		"bbca,Bank Central Asia Tbk.,main,2000-05-31,123275050000,false\n" +
+		"TLKM,Telkom Indonesia (Persero) Tbk.,,1995-11-14,99062216600,true\n" +
+		"XXXX,Bad Board Tbk.,premium,,,\n" +
+		"BBCA,Duplicate Tbk.,main,,,\n" +
+		"GOTO,GoTo Gojek Tokopedia Tbk.,main,2022-04-11,many,true\n"
+
+	var saved []entities.Emiten
+	repo.On("Upsert", mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(0).([]entities.Emiten)
+	}).Return(nil)
+
+	result, err := uc.Import(strings.NewReader(csv))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Imported)
+	assert.Equal(t, 3, result.Failed)
+	if assert.Len(t, result.Errors, 3) {
+		assert.Equal(t, 4, result.Errors[0].Line)
+		assert.Equal(t, 5, result.Errors[1].Line)
+		assert.Equal(t, 6, result.Errors[2].Line)
+	}
+	if assert.Len(t, saved, 2) {
+		assert.Equal(t, "BBCA", saved[0].Ticker)
+		assert.Equal(t, entities.BoardMain, saved[1].Board)
+		assert.True(t, saved[1].IsSharia)
+	}
+}
+
+func TestEmitenUseCase_Import_MissingColumn(t *testing.T) {
+	uc := usecases.NewEmitenUseCase(new(MockEmitenRepository))
+
+	_, err := uc.Import(strings.NewReader("ticker,sector\nBBCA,Financials\n"))
+
+	assert.ErrorIs(t, err, usecases.ErrInvalidCSV)
+}
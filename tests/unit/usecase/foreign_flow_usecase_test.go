@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/application/usecases"
+	"api-web-scrapping/internal/domain/entities"
+)
+
+type MockForeignFlowRepository struct {
+	mock.Mock
+}
+
+func (m *MockForeignFlowRepository) list(args mock.Arguments) ([]entities.ForeignFlow, error) {
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.ForeignFlow), args.Error(1)
+}
+
+func (m *MockForeignFlowRepository) Upsert(flows []entities.ForeignFlow) error {
+	return m.Called(flows).Error(0)
+}
+
+func (m *MockForeignFlowRepository) FindByEmiten(emiten string, from, to time.Time) ([]entities.ForeignFlow, error) {
+	return m.list(m.Called(emiten, from, to))
+}
+
+func (m *MockForeignFlowRepository) FindByRange(from, to time.Time) ([]entities.ForeignFlow, error) {
+	return m.list(m.Called(from, to))
+}
+
+func (m *MockForeignFlowRepository) LatestDate() (*time.Time, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*time.Time), args.Error(1)
+}
+
+func TestForeignFlowUseCase_Rankings_LatestSession(t *testing.T) {
+	repo := new(MockForeignFlowRepository)
+	uc := usecases.NewForeignFlowUseCase(repo)
+
+	latest := time.Date(2026, time.March, 6, 0, 0, 0, 0, time.UTC)
+	repo.On("LatestDate").Return(&latest, nil)
+	repo.On("FindByRange", latest, latest).Return([]entities.ForeignFlow{
+		{Emiten: "BBCA", Date: latest, BuyValue: 500e9, SellValue: 200e9},
+		{Emiten: "BBRI", Date: latest, BuyValue: 100e9, SellValue: 350e9},
+		{Emiten: "TLKM", Date: latest, BuyValue: 80e9, SellValue: 20e9},
+		{Emiten: "ASII", Date: latest, BuyValue: 10e9, SellValue: 10e9},
+	}, nil)
+
+	rankings, err := uc.Rankings(time.Time{}, time.Time{}, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, "2026-03-06", rankings.From)
+	assert.Equal(t, "2026-03-06", rankings.To)
+	require.Len(t, rankings.NetBuys, 2)
+	assert.Equal(t, "BBCA", rankings.NetBuys[0].Emiten)
+	assert.InDelta(t, 300e9, rankings.NetBuys[0].NetValue, 1)
+	assert.Equal(t, "TLKM", rankings.NetBuys[1].Emiten)
+	require.Len(t, rankings.NetSells, 1)
+	assert.Equal(t, "BBRI", rankings.NetSells[0].Emiten)
+	assert.InDelta(t, -250e9, rankings.NetSells[0].NetValue, 1)
+}
+
+func TestForeignFlowUseCase_History_Total(t *testing.T) {
+	repo := new(MockForeignFlowRepository)
+	uc := usecases.NewForeignFlowUseCase(repo)
+
+	from := time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, time.March, 3, 0, 0, 0, 0, time.UTC)
+	repo.On("FindByEmiten", "BBCA", from, to).Return([]entities.ForeignFlow{
+		{Emiten: "BBCA", Date: from, BuyVolume: 1000, SellVolume: 400, BuyValue: 9e6, SellValue: 3.6e6},
+		{Emiten: "BBCA", Date: to, BuyVolume: 200, SellVolume: 700, BuyValue: 1.8e6, SellValue: 6.3e6},
+	}, nil)
+
+	history, err := uc.History("bbca", from, to)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, history.Count)
+	assert.Equal(t, int64(600), history.Data[0].NetVolume)
+	assert.Equal(t, 2, history.Total.Days)
+	assert.Equal(t, int64(100), history.Total.NetVolume)
+	assert.InDelta(t, 0.9e6, history.Total.NetValue, 1e-6)
+}
+
+func TestForeignFlowUseCase_Ingest_SkipsInvalid(t *testing.T) {
+	repo := new(MockForeignFlowRepository)
+	uc := usecases.NewForeignFlowUseCase(repo)
+
+	repo.On("Upsert", mock.MatchedBy(func(flows []entities.ForeignFlow) bool {
+		return len(flows) == 1 && flows[0].Emiten == "BBCA"
+	})).Return(nil)
+
+	result, err := uc.Ingest([]dto.ForeignFlowRequest{
+		{Emiten: "bbca", Date: "2026-03-06", BuyVolume: 100, BuyValue: 925000},
+		{Emiten: "BBRI", Date: "06/03/2026"},
+		{Emiten: "TLKM", Date: "2026-03-06", SellVolume: -5},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Imported)
+	assert.Equal(t, 2, result.Failed)
+	assert.Equal(t, 2, result.Errors[0].Line)
+	assert.Equal(t, 3, result.Errors[1].Line)
+}
+
+func TestForeignFlowUseCase_Import(t *testing.T) {
+	repo := new(MockForeignFlowRepository)
+	uc := usecases.NewForeignFlowUseCase(repo)
+
+	repo.On("Upsert", mock.Anything).Return(nil)
+
+	csv := "emiten,date,buy_volume,sell_volume,buy_value,sell_value\n" +
+		"BBCA,2026-03-06,1000,400,9250000,3700000\n" +
+		"BBRI,2026-03-06,lots,0,0,0\n"
+	result, err := uc.Import(strings.NewReader(csv))
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Imported)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, 3, result.Errors[0].Line)
+}
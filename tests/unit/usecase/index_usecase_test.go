@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"api-web-scrapping/internal/application/usecases"
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/services"
+)
+
+type MockIndexRepository struct {
+	mock.Mock
+}
+
+func (m *MockIndexRepository) Create(index *entities.Index, base *entities.IndexPeriod) error {
+	return m.Called(index, base).Error(0)
+}
+
+func (m *MockIndexRepository) Update(index *entities.Index) error {
+	return m.Called(index).Error(0)
+}
+
+func (m *MockIndexRepository) Delete(code string) error {
+	return m.Called(code).Error(0)
+}
+
+func (m *MockIndexRepository) FindByCode(code string) (*entities.Index, error) {
+	args := m.Called(code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Index), args.Error(1)
+}
+
+func (m *MockIndexRepository) FindAll() ([]entities.Index, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.Index), args.Error(1)
+}
+
+func (m *MockIndexRepository) CreatePeriod(period *entities.IndexPeriod) error {
+	return m.Called(period).Error(0)
+}
+
+func (m *MockIndexRepository) FindPeriods(code string) ([]entities.IndexPeriod, error) {
+	args := m.Called(code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.IndexPeriod), args.Error(1)
+}
+
+func TestIndexUseCase_GetSeries_SkipsOffTickSnapshots(t *testing.T) {
+	indexRepo := new(MockIndexRepository)
+	repo := new(MockMarketDataRepository)
+	uc := usecases.NewIndexUseCase(indexRepo, repo, new(MockEmitenRepository))
+
+	session := time.Date(2026, time.March, 2, 0, 0, 0, 0, services.WIB)
+	indexRepo.On("FindByCode", "TEST").Return(&entities.Index{Code: "TEST", Weighting: entities.IndexWeightingPrice}, nil)
+	indexRepo.On("FindPeriods", "TEST").Return([]entities.IndexPeriod{{
+		EffectiveFrom: time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC),
+		Divisor:       92.5,
+		Constituents:  []entities.IndexConstituent{{Emiten: "BBCA", Weight: 1}},
+	}}, nil)
+	// 9301 is off the Rp25 tick grid of BBCA
+	repo.On("GetHistoryByEmiten", "BBCA", mock.Anything, mock.Anything).Return([]entities.MarketData{
+		{Emiten: "BBCA", OpenPrice: 9250, HighPrice: 9250, LowPrice: 9250, ClosePrice: 9250, Date: session.Add(10 * time.Hour)},
+		{Emiten: "BBCA", OpenPrice: 9250, HighPrice: 9301, LowPrice: 9250, ClosePrice: 9301, Date: session.Add(11 * time.Hour)},
+		{Emiten: "BBCA", OpenPrice: 9250, HighPrice: 9275, LowPrice: 9250, ClosePrice: 9275, Date: session.Add(15 * time.Hour)},
+	}, nil)
+
+	response, err := uc.GetSeries("test", session, session.Add(24*time.Hour-time.Nanosecond))
+
+	require.NoError(t, err)
+	require.Len(t, response.Data, 1)
+	assert.InDelta(t, 9275/92.5, response.Data[0].HighPrice, 1e-9)
+	assert.InDelta(t, 9275/92.5, response.Data[0].ClosePrice, 1e-9)
+}
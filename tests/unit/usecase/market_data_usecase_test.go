@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/application/usecases"
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/services"
+)
+
+type MockMarketDataRepository struct {
+	mock.Mock
+}
+
+func (m *MockMarketDataRepository) list(args mock.Arguments) ([]entities.MarketData, error) {
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.MarketData), args.Error(1)
+}
+
+func (m *MockMarketDataRepository) one(args mock.Arguments) (*entities.MarketData, error) {
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.MarketData), args.Error(1)
+}
+
+func (m *MockMarketDataRepository) GetAll() ([]entities.MarketData, error) {
+	return m.list(m.Called())
+}
+
+func (m *MockMarketDataRepository) GetByEmiten(emiten string) ([]entities.MarketData, error) {
+	return m.list(m.Called(emiten))
+}
+
+func (m *MockMarketDataRepository) GetLatestByEmiten(emiten string) (*entities.MarketData, error) {
+	return m.one(m.Called(emiten))
+}
+
+func (m *MockMarketDataRepository) GetLatestByAllEmiten() ([]entities.MarketData, error) {
+	return m.list(m.Called())
+}
+
+func (m *MockMarketDataRepository) GetLatestByEmitenAsOf(emiten string, asOf time.Time) (*entities.MarketData, error) {
+	return m.one(m.Called(emiten, asOf))
+}
+
+func (m *MockMarketDataRepository) GetLatestByAllEmitenAsOf(asOf time.Time) ([]entities.MarketData, error) {
+	return m.list(m.Called(asOf))
+}
+
+func (m *MockMarketDataRepository) GetHistoryByEmiten(emiten string, from, to time.Time) ([]entities.MarketData, error) {
+	return m.list(m.Called(emiten, from, to))
+}
+
+func (m *MockMarketDataRepository) GetHistory(from, to time.Time) ([]entities.MarketData, error) {
+	return m.list(m.Called(from, to))
+}
+
+func (m *MockMarketDataRepository) GetDailyHistoryByEmiten(emiten string, from, to time.Time) ([]entities.MarketData, error) {
+	return m.list(m.Called(emiten, from, to))
+}
+
+func (m *MockMarketDataRepository) GetDailyHistory(from, to time.Time) ([]entities.MarketData, error) {
+	return m.list(m.Called(from, to))
+}
+
+func (m *MockMarketDataRepository) GetHighestByEmiten(emiten string, before time.Time, limit int) ([]entities.MarketData, error) {
+	return m.list(m.Called(emiten, before, limit))
+}
+
+func TestMarketDataUseCase_GetLatestByAllEmiten_AsOf(t *testing.T) {
+	repo := new(MockMarketDataRepository)
+	uc := usecases.NewMarketDataUseCase(repo, new(MockEmitenRepository), new(MockCorporateActionRepository), noTradingStatuses(), services.DefaultAutoRejectionRules())
+
+	asOf := time.Date(2026, time.March, 2, 10, 0, 0, 0, time.UTC)
+	repo.On("GetLatestByAllEmitenAsOf", asOf).Return([]entities.MarketData{{ID: 7, Emiten: "BBCA", ClosePrice: 9250}}, nil)
+
+	response, err := uc.GetLatestByAllEmiten(dto.MarketDataQuery{AsOf: asOf})
+
+	require.NoError(t, err)
+	require.NotNil(t, response.AsOf)
+	assert.Equal(t, asOf, *response.AsOf)
+	assert.Equal(t, int64(7), response.Data[0].ID)
+	repo.AssertNotCalled(t, "GetLatestByAllEmiten")
+}
+
+func TestMarketDataUseCase_GetLatestByEmiten_AsOfBeforeFirstRecord(t *testing.T) {
+	repo := new(MockMarketDataRepository)
+	uc := usecases.NewMarketDataUseCase(repo, new(MockEmitenRepository), new(MockCorporateActionRepository), new(MockTradingStatusRepository), services.DefaultAutoRejectionRules())
+
+	asOf := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	repo.On("GetLatestByEmitenAsOf", "BBCA", asOf).Return(nil, nil)
+
+	_, err := uc.GetLatestByEmiten("BBCA", dto.MarketDataQuery{AsOf: asOf})
+
+	assert.Error(t, err)
+	repo.AssertNotCalled(t, "GetLatestByEmiten", mock.Anything)
+}
+
+func TestMarketDataUseCase_GetLatestByEmiten_OffTick(t *testing.T) {
+	repo := new(MockMarketDataRepository)
+	uc := usecases.NewMarketDataUseCase(repo, new(MockEmitenRepository), new(MockCorporateActionRepository), noTradingStatuses(), services.DefaultAutoRejectionRules())
+
+	session := time.Date(2026, time.March, 2, 0, 0, 0, 0, services.WIB)
+	offTick := entities.MarketData{ID: 9, Emiten: "BBCA", ClosePrice: 9251, Date: session.Add(11 * time.Hour)}
+	repo.On("GetLatestByEmiten", "BBCA").Return(&offTick, nil)
+	repo.On("GetHistoryByEmiten", "BBCA", session, offTick.Date).Return([]entities.MarketData{
+		{ID: 7, Emiten: "BBCA", ClosePrice: 9225, Date: session.Add(10 * time.Hour)},
+		{ID: 8, Emiten: "BBCA", ClosePrice: 9250, Date: session.Add(10*time.Hour + 30*time.Minute)},
+		offTick,
+	}, nil)
+
+	response, err := uc.GetLatestByEmiten("BBCA", dto.MarketDataQuery{})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), response.ID)
+	assert.Equal(t, 9250.0, response.ClosePrice)
+}
+
+func TestMarketDataUseCase_GetByEmiten_Adjusted(t *testing.T) {
+	repo := new(MockMarketDataRepository)
+	actions := new(MockCorporateActionRepository)
+	uc := usecases.NewMarketDataUseCase(repo, new(MockEmitenRepository), actions, noTradingStatuses(), services.DefaultAutoRejectionRules())
+
+	before := time.Date(2026, time.March, 2, 9, 30, 0, 0, services.WIB)
+	after := time.Date(2026, time.March, 3, 9, 30, 0, 0, services.WIB)
+	repo.On("GetByEmiten", "BBCA").Return([]entities.MarketData{
+		{Emiten: "BBCA", OpenPrice: 9000, HighPrice: 9100, LowPrice: 8900, ClosePrice: 9000, Date: before},
+		{Emiten: "BBCA", OpenPrice: 1800, HighPrice: 1850, LowPrice: 1790, ClosePrice: 1820, Date: after},
+	}, nil)
+	actions.On("FindByEmiten", "BBCA").Return([]entities.CorporateAction{{
+		Emiten:    "BBCA",
+		Type:      entities.CorporateActionSplit,
+		ExDate:    time.Date(2026, time.March, 3, 0, 0, 0, 0, time.UTC),
+		RatioFrom: 1,
+		RatioTo:   5,
+	}}, nil)
+
+	response, err := uc.GetByEmiten("BBCA", dto.MarketDataQuery{Adjusted: true})
+
+	require.NoError(t, err)
+	assert.True(t, response.Adjusted)
+	assert.InDelta(t, 1800, response.Data[0].ClosePrice, 1e-9)
+	assert.InDelta(t, 1820, response.Data[0].HighPrice, 1e-9)
+	assert.InDelta(t, 1820, response.Data[1].ClosePrice, 1e-9)
+}
+
+func TestMarketDataUseCase_GetLatestByAllEmiten_ExcludeSuspended(t *testing.T) {
+	repo := new(MockMarketDataRepository)
+	statuses := new(MockTradingStatusRepository)
+	uc := usecases.NewMarketDataUseCase(repo, new(MockEmitenRepository), new(MockCorporateActionRepository), statuses, services.DefaultAutoRejectionRules())
+
+	stale := time.Date(2026, time.February, 2, 15, 0, 0, 0, services.WIB)
+	repo.On("GetLatestByAllEmiten").Return([]entities.MarketData{
+		{Emiten: "BBCA", ClosePrice: 9250, Date: time.Now()},
+		{Emiten: "SRIL", ClosePrice: 146, Date: stale},
+	}, nil)
+	statuses.On("FindByRange", mock.Anything, mock.Anything).Return([]entities.TradingStatus{{
+		Emiten:        "SRIL",
+		Status:        entities.EmitenStatusSuspended,
+		Notations:     []string{"E"},
+		EffectiveFrom: time.Date(2026, time.February, 3, 0, 0, 0, 0, time.UTC),
+	}}, nil)
+
+	all, err := uc.GetLatestByAllEmiten(dto.MarketDataQuery{})
+	require.NoError(t, err)
+	require.Len(t, all.Data, 2)
+	assert.False(t, all.Data[0].TradingStatus.Suspended)
+	assert.True(t, all.Data[1].TradingStatus.Suspended)
+	assert.Equal(t, []string{"E"}, all.Data[1].TradingStatus.Notations)
+
+	active, err := uc.GetLatestByAllEmiten(dto.MarketDataQuery{ExcludeSuspended: true})
+	require.NoError(t, err)
+	require.Len(t, active.Data, 1)
+	assert.Equal(t, "BBCA", active.Data[0].Emiten)
+}
+
+func TestMarketDataUseCase_GetLatestByAllEmiten_LimitsBoundStaleHistory(t *testing.T) {
+	repo := new(MockMarketDataRepository)
+	emitens := new(MockEmitenRepository)
+	uc := usecases.NewMarketDataUseCase(repo, emitens, new(MockCorporateActionRepository), noTradingStatuses(), services.DefaultAutoRejectionRules())
+
+	session := time.Date(2026, time.March, 2, 0, 0, 0, 0, services.WIB)
+	latest := session.Add(15 * time.Hour)
+	stale := time.Date(2024, time.March, 1, 15, 0, 0, 0, services.WIB)
+	repo.On("GetLatestByAllEmiten").Return([]entities.MarketData{
+		{Emiten: "BBCA", OpenPrice: 9250, HighPrice: 9250, LowPrice: 9250, ClosePrice: 9250, Date: latest},
+		{Emiten: "SRIL", OpenPrice: 146, HighPrice: 146, LowPrice: 146, ClosePrice: 146, Date: stale},
+	}, nil)
+	emitens.On("FindAll").Return([]entities.Emiten{}, nil)
+	// The market-wide load covers the latest session only; the stale emiten
+	// is loaded on its own
+	repo.On("GetDailyHistory", session.AddDate(0, 0, -15), latest).Return([]entities.MarketData{
+		{Emiten: "BBCA", ClosePrice: 9200, Date: session.Add(-9 * time.Hour)},
+	}, nil)
+	staleSession := time.Date(2024, time.March, 1, 0, 0, 0, 0, services.WIB)
+	repo.On("GetDailyHistoryByEmiten", "SRIL", staleSession.AddDate(0, 0, -15), stale).Return([]entities.MarketData{
+		{Emiten: "SRIL", ClosePrice: 150, Date: staleSession.Add(-9 * time.Hour)},
+	}, nil)
+
+	response, err := uc.GetLatestByAllEmiten(dto.MarketDataQuery{IncludeLimits: true})
+
+	require.NoError(t, err)
+	require.Len(t, response.Data, 2)
+	require.NotNil(t, response.Data[0].AutoRejection)
+	assert.Equal(t, 9200.0, response.Data[0].AutoRejection.PrevClose)
+	require.NotNil(t, response.Data[1].AutoRejection)
+	assert.Equal(t, 150.0, response.Data[1].AutoRejection.PrevClose)
+	repo.AssertNumberOfCalls(t, "GetDailyHistory", 1)
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"api-web-scrapping/internal/application/usecases"
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/services"
+)
+
+func TestMarketUseCase_GetSectors_SkipsStaleEmitens(t *testing.T) {
+	repo := new(MockMarketDataRepository)
+	emitens := new(MockEmitenRepository)
+	uc := usecases.NewMarketUseCase(repo, emitens, noTradingStatuses(), nil, services.DefaultAutoRejectionRules())
+
+	session := time.Date(2026, time.March, 2, 0, 0, 0, 0, services.WIB)
+	repo.On("GetLatestByAllEmiten").Return([]entities.MarketData{
+		{Emiten: "BBCA", ClosePrice: 9250, Date: session.Add(15 * time.Hour)},
+		{Emiten: "SRIL", ClosePrice: 146, Date: session.AddDate(-2, 0, 0)},
+	}, nil)
+	emitens.On("FindAll").Return([]entities.Emiten{
+		{Ticker: "BBCA", Sector: "Finance"},
+		{Ticker: "SRIL", Sector: "Consumer"},
+	}, nil)
+	repo.On("GetDailyHistory", session.AddDate(0, 0, -21), session.Add(15*time.Hour)).Return([]entities.MarketData{
+		{Emiten: "BBCA", ClosePrice: 9200, Date: session.Add(-9 * time.Hour)},
+		{Emiten: "BBCA", ClosePrice: 9250, Date: session.Add(15 * time.Hour)},
+	}, nil)
+
+	response, err := uc.GetSectors()
+
+	require.NoError(t, err)
+	require.Len(t, response.Data, 1)
+	assert.Equal(t, "Finance", response.Data[0].Sector)
+	assert.Equal(t, 1, response.Data[0].Advancers)
+}
+
+func TestMarketUseCase_GetSectors_SkipsDelistedByTradingStatus(t *testing.T) {
+	repo := new(MockMarketDataRepository)
+	emitens := new(MockEmitenRepository)
+	statuses := new(MockTradingStatusRepository)
+	uc := usecases.NewMarketUseCase(repo, emitens, statuses, nil, services.DefaultAutoRejectionRules())
+
+	session := time.Date(2026, time.March, 2, 0, 0, 0, 0, services.WIB)
+	repo.On("GetLatestByAllEmiten").Return([]entities.MarketData{
+		{Emiten: "BBCA", ClosePrice: 9250, Date: session.Add(15 * time.Hour)},
+		{Emiten: "SRIL", ClosePrice: 146, Date: session.Add(15 * time.Hour)},
+	}, nil)
+	emitens.On("FindAll").Return([]entities.Emiten{
+		{Ticker: "BBCA", Sector: "Finance"},
+		{Ticker: "SRIL", Sector: "Consumer"},
+	}, nil)
+	repo.On("GetDailyHistory", session.AddDate(0, 0, -21), session.Add(15*time.Hour)).Return([]entities.MarketData{
+		{Emiten: "BBCA", ClosePrice: 9200, Date: session.Add(-9 * time.Hour)},
+		{Emiten: "SRIL", ClosePrice: 150, Date: session.Add(-9 * time.Hour)},
+	}, nil)
+	day := time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC)
+	statuses.On("FindByRange", day, day).Return([]entities.TradingStatus{
+		{Emiten: "SRIL", Status: entities.EmitenStatusDelisted, EffectiveFrom: day.AddDate(0, -1, 0)},
+	}, nil)
+
+	response, err := uc.GetSectors()
+
+	require.NoError(t, err)
+	require.Len(t, response.Data, 1)
+	assert.Equal(t, "Finance", response.Data[0].Sector)
+}
+
+func TestMarketUseCase_GetAutoRejections_WatchlistFromTradingStatus(t *testing.T) {
+	repo := new(MockMarketDataRepository)
+	emitens := new(MockEmitenRepository)
+	statuses := new(MockTradingStatusRepository)
+	uc := usecases.NewMarketUseCase(repo, emitens, statuses, nil, services.DefaultAutoRejectionRules())
+
+	session := time.Date(2026, time.March, 2, 0, 0, 0, 0, services.WIB)
+	repo.On("GetLatestByAllEmiten").Return([]entities.MarketData{
+		{Emiten: "BBCA", OpenPrice: 9250, HighPrice: 9250, LowPrice: 9250, ClosePrice: 9250, Date: session.Add(15 * time.Hour)},
+		{Emiten: "SRIL", OpenPrice: 60, HighPrice: 60, LowPrice: 60, ClosePrice: 60, Date: session.Add(15 * time.Hour)},
+	}, nil)
+	emitens.On("FindAll").Return([]entities.Emiten{
+		{Ticker: "BBCA", Board: entities.BoardMain},
+		{Ticker: "SRIL", Board: entities.BoardDevelopment},
+	}, nil)
+	repo.On("GetDailyHistory", session.AddDate(0, 0, -15), session.Add(15*time.Hour)).Return([]entities.MarketData{
+		{Emiten: "BBCA", ClosePrice: 7400, Date: session.Add(-9 * time.Hour)},
+		{Emiten: "SRIL", ClosePrice: 50, Date: session.Add(-9 * time.Hour)},
+	}, nil)
+	day := time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC)
+	statuses.On("FindByRange", day, day).Return([]entities.TradingStatus{
+		{Emiten: "SRIL", Status: entities.EmitenStatusActive, Watchlist: true, EffectiveFrom: day.AddDate(0, -1, 0)},
+	}, nil)
+
+	response, err := uc.GetAutoRejections()
+
+	require.NoError(t, err)
+	require.Len(t, response.ARA, 2)
+	boards := map[string]string{}
+	for _, stock := range response.ARA {
+		boards[stock.Emiten] = stock.Board
+	}
+	assert.Equal(t, entities.BoardMain, boards["BBCA"])
+	assert.Equal(t, entities.BoardWatchlist, boards["SRIL"])
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/application/usecases"
+	"api-web-scrapping/internal/domain/entities"
+)
+
+type MockOrderBookRepository struct {
+	mock.Mock
+}
+
+func (m *MockOrderBookRepository) Save(snapshots []entities.OrderBookSnapshot) error {
+	return m.Called(snapshots).Error(0)
+}
+
+func (m *MockOrderBookRepository) FindLatestByEmiten(emiten string, asOf time.Time) (*entities.OrderBookSnapshot, error) {
+	args := m.Called(emiten, asOf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.OrderBookSnapshot), args.Error(1)
+}
+
+func (m *MockOrderBookRepository) DeleteBefore(cutoff time.Time) (int64, error) {
+	args := m.Called(cutoff)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestOrderBookUseCase_Latest(t *testing.T) {
+	repo := new(MockOrderBookRepository)
+	uc := usecases.NewOrderBookUseCase(repo)
+
+	asOf := time.Date(2026, time.March, 6, 10, 0, 0, 0, time.UTC)
+	repo.On("FindLatestByEmiten", "BBCA", asOf).Return(&entities.OrderBookSnapshot{
+		Emiten: "BBCA",
+		Time:   asOf.Add(-time.Second),
+		Bids:   []entities.OrderBookLevel{{Price: 9225, Lots: 120, Orders: 8}},
+		Offers: []entities.OrderBookLevel{{Price: 9250, Lots: 40, Orders: 3}},
+	}, nil)
+	repo.On("FindLatestByEmiten", "GOTO", asOf).Return(nil, nil)
+
+	book, err := uc.Latest("bbca", asOf, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 9225.0, *book.Metrics.BestBid)
+	assert.Equal(t, 1, *book.Metrics.SpreadTicks)
+	assert.InDelta(t, 0.5, *book.Metrics.Imbalance, 1e-9)
+
+	_, err = uc.Latest("GOTO", asOf, 0)
+	assert.ErrorIs(t, err, usecases.ErrOrderBookNotFound)
+}
+
+func TestOrderBookUseCase_Ingest_SkipsInvalid(t *testing.T) {
+	repo := new(MockOrderBookRepository)
+	uc := usecases.NewOrderBookUseCase(repo)
+
+	repo.On("Save", mock.MatchedBy(func(snapshots []entities.OrderBookSnapshot) bool {
+		return len(snapshots) == 1 && snapshots[0].Offers[0].Price == 9250
+	})).Return(nil)
+
+	at := time.Date(2026, time.March, 6, 10, 0, 0, 0, time.UTC)
+	result, err := uc.Ingest([]dto.OrderBookRequest{
+		{Emiten: "BBCA", Time: at, Offers: []dto.OrderBookLevel{{Price: 9275, Lots: 5}, {Price: 9250, Lots: 40}}},
+		{Emiten: "BBRI", Time: at, Bids: []dto.OrderBookLevel{{Price: -1, Lots: 5}}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Imported)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, 2, result.Errors[0].Line)
+}
+
+func TestOrderBookUseCase_Prune(t *testing.T) {
+	repo := new(MockOrderBookRepository)
+	uc := usecases.NewOrderBookUseCase(repo)
+
+	now := time.Date(2026, time.March, 13, 0, 0, 0, 0, time.UTC)
+	repo.On("DeleteBefore", time.Date(2026, time.March, 6, 0, 0, 0, 0, time.UTC)).Return(int64(42), nil)
+
+	removed, err := uc.Prune(now, 7*24*time.Hour)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), removed)
+}
@@ -0,0 +1,202 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/application/usecases"
+	"api-web-scrapping/internal/domain/entities"
+)
+
+type MockScreenRepository struct {
+	mock.Mock
+}
+
+func (m *MockScreenRepository) Create(screen *entities.Screen) error {
+	return m.Called(screen).Error(0)
+}
+
+func (m *MockScreenRepository) Update(screen *entities.Screen) error {
+	return m.Called(screen).Error(0)
+}
+
+func (m *MockScreenRepository) Delete(id int64) error {
+	return m.Called(id).Error(0)
+}
+
+func (m *MockScreenRepository) FindByID(id int64) (*entities.Screen, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Screen), args.Error(1)
+}
+
+func (m *MockScreenRepository) FindByUser(userID string) ([]entities.Screen, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]entities.Screen), args.Error(1)
+}
+
+func (m *MockScreenRepository) FindDue(now time.Time) ([]entities.Screen, error) {
+	args := m.Called(now)
+	return args.Get(0).([]entities.Screen), args.Error(1)
+}
+
+func (m *MockScreenRepository) UpdateRunTimes(id int64, lastRunAt time.Time, nextRunAt *time.Time) error {
+	return m.Called(id, lastRunAt, nextRunAt).Error(0)
+}
+
+func (m *MockScreenRepository) CreateRun(run *entities.ScreenRun) error {
+	return m.Called(run).Error(0)
+}
+
+func (m *MockScreenRepository) FindRuns(screenID int64, limit int) ([]entities.ScreenRun, error) {
+	args := m.Called(screenID, limit)
+	return args.Get(0).([]entities.ScreenRun), args.Error(1)
+}
+
+func (m *MockScreenRepository) FindRun(screenID, runID int64) (*entities.ScreenRun, error) {
+	args := m.Called(screenID, runID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ScreenRun), args.Error(1)
+}
+
+func (m *MockScreenRepository) FindPreviousRun(screenID, runID int64) (*entities.ScreenRun, error) {
+	args := m.Called(screenID, runID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ScreenRun), args.Error(1)
+}
+
+func float64Ptr(v float64) *float64 {
+	return &v
+}
+
+func TestScreenUseCase_RunNow_SmallBounds(t *testing.T) {
+	repo := new(MockMarketDataRepository)
+	screens := new(MockScreenRepository)
+	uc := usecases.NewScreenUseCase(screens, usecases.NewScreenerUseCase(repo))
+
+	screen := &entities.Screen{
+		ID:     1,
+		UserID: "user-1",
+		Filter: entities.ScreenFilter{MinPrice: float64Ptr(1e-7), MaxPrice: float64Ptr(1e21)},
+	}
+	screens.On("FindByID", int64(1)).Return(screen, nil)
+	repo.On("GetLatestByAllEmiten").Return([]entities.MarketData{{Emiten: "BBCA", ClosePrice: 9250}}, nil)
+	repo.On("GetDailyHistory", mock.Anything, mock.Anything).Return([]entities.MarketData{}, nil)
+	screens.On("CreateRun", mock.Anything).Return(nil)
+	screens.On("UpdateRunTimes", int64(1), mock.Anything, (*time.Time)(nil)).Return(nil)
+
+	run, err := uc.RunNow("user-1", 1)
+
+	require.NoError(t, err)
+	require.Len(t, run.Results, 1)
+	assert.Equal(t, "BBCA", run.Results[0].Emiten)
+}
+
+func TestScreenUseCase_Create_CombinesBoundsAndExpression(t *testing.T) {
+	screens := new(MockScreenRepository)
+	uc := usecases.NewScreenUseCase(screens, usecases.NewScreenerUseCase(new(MockMarketDataRepository)))
+
+	screens.On("Create", mock.Anything).Return(nil)
+
+	response, err := uc.Create("user-1", dto.ScreenRequest{
+		Name: "Falling knives",
+		Filter: dto.ScreenFilter{
+			MaxChangePct: float64Ptr(-0.000001),
+			Expression:   "rsi(14) < 30 or close < sma(20)",
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "rsi(14) < 30 or close < sma(20)", response.Filter.Expression)
+	screens.AssertCalled(t, "Create", mock.Anything)
+}
+
+func TestScreenUseCase_RunDue(t *testing.T) {
+	repo := new(MockMarketDataRepository)
+	screens := new(MockScreenRepository)
+	uc := usecases.NewScreenUseCase(screens, usecases.NewScreenerUseCase(repo))
+
+	// Monday 08:45 WIB; the next run is Tuesday 08:45 WIB
+	now := time.Date(2026, time.March, 2, 1, 45, 0, 0, time.UTC)
+	next := time.Date(2026, time.March, 3, 1, 45, 0, 0, time.UTC)
+	screens.On("FindDue", now).Return([]entities.Screen{
+		{ID: 1, Schedule: "45 8 * * 1-5", Enabled: true, Filter: entities.ScreenFilter{MinPrice: float64Ptr(5000)}},
+		{ID: 2, Schedule: "45 8 * * 1-5", Enabled: true, Filter: entities.ScreenFilter{Expression: "close >"}},
+	}, nil)
+	repo.On("GetLatestByAllEmiten").Return([]entities.MarketData{
+		{Emiten: "BBCA", ClosePrice: 9250},
+		{Emiten: "TLKM", ClosePrice: 3150},
+	}, nil)
+	repo.On("GetDailyHistory", mock.Anything, mock.Anything).Return([]entities.MarketData{}, nil)
+	var run *entities.ScreenRun
+	screens.On("CreateRun", mock.Anything).Run(func(args mock.Arguments) {
+		run = args.Get(0).(*entities.ScreenRun)
+	}).Return(nil)
+	screens.On("UpdateRunTimes", mock.Anything, now, mock.Anything).Return(nil)
+
+	require.NoError(t, uc.RunDue(now))
+
+	require.NotNil(t, run)
+	assert.Equal(t, int64(1), run.ScreenID)
+	assert.Equal(t, entities.ScreenTriggerSchedule, run.Trigger)
+	assert.Equal(t, []string{"BBCA"}, run.Emitens())
+	// The broken screen is rescheduled rather than retried on every tick
+	for _, id := range []int64{1, 2} {
+		screens.AssertCalled(t, "UpdateRunTimes", id, now, mock.MatchedBy(func(at *time.Time) bool {
+			return at != nil && at.Equal(next)
+		}))
+	}
+}
+
+func TestScreenUseCase_Diff_LatestRun(t *testing.T) {
+	screens := new(MockScreenRepository)
+	uc := usecases.NewScreenUseCase(screens, usecases.NewScreenerUseCase(new(MockMarketDataRepository)))
+
+	results := func(emitens ...string) []entities.ScreenResult {
+		list := make([]entities.ScreenResult, len(emitens))
+		for i, e := range emitens {
+			list[i] = entities.ScreenResult{Emiten: e}
+		}
+		return list
+	}
+	screens.On("FindByID", int64(1)).Return(&entities.Screen{ID: 1, UserID: "user-1"}, nil)
+	screens.On("FindRuns", int64(1), 1).Return([]entities.ScreenRun{{ID: 12, ScreenID: 1, Results: results("TLKM", "BBCA", "ASII")}}, nil)
+	screens.On("FindPreviousRun", int64(1), int64(12)).Return(&entities.ScreenRun{ID: 11, ScreenID: 1, Results: results("BBRI", "BBCA")}, nil)
+
+	diff, err := uc.Diff("user-1", 1, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), diff.Run.ID)
+	require.NotNil(t, diff.PreviousRun)
+	assert.Equal(t, int64(11), diff.PreviousRun.ID)
+	assert.Equal(t, []string{"ASII", "TLKM"}, diff.Entered)
+	assert.Equal(t, []string{"BBRI"}, diff.Left)
+	assert.Equal(t, []string{"BBCA"}, diff.Unchanged)
+}
+
+func TestScreenUseCase_Diff_FirstRun(t *testing.T) {
+	screens := new(MockScreenRepository)
+	uc := usecases.NewScreenUseCase(screens, usecases.NewScreenerUseCase(new(MockMarketDataRepository)))
+
+	screens.On("FindByID", int64(1)).Return(&entities.Screen{ID: 1, UserID: "user-1"}, nil)
+	screens.On("FindRun", int64(1), int64(5)).Return(&entities.ScreenRun{ID: 5, ScreenID: 1, Results: []entities.ScreenResult{{Emiten: "BBCA"}}}, nil)
+	screens.On("FindPreviousRun", int64(1), int64(5)).Return(nil, nil)
+
+	diff, err := uc.Diff("user-1", 1, 5)
+
+	require.NoError(t, err)
+	assert.Nil(t, diff.PreviousRun)
+	assert.Equal(t, []string{"BBCA"}, diff.Entered)
+	assert.Empty(t, diff.Left)
+}
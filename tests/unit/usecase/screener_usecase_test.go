@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/application/usecases"
+	"api-web-scrapping/internal/domain/entities"
+	"api-web-scrapping/internal/domain/services"
+)
+
+func TestScreenerUseCase_Run_CapsSettlingHistory(t *testing.T) {
+	repo := new(MockMarketDataRepository)
+	uc := usecases.NewScreenerUseCase(repo)
+
+	var from time.Time
+	repo.On("GetLatestByAllEmiten").Return([]entities.MarketData{{Emiten: "BBCA", ClosePrice: 9250}}, nil)
+	repo.On("GetDailyHistory", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		from = args.Get(0).(time.Time)
+	}).Return([]entities.MarketData{}, nil)
+
+	_, err := uc.Run(dto.ScreenerRequest{Expression: "rsi(250) < 30"})
+	require.NoError(t, err)
+
+	// 250 sessions, at most 100 more to settle and one more, in calendar days
+	sessions := 250 + 100 + 1
+	days := time.Since(from).Hours() / 24
+	assert.InDelta(t, float64(sessions*7/5+14), days, 1.5)
+}
+
+func TestScreenerUseCase_Run_OrWithUnavailableSide(t *testing.T) {
+	repo := new(MockMarketDataRepository)
+	uc := usecases.NewScreenerUseCase(repo)
+
+	latest := entities.MarketData{
+		Emiten:     "GOTO",
+		Date:       time.Now(),
+		OpenPrice:  148,
+		HighPrice:  152,
+		LowPrice:   147,
+		ClosePrice: 150,
+	}
+	repo.On("GetLatestByAllEmiten").Return([]entities.MarketData{latest}, nil)
+	repo.On("GetDailyHistory", mock.Anything, mock.Anything).Return([]entities.MarketData{}, nil)
+
+	resp, err := uc.Run(dto.ScreenerRequest{Expression: "rsi(14) < 30 or close > 100"})
+	require.NoError(t, err)
+
+	require.Len(t, resp.Data, 1)
+	assert.Equal(t, "GOTO", resp.Data[0].Emiten)
+	assert.Nil(t, resp.Data[0].Fields["rsi(14)"])
+	require.NotNil(t, resp.Data[0].Fields["close"])
+	assert.Equal(t, 150.0, *resp.Data[0].Fields["close"])
+}
+
+func TestScreenerUseCase_Run_ScreensUnquarantinedSession(t *testing.T) {
+	repo := new(MockMarketDataRepository)
+	uc := usecases.NewScreenerUseCase(repo)
+
+	session := services.BucketStart(time.Now(), entities.CandleInterval1d)
+	onTick := entities.MarketData{ID: 1, Emiten: "GOTO", Date: session.Add(2 * time.Hour), OpenPrice: 148, HighPrice: 150, LowPrice: 147, ClosePrice: 150}
+	// Scraped after the worker's last run, so the repository still rolls it in
+	offTick := entities.MarketData{ID: 2, Emiten: "GOTO", Date: session.Add(3 * time.Hour), OpenPrice: 148, HighPrice: 150.5, LowPrice: 147, ClosePrice: 150.5}
+	daily := offTick
+	daily.OpenPrice = onTick.OpenPrice
+
+	repo.On("GetLatestByAllEmiten").Return([]entities.MarketData{offTick}, nil)
+	repo.On("GetDailyHistory", mock.Anything, mock.Anything).Return([]entities.MarketData{daily}, nil)
+	repo.On("GetHistoryByEmiten", "GOTO", session, mock.Anything).Return([]entities.MarketData{onTick, offTick}, nil)
+
+	resp, err := uc.Run(dto.ScreenerRequest{Expression: "sma(1) > 0"})
+	require.NoError(t, err)
+
+	require.Len(t, resp.Data, 1)
+	require.NotNil(t, resp.Data[0].Fields["sma(1)"])
+	assert.Equal(t, 150.0, *resp.Data[0].Fields["sma(1)"])
+}
@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"api-web-scrapping/internal/application/dto"
+	"api-web-scrapping/internal/application/usecases"
+	"api-web-scrapping/internal/domain/entities"
+)
+
+type MockTradingStatusRepository struct {
+	mock.Mock
+}
+
+// noTradingStatuses returns a repository without status records
+func noTradingStatuses() *MockTradingStatusRepository {
+	repo := new(MockTradingStatusRepository)
+	repo.On("FindByRange", mock.Anything, mock.Anything).Return(nil, nil)
+	return repo
+}
+
+func (m *MockTradingStatusRepository) list(args mock.Arguments) ([]entities.TradingStatus, error) {
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.TradingStatus), args.Error(1)
+}
+
+func (m *MockTradingStatusRepository) Create(status *entities.TradingStatus) error {
+	return m.Called(status).Error(0)
+}
+
+func (m *MockTradingStatusRepository) Update(status *entities.TradingStatus) error {
+	return m.Called(status).Error(0)
+}
+
+func (m *MockTradingStatusRepository) Upsert(statuses []entities.TradingStatus) error {
+	return m.Called(statuses).Error(0)
+}
+
+func (m *MockTradingStatusRepository) Delete(id int64) error {
+	return m.Called(id).Error(0)
+}
+
+func (m *MockTradingStatusRepository) FindByID(id int64) (*entities.TradingStatus, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.TradingStatus), args.Error(1)
+}
+
+func (m *MockTradingStatusRepository) FindByEmiten(emiten string) ([]entities.TradingStatus, error) {
+	return m.list(m.Called(emiten))
+}
+
+func (m *MockTradingStatusRepository) FindByRange(from, to time.Time) ([]entities.TradingStatus, error) {
+	return m.list(m.Called(from, to))
+}
+
+func TestTradingStatusUseCase_Create_Overlap(t *testing.T) {
+	repo := new(MockTradingStatusRepository)
+	uc := usecases.NewTradingStatusUseCase(repo)
+
+	repo.On("FindByEmiten", "SRIL").Return([]entities.TradingStatus{{
+		ID:            4,
+		Emiten:        "SRIL",
+		Status:        entities.EmitenStatusSuspended,
+		EffectiveFrom: time.Date(2026, time.February, 3, 0, 0, 0, 0, time.UTC),
+	}}, nil)
+
+	_, err := uc.Create(dto.TradingStatusRequest{Emiten: "sril", Status: "delisted", From: "2026-09-01"})
+
+	assert.ErrorIs(t, err, usecases.ErrTradingStatusOverlap)
+	repo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestTradingStatusUseCase_Current(t *testing.T) {
+	repo := new(MockTradingStatusRepository)
+	uc := usecases.NewTradingStatusUseCase(repo)
+
+	to := time.Date(2026, time.March, 31, 0, 0, 0, 0, time.UTC)
+	repo.On("FindByEmiten", "GOTO").Return([]entities.TradingStatus{{
+		ID:            9,
+		Emiten:        "GOTO",
+		Status:        entities.EmitenStatusActive,
+		Notations:     []string{"X"},
+		Watchlist:     true,
+		EffectiveFrom: time.Date(2026, time.January, 2, 0, 0, 0, 0, time.UTC),
+		EffectiveTo:   &to,
+	}}, nil)
+
+	during, err := uc.Current("GOTO", time.Date(2026, time.March, 31, 15, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.True(t, during.Flags.Watchlist)
+	require.NotNil(t, during.Record)
+	assert.Equal(t, "2026-03-31", *during.Record.To)
+
+	// 2026-03-31 17:00 UTC is already April 1 in WIB
+	after, err := uc.Current("GOTO", time.Date(2026, time.March, 31, 17, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Nil(t, after.Record)
+	assert.Equal(t, entities.EmitenStatusActive, after.Flags.Status)
+	assert.False(t, after.Flags.Watchlist)
+}
+
+func TestTradingStatusUseCase_Import(t *testing.T) {
+	repo := new(MockTradingStatusRepository)
+	uc := usecases.NewTradingStatusUseCase(repo)
+
+	repo.On("FindByEmiten", mock.Anything).Return(nil, nil)
+	var saved []entities.TradingStatus
+	repo.On("Upsert", mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(0).([]entities.TradingStatus)
+	}).Return(nil)
+
+	csv := "emiten,status,notations,watchlist,from,to,reason\n" +
+		"SRIL,suspended,E X,false,2026-02-03,,Going concern\n" +
+		"GOTO,,x,true,2026-01-02,2026-03-31,\n" +
+		"BBCA,halted,,,2026-01-02,,\n" +
+		"SRIL,delisted,,,2026-09-01,,\n"
+
+	result, err := uc.Import(strings.NewReader(csv))
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Imported)
+	assert.Equal(t, 2, result.Failed)
+	assert.Equal(t, 4, result.Errors[0].Line)
+	assert.Equal(t, 5, result.Errors[1].Line)
+	require.Len(t, saved, 2)
+	assert.Equal(t, []string{"E", "X"}, saved[0].Notations)
+	assert.Equal(t, entities.EmitenStatusActive, saved[1].Status)
+	assert.True(t, saved[1].Watchlist)
+}